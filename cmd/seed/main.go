@@ -0,0 +1,42 @@
+package main
+
+// main.goはサンプルデータを投入するコマンド
+// 設定されたデータの保存先（DATABASE_DRIVER / APP_ENV）にサンプルのユーザー・ジャンル・問題・回答履歴を投入する
+
+import (
+	"context"
+	"log"
+
+	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/infrastructure/di"
+	"Shittaka_back/internal/infrastructure/postgrest"
+	"Shittaka_back/internal/infrastructure/seed"
+)
+
+func main() {
+	cfg := config.LoadConfig()
+	if cfg.AppEnv == "memory" {
+		log.Printf("APP_ENV=memory: seeded data is discarded when this command exits (use SEED_ON_START=true with the server instead)")
+	}
+
+	httpClient := postgrest.NewHTTPClient()
+	defer httpClient.CloseIdleConnections()
+
+	repos, err := di.NewRepositories(cfg, httpClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer repos.Close()
+
+	fixtures, err := seed.LoadFixtures()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := di.NewSeeder(cfg, repos).Run(context.Background(), fixtures)
+	if err != nil {
+		log.Fatalf("Seeding failed: %v", err)
+	}
+	log.Printf("Seeded into %s: %d users, %d genres, %d questions, %d choices, %d answers created",
+		cfg.DatabaseDriver, result.Users, result.Genres, result.Questions, result.Choices, result.Answers)
+}
@@ -3,29 +3,98 @@ package main
 // main.goはサーバー起動のメインファイル
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"Shittaka_back/internal/infrastructure/config"
 	"Shittaka_back/internal/infrastructure/di"
-	"Shittaka_back/internal/presentation/http/router"
+	"Shittaka_back/internal/infrastructure/logging"
 )
 
 func main() {
-	// DIコンテナを初期化
-	authContainer := di.NewContainer()
-	genreHandler := di.NewGenreHandler()
-	questionHandler := di.NewQuestionHandler()
-	answerHandler := di.NewAnswerHandler()
-	choiceHandler := di.NewChoiceHandler()
+	// server migrate up|down|status はマイグレーションだけを実行して終了する
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
+	// 設定を読み込み、全ての機能を配線する
+	cfg := config.LoadConfig()
 
-	log.Printf("Server starting on port %s", authContainer.Config.Port)
-	log.Printf("Supabase URL: %s", authContainer.Config.SupabaseURL)
+	// 構造化ログ（log/slog）を既定のロガーにし、log.Printf の出力も同じ形式にする
+	logger, err := logging.New(os.Stdout, cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	container, err := di.New(cfg, di.WithLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	// ルーターを設定
-	mux := router.SetupRoutes(authContainer.AuthHandler, genreHandler, questionHandler, answerHandler, choiceHandler)
+	log.Printf("Server starting on port %s", cfg.Port)
+	if cfg.AppEnv == "memory" {
+		log.Printf("APP_ENV=memory: data is kept in memory and lost on restart")
+	} else {
+		log.Printf("Supabase URL: %s", cfg.SupabaseURL)
+	}
+	log.Printf("Database driver: %s", cfg.DatabaseDriver)
+
+	// SIGINT / SIGTERM で ctx が終了し、バックグラウンド処理とサーバーを止める
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 起動時の確認・サンプルデータの投入・バックグラウンド処理の起動
+	if err := container.Start(ctx); err != nil {
+		log.Fatalf("Startup failed: %v", err)
+	}
 
-	// サーバーを起動
-	if err := http.ListenAndServe(":"+authContainer.Config.Port, mux); err != nil {
+	server := newHTTPServer(cfg, container.Router(), logger)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		// 起動に失敗した場合も、バックグラウンド処理は止めてから終了する
+		if shutdownErr := container.Shutdown(context.Background()); shutdownErr != nil {
+			log.Printf("Shutdown error: %v", shutdownErr)
+		}
 		log.Fatal("Server failed to start:", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	// 新しい接続の受け付けを止め、処理中のリクエスト（回答の送信など）が終わるのを待つ
+	log.Printf("Shutting down (waiting up to %s for in-flight requests)", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown error: %v", err)
+	}
+	if err := container.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Shutdown error: %v", err)
+	}
+	log.Printf("Server stopped")
+}
+
+// newHTTPServer は設定のタイムアウトを適用したHTTPサーバーを作成
+func newHTTPServer(cfg *config.Config, handler http.Handler, logger *slog.Logger) *http.Server {
+	return &http.Server{
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ServerReadHeaderTimeout,
+		ReadTimeout:       cfg.ServerReadTimeout,
+		WriteTimeout:      cfg.ServerWriteTimeout,
+		IdleTimeout:       cfg.ServerIdleTimeout,
 	}
 }
@@ -4,15 +4,24 @@ import "time"
 
 // CreateAnswerRequest は回答作成リクエストDTO
 type CreateAnswerRequest struct {
-	QuestionID int64 `json:"question_id"`
-	ChoiceID   int64 `json:"choice_id"`
+	QuestionID    int64    `json:"question_id"`
+	ChoiceID      int64    `json:"choice_id"`
+	ChoiceIDs     []int64  `json:"choice_ids"`
+	TextAnswer    string   `json:"text_answer"`
+	NumericAnswer *float64 `json:"numeric_answer"`
 }
 
 // AnswerResponse は回答レスポンスDTO
 type AnswerResponse struct {
-	ID         int64     `json:"id"`
-	UserID     string    `json:"user_id"`
-	QuestionID int64     `json:"question_id"`
-	ChoiceID   int64     `json:"choice_id"`
-	AnsweredAt time.Time `json:"answered_at"`
-}
\ No newline at end of file
+	ID               int64     `json:"id"`
+	UserID           string    `json:"user_id"`
+	QuestionID       int64     `json:"question_id"`
+	ChoiceID         int64     `json:"choice_id"`
+	ChoiceIDs        []int64   `json:"choice_ids"`
+	TextAnswer       string    `json:"text_answer"`
+	NumericAnswer    *float64  `json:"numeric_answer"`
+	IsCorrect        bool      `json:"is_correct"`
+	Score            float64   `json:"score"`
+	AnsweredAt       time.Time `json:"answered_at"`
+	QuestionRevision int       `json:"question_revision"`
+}
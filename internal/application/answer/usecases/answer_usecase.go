@@ -6,18 +6,26 @@ import (
 	"Shittaka_back/internal/application/answer/dto"
 	"Shittaka_back/internal/domain/answer/entities"
 	"Shittaka_back/internal/domain/answer/repositories"
+	"Shittaka_back/internal/domain/answer/services"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
 	"Shittaka_back/internal/domain/shared"
 )
 
 // AnswerUsecase は回答ユースケース
 type AnswerUsecase struct {
-	answerRepo repositories.AnswerRepository
+	answerRepo   repositories.AnswerRepository
+	questionRepo questionRepositories.QuestionRepository
+	choiceRepo   choiceRepositories.ChoiceRepository
 }
 
 // NewAnswerUsecase は新しいAnswerUsecaseを作成
-func NewAnswerUsecase(answerRepo repositories.AnswerRepository) *AnswerUsecase {
+func NewAnswerUsecase(answerRepo repositories.AnswerRepository, questionRepo questionRepositories.QuestionRepository, choiceRepo choiceRepositories.ChoiceRepository) *AnswerUsecase {
 	return &AnswerUsecase{
-		answerRepo: answerRepo,
+		answerRepo:   answerRepo,
+		questionRepo: questionRepo,
+		choiceRepo:   choiceRepo,
 	}
 }
 
@@ -28,14 +36,42 @@ func (u *AnswerUsecase) CreateAnswer(ctx context.Context, req dto.CreateAnswerRe
 		return nil, err
 	}
 
+	// 回答時点のリビジョンを記録するため問題を取得
+	question, err := u.questionRepo.GetByID(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 公開中の問題にのみ回答できる（通報で非公開になった問題は除く）
+	if !question.IsPubliclyVisible() {
+		return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	}
+
 	// 回答エンティティを作成
-	answer := entities.NewAnswer(userID, req.QuestionID, req.ChoiceID)
+	answer := entities.NewAnswer(userID, req.QuestionID, req.ChoiceID, question.Revision)
+	answer.ChoiceIDs = req.ChoiceIDs
+	answer.TextAnswer = req.TextAnswer
+	answer.NumericAnswer = req.NumericAnswer
 
 	// エンティティレベルでのバリデーション
 	if err := answer.Validate(); err != nil {
 		return nil, err
 	}
 
+	// 問題の種類に応じて採点
+	var choices []choiceEntities.Choice
+	if question.UsesChoices() {
+		choices, err = u.choiceRepo.GetByQuestionID(ctx, question.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result, err := services.Grade(question, choices, answer)
+	if err != nil {
+		return nil, err
+	}
+	answer.SetGrade(result.IsCorrect, result.Score)
+
 	// リポジトリに保存（ユーザートークンを渡してRLS適用）
 	createdAnswer, err := u.answerRepo.Create(ctx, answer, userToken)
 	if err != nil {
@@ -43,13 +79,7 @@ func (u *AnswerUsecase) CreateAnswer(ctx context.Context, req dto.CreateAnswerRe
 	}
 
 	// レスポンスDTOに変換
-	return &dto.AnswerResponse{
-		ID:         createdAnswer.ID,
-		UserID:     createdAnswer.UserID,
-		QuestionID: createdAnswer.QuestionID,
-		ChoiceID:   createdAnswer.ChoiceID,
-		AnsweredAt: createdAnswer.AnsweredAt,
-	}, nil
+	return u.toAnswerResponse(createdAnswer), nil
 }
 
 // GetAnswersByUser はユーザーの回答一覧を取得する
@@ -62,13 +92,7 @@ func (u *AnswerUsecase) GetAnswersByUser(ctx context.Context, userID string) ([]
 	// レスポンスDTOに変換
 	responses := make([]*dto.AnswerResponse, len(answers))
 	for i, answer := range answers {
-		responses[i] = &dto.AnswerResponse{
-			ID:         answer.ID,
-			UserID:     answer.UserID,
-			QuestionID: answer.QuestionID,
-			ChoiceID:   answer.ChoiceID,
-			AnsweredAt: answer.AnsweredAt,
-		}
+		responses[i] = u.toAnswerResponse(answer)
 	}
 
 	return responses, nil
@@ -84,13 +108,7 @@ func (u *AnswerUsecase) GetAnswersByQuestion(ctx context.Context, questionID int
 	// レスポンスDTOに変換
 	responses := make([]*dto.AnswerResponse, len(answers))
 	for i, answer := range answers {
-		responses[i] = &dto.AnswerResponse{
-			ID:         answer.ID,
-			UserID:     answer.UserID,
-			QuestionID: answer.QuestionID,
-			ChoiceID:   answer.ChoiceID,
-			AnsweredAt: answer.AnsweredAt,
-		}
+		responses[i] = u.toAnswerResponse(answer)
 	}
 
 	return responses, nil
@@ -102,9 +120,26 @@ func (u *AnswerUsecase) validateCreateAnswerRequest(req dto.CreateAnswerRequest)
 		return shared.NewValidationError("question_id", "問題IDは必須です")
 	}
 
-	if req.ChoiceID == 0 {
-		return shared.NewValidationError("choice_id", "選択肢IDは必須です")
+	if req.ChoiceID == 0 && len(req.ChoiceIDs) == 0 && req.TextAnswer == "" && req.NumericAnswer == nil {
+		return shared.NewValidationError("answer", "回答内容は必須です")
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// toAnswerResponse はAnswerエンティティをレスポンスDTOに変換
+func (u *AnswerUsecase) toAnswerResponse(answer *entities.Answer) *dto.AnswerResponse {
+	return &dto.AnswerResponse{
+		ID:               answer.ID,
+		UserID:           answer.UserID,
+		QuestionID:       answer.QuestionID,
+		ChoiceID:         answer.ChoiceID,
+		ChoiceIDs:        answer.ChoiceIDs,
+		TextAnswer:       answer.TextAnswer,
+		NumericAnswer:    answer.NumericAnswer,
+		IsCorrect:        answer.IsCorrect,
+		Score:            answer.Score,
+		AnsweredAt:       answer.AnsweredAt,
+		QuestionRevision: answer.QuestionRevision,
+	}
+}
@@ -0,0 +1,44 @@
+package dto
+
+// attachment_dto.goは添付画像関連のデータ転送オブジェクトを定義
+
+import (
+	"time"
+
+	"Shittaka_back/internal/domain/attachment/entities"
+)
+
+// UploadAttachmentRequest は添付画像アップロードリクエスト
+type UploadAttachmentRequest struct {
+	QuestionID int64
+	ChoiceID   *int64 // 選択肢に添付する場合のみ指定
+	Data       []byte
+}
+
+// AttachmentResponse は添付画像レスポンス
+type AttachmentResponse struct {
+	ID          int64     `json:"id"`
+	QuestionID  int64     `json:"question_id"`
+	ChoiceID    *int64    `json:"choice_id"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewAttachmentResponse はAttachmentエンティティをレスポンスDTOに変換
+func NewAttachmentResponse(attachment *entities.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:          attachment.ID,
+		QuestionID:  attachment.QuestionID,
+		ChoiceID:    attachment.ChoiceID,
+		URL:         attachment.URL,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Width:       attachment.Width,
+		Height:      attachment.Height,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
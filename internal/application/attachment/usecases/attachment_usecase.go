@@ -0,0 +1,143 @@
+package usecases
+
+// attachment_usecase.goは問題・選択肢への画像添付のユースケースを定義
+
+import (
+	"context"
+	"log"
+
+	"Shittaka_back/internal/application/attachment/dto"
+	"Shittaka_back/internal/domain/attachment/entities"
+	"Shittaka_back/internal/domain/attachment/repositories"
+	"Shittaka_back/internal/domain/attachment/services"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// AttachmentUsecase は添付画像ユースケース
+type AttachmentUsecase struct {
+	attachmentRepo repositories.AttachmentRepository
+	storage        repositories.FileStorage
+	questionRepo   questionRepositories.QuestionRepository
+	choiceRepo     choiceRepositories.ChoiceRepository
+	limits         services.ImageLimits
+}
+
+// NewAttachmentUsecase は新しいAttachmentUsecaseを作成
+func NewAttachmentUsecase(attachmentRepo repositories.AttachmentRepository, storage repositories.FileStorage, questionRepo questionRepositories.QuestionRepository, choiceRepo choiceRepositories.ChoiceRepository, limits services.ImageLimits) *AttachmentUsecase {
+	return &AttachmentUsecase{
+		attachmentRepo: attachmentRepo,
+		storage:        storage,
+		questionRepo:   questionRepo,
+		choiceRepo:     choiceRepo,
+		limits:         limits,
+	}
+}
+
+// MaxUploadBytes はアップロードできるファイルサイズの上限を返す
+func (u *AttachmentUsecase) MaxUploadBytes() int64 {
+	return u.limits.MaxBytes
+}
+
+// UploadAttachment は問題または選択肢に画像を添付する（問題の作成者のみ）
+func (u *AttachmentUsecase) UploadAttachment(ctx context.Context, req dto.UploadAttachmentRequest, userID string, userToken string) (*dto.AttachmentResponse, error) {
+	// 問題の存在と作成者のチェック
+	question, err := u.questionRepo.GetByID(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.UserID != userID {
+		return nil, shared.NewDomainError("FORBIDDEN", "この問題に画像を添付する権限がありません")
+	}
+
+	// 選択肢に添付する場合は、その問題の選択肢かどうかチェック
+	if req.ChoiceID != nil {
+		if err := u.ensureChoiceOfQuestion(ctx, req.QuestionID, *req.ChoiceID); err != nil {
+			return nil, err
+		}
+	}
+
+	// ファイルの中身を検証
+	info, err := services.ValidateImage(req.Data, u.limits)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := entities.NewAttachment(req.QuestionID, req.ChoiceID, userID, info.ContentType, int64(len(req.Data)), info.Width, info.Height)
+	if err := attachment.AssignStorageKey(); err != nil {
+		return nil, err
+	}
+	if err := attachment.Validate(); err != nil {
+		return nil, err
+	}
+
+	// ファイル本体を保存してからメタデータを登録する
+	url, err := u.storage.Put(ctx, attachment.StorageKey, attachment.ContentType, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	attachment.URL = url
+
+	created, err := u.attachmentRepo.Create(ctx, attachment, userToken)
+	if err != nil {
+		// 登録に失敗した場合は保存したファイルを残さない
+		if deleteErr := u.storage.Delete(ctx, attachment.StorageKey); deleteErr != nil {
+			log.Printf("Failed to clean up attachment file %s: %v", attachment.StorageKey, deleteErr)
+		}
+		return nil, err
+	}
+
+	return dto.NewAttachmentResponse(created), nil
+}
+
+// DeleteAttachment は添付画像を削除する（添付した本人のみ）
+func (u *AttachmentUsecase) DeleteAttachment(ctx context.Context, id int64, userID string, userToken string) error {
+	attachment, err := u.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.UserID != userID {
+		return shared.NewDomainError("FORBIDDEN", "この画像を削除する権限がありません")
+	}
+
+	if err := u.attachmentRepo.Delete(ctx, id, userToken); err != nil {
+		return err
+	}
+
+	// メタデータの削除後はファイルが残っても参照されないため、失敗はログに留める
+	if err := u.storage.Delete(ctx, attachment.StorageKey); err != nil {
+		log.Printf("Failed to delete attachment file %s: %v", attachment.StorageKey, err)
+	}
+	return nil
+}
+
+// GetChoiceAttachments は問題の選択肢に添付された画像を選択肢IDごとに取得する
+func (u *AttachmentUsecase) GetChoiceAttachments(ctx context.Context, questionID int64) (map[int64][]*dto.AttachmentResponse, error) {
+	attachments, err := u.attachmentRepo.GetByQuestionIDs(ctx, []int64{questionID})
+	if err != nil {
+		return nil, err
+	}
+
+	byChoice := make(map[int64][]*dto.AttachmentResponse)
+	for _, attachment := range attachments {
+		if attachment.IsForChoice() {
+			byChoice[*attachment.ChoiceID] = append(byChoice[*attachment.ChoiceID], dto.NewAttachmentResponse(attachment))
+		}
+	}
+	return byChoice, nil
+}
+
+// ensureChoiceOfQuestion は選択肢が指定した問題のものかどうかをチェックする
+func (u *AttachmentUsecase) ensureChoiceOfQuestion(ctx context.Context, questionID, choiceID int64) error {
+	choices, err := u.choiceRepo.GetByQuestionID(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	for _, choice := range choices {
+		if choice.ID == choiceID {
+			return nil
+		}
+	}
+	return shared.NewDomainError("NOT_FOUND", "選択肢が見つかりません")
+}
@@ -0,0 +1,26 @@
+package dto
+
+// bookmark_dto.goはブックマーク関連のデータ転送オブジェクトを定義
+
+import "time"
+
+// ListBookmarksRequest はブックマーク一覧取得リクエスト
+type ListBookmarksRequest struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// BookmarkResponse はブックマーク一覧の1件
+type BookmarkResponse struct {
+	QuestionID   int64     `json:"question_id"`
+	Title        string    `json:"title"`
+	BookmarkedAt time.Time `json:"bookmarked_at"`
+}
+
+// BookmarkListResponse はブックマーク一覧レスポンス
+type BookmarkListResponse struct {
+	Bookmarks []*BookmarkResponse `json:"bookmarks"`
+	Limit     int                 `json:"limit"`
+	Offset    int                 `json:"offset"`
+	HasMore   bool                `json:"has_more"`
+}
@@ -0,0 +1,120 @@
+package usecases
+
+// bookmark_usecase.goは問題のブックマークのユースケースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/application/bookmark/dto"
+	"Shittaka_back/internal/domain/bookmark/entities"
+	"Shittaka_back/internal/domain/bookmark/repositories"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+const (
+	// defaultBookmarkLimit は1ページあたりのブックマーク数の既定値
+	defaultBookmarkLimit = 20
+	// maxBookmarkLimit は1ページあたりのブックマーク数の上限
+	maxBookmarkLimit = 100
+)
+
+// BookmarkUsecase はブックマークユースケース
+type BookmarkUsecase struct {
+	bookmarkRepo repositories.BookmarkRepository
+	questionRepo questionRepositories.QuestionRepository
+}
+
+// NewBookmarkUsecase は新しいBookmarkUsecaseを作成
+func NewBookmarkUsecase(bookmarkRepo repositories.BookmarkRepository, questionRepo questionRepositories.QuestionRepository) *BookmarkUsecase {
+	return &BookmarkUsecase{
+		bookmarkRepo: bookmarkRepo,
+		questionRepo: questionRepo,
+	}
+}
+
+// AddBookmark は問題をブックマークする（認証が必要、既にブックマークしている場合も成功）
+// 閲覧できない問題（他のユーザーの下書きや非公開になった問題）はブックマークできない
+func (u *BookmarkUsecase) AddBookmark(ctx context.Context, userID string, questionID int64, userToken string) error {
+	question, err := u.questionRepo.GetByID(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !question.IsVisibleTo(userID) {
+		return shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	}
+
+	return u.bookmarkRepo.Add(ctx, entities.NewBookmark(userID, questionID), userToken)
+}
+
+// RemoveBookmark はブックマークを外す（認証が必要、ブックマークしていない場合も成功）
+func (u *BookmarkUsecase) RemoveBookmark(ctx context.Context, userID string, questionID int64, userToken string) error {
+	return u.bookmarkRepo.Remove(ctx, userID, questionID, userToken)
+}
+
+// ListBookmarks は自分のブックマークを新しい順に取得する（認証が必要）
+// ブックマークした後に閲覧できなくなった問題は一覧に含めない
+func (u *BookmarkUsecase) ListBookmarks(ctx context.Context, userID string, req dto.ListBookmarksRequest, userToken string) (*dto.BookmarkListResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultBookmarkLimit
+	}
+	if limit > maxBookmarkLimit {
+		limit = maxBookmarkLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 続きがあるか判定するため1件多く取得する
+	bookmarks, err := u.bookmarkRepo.GetByUserID(ctx, userID, limit+1, offset, userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(bookmarks) > limit
+	if hasMore {
+		bookmarks = bookmarks[:limit]
+	}
+
+	response := &dto.BookmarkListResponse{
+		Bookmarks: make([]*dto.BookmarkResponse, 0, len(bookmarks)),
+		Limit:     limit,
+		Offset:    offset,
+		HasMore:   hasMore,
+	}
+	if len(bookmarks) == 0 {
+		return response, nil
+	}
+
+	// ブックマークした問題をまとめて取得する
+	ids := make([]int64, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		ids[i] = bookmark.QuestionID
+	}
+	questions, err := u.questionRepo.GetPage(ctx, questionRepositories.QuestionPageQuery{IDs: ids, Limit: len(ids)}, userToken)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[int64]string, len(questions))
+	for _, question := range questions {
+		if question.IsVisibleTo(userID) {
+			titles[question.ID] = question.Title
+		}
+	}
+
+	for _, bookmark := range bookmarks {
+		title, ok := titles[bookmark.QuestionID]
+		if !ok {
+			continue
+		}
+		response.Bookmarks = append(response.Bookmarks, &dto.BookmarkResponse{
+			QuestionID:   bookmark.QuestionID,
+			Title:        title,
+			BookmarkedAt: bookmark.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"Shittaka_back/internal/application/bookmark/dto"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/shared"
+	bookmarkMemory "Shittaka_back/internal/infrastructure/bookmark/memory"
+	questionMemory "Shittaka_back/internal/infrastructure/question/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkUsecase_OnlyVisibleQuestions(t *testing.T) {
+	ctx := context.Background()
+	questionRepo := questionMemory.NewQuestionRepository(nil)
+	usecase := NewBookmarkUsecase(bookmarkMemory.NewBookmarkRepository(), questionRepo)
+
+	create := func(title, userID, status string) int64 {
+		question, err := questionRepo.Create(ctx, &questionEntities.Question{Title: title, UserID: userID, Status: status}, "")
+		require.NoError(t, err)
+		return question.ID
+	}
+	published := create("公開中の問題", "author", questionEntities.StatusPublished)
+	draft := create("他人の下書き", "author", questionEntities.StatusDraft)
+	own := create("自分の下書き", "reader", questionEntities.StatusDraft)
+
+	// 他のユーザーの下書きはブックマークできない
+	err := usecase.AddBookmark(ctx, "reader", draft, "")
+	require.Error(t, err)
+	assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code)
+
+	require.NoError(t, usecase.AddBookmark(ctx, "reader", published, ""))
+	require.NoError(t, usecase.AddBookmark(ctx, "reader", own, ""))
+	// 重複したブックマークは1件として扱う
+	require.NoError(t, usecase.AddBookmark(ctx, "reader", published, ""))
+
+	list, err := usecase.ListBookmarks(ctx, "reader", dto.ListBookmarksRequest{}, "")
+	require.NoError(t, err)
+	require.Len(t, list.Bookmarks, 2)
+	assert.Equal(t, own, list.Bookmarks[0].QuestionID)
+	assert.Equal(t, "公開中の問題", list.Bookmarks[1].Title)
+
+	// 通報で非公開になった問題は一覧に含めない
+	require.NoError(t, questionRepo.SetHidden(ctx, published, true))
+	list, err = usecase.ListBookmarks(ctx, "reader", dto.ListBookmarksRequest{}, "")
+	require.NoError(t, err)
+	require.Len(t, list.Bookmarks, 1)
+	assert.Equal(t, own, list.Bookmarks[0].QuestionID)
+
+	require.NoError(t, usecase.RemoveBookmark(ctx, "reader", own, ""))
+	list, err = usecase.ListBookmarks(ctx, "reader", dto.ListBookmarksRequest{}, "")
+	require.NoError(t, err)
+	assert.Empty(t, list.Bookmarks)
+}
@@ -0,0 +1,45 @@
+package dto
+
+// comment_dto.goはコメント関連のデータ転送オブジェクトを定義
+
+import "time"
+
+// CreateCommentRequest はコメント作成リクエスト
+type CreateCommentRequest struct {
+	ParentID *int64 `json:"parent_id"`
+	Body     string `json:"body"`
+}
+
+// UpdateCommentRequest はコメント更新リクエスト
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// ListCommentsRequest はコメント一覧取得リクエスト
+type ListCommentsRequest struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// CommentResponse はコメントレスポンス
+type CommentResponse struct {
+	ID         int64              `json:"id"`
+	QuestionID int64              `json:"question_id"`
+	UserID     string             `json:"user_id"`
+	ParentID   *int64             `json:"parent_id"`
+	Body       string             `json:"body"`
+	IsHidden   bool               `json:"is_hidden"`
+	IsDeleted  bool               `json:"is_deleted"`
+	IsEdited   bool               `json:"is_edited"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	Replies    []*CommentResponse `json:"replies"`
+}
+
+// CommentListResponse はコメント一覧レスポンス
+type CommentListResponse struct {
+	Comments []*CommentResponse `json:"comments"`
+	Limit    int                `json:"limit"`
+	Offset   int                `json:"offset"`
+	HasMore  bool               `json:"has_more"`
+}
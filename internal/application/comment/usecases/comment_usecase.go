@@ -0,0 +1,290 @@
+package usecases
+
+// comment_usecase.goはコメント関連のユースケースを定義
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"Shittaka_back/internal/application/comment/dto"
+	answerRepositories "Shittaka_back/internal/domain/answer/repositories"
+	"Shittaka_back/internal/domain/comment/entities"
+	"Shittaka_back/internal/domain/comment/repositories"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+const (
+	// defaultCommentLimit は1ページあたりのスレッド数の既定値
+	defaultCommentLimit = 20
+	// maxCommentLimit は1ページあたりのスレッド数の上限
+	maxCommentLimit = 100
+)
+
+// CommentUsecase はコメントユースケース
+type CommentUsecase struct {
+	commentRepo  repositories.CommentRepository
+	answerRepo   answerRepositories.AnswerRepository
+	questionRepo questionRepositories.QuestionRepository
+}
+
+// NewCommentUsecase は新しいCommentUsecaseを作成
+func NewCommentUsecase(commentRepo repositories.CommentRepository, answerRepo answerRepositories.AnswerRepository, questionRepo questionRepositories.QuestionRepository) *CommentUsecase {
+	return &CommentUsecase{
+		commentRepo:  commentRepo,
+		answerRepo:   answerRepo,
+		questionRepo: questionRepo,
+	}
+}
+
+// CreateComment は問題にコメントを投稿する（回答済みのユーザーと問題の作成者のみ）
+func (u *CommentUsecase) CreateComment(ctx context.Context, questionID int64, req dto.CreateCommentRequest, userID string, userToken string) (*dto.CommentResponse, error) {
+	// バリデーション
+	if err := u.validateBody(req.Body); err != nil {
+		return nil, err
+	}
+
+	question, err := u.getVisibleQuestion(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 回答前のユーザーは解説をネタバレしないよう投稿不可
+	answered, err := u.canReadComments(ctx, question, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !answered {
+		return nil, shared.NewDomainError("NOT_ANSWERED", "問題に回答してからコメントしてください")
+	}
+
+	// 返信の場合は親コメントをチェック（返信は1階層まで）
+	if req.ParentID != nil {
+		parent, err := u.commentRepo.GetByID(ctx, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.QuestionID != questionID {
+			return nil, shared.NewValidationError("parent_id", "返信先のコメントが別の問題に属しています")
+		}
+		if parent.IsReply() {
+			return nil, shared.NewValidationError("parent_id", "返信に対して返信することはできません")
+		}
+		if parent.IsDeleted() {
+			return nil, shared.NewValidationError("parent_id", "削除されたコメントには返信できません")
+		}
+	}
+
+	// コメントエンティティを作成
+	comment := entities.NewComment(questionID, userID, req.ParentID, req.Body)
+
+	// エンティティレベルでのバリデーション
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+
+	// リポジトリに保存（ユーザートークンを渡してRLS適用）
+	createdComment, err := u.commentRepo.Create(ctx, comment, userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toCommentResponse(createdComment, true), nil
+}
+
+// UpdateComment はコメントを編集する（作成者のみ・投稿から一定時間内）
+func (u *CommentUsecase) UpdateComment(ctx context.Context, id int64, req dto.UpdateCommentRequest, userID string, userToken string) error {
+	// バリデーション
+	if err := u.validateBody(req.Body); err != nil {
+		return err
+	}
+
+	// 既存のコメントを取得
+	existingComment, err := u.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existingComment.IsDeleted() {
+		return shared.NewDomainError("NOT_FOUND", "コメントが見つかりません")
+	}
+
+	// 作成者かどうかチェック
+	if existingComment.UserID != userID {
+		return shared.NewDomainError("FORBIDDEN", "このコメントを更新する権限がありません")
+	}
+
+	// 編集可能期間かどうかチェック
+	now := time.Now()
+	if !existingComment.CanEdit(now) {
+		return shared.NewDomainError("EDIT_WINDOW_EXPIRED", "コメントの編集期間を過ぎています")
+	}
+
+	existingComment.Edit(req.Body, now)
+
+	// リポジトリで更新
+	return u.commentRepo.Update(ctx, existingComment, userToken)
+}
+
+// DeleteComment はコメントを論理削除する（作成者のみ）
+func (u *CommentUsecase) DeleteComment(ctx context.Context, id int64, userID string, userToken string) error {
+	// 既存のコメントを取得
+	existingComment, err := u.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existingComment.IsDeleted() {
+		return shared.NewDomainError("NOT_FOUND", "コメントが見つかりません")
+	}
+
+	// 作成者かどうかチェック
+	if existingComment.UserID != userID {
+		return shared.NewDomainError("FORBIDDEN", "このコメントを削除する権限がありません")
+	}
+
+	existingComment.SoftDelete(time.Now())
+
+	// リポジトリで論理削除
+	return u.commentRepo.SoftDelete(ctx, existingComment, userToken)
+}
+
+// GetComments は問題のコメントスレッドを取得する
+// 閲覧できない問題の場合は NOT_FOUND を返す
+// viewerID が空、または閲覧者が未回答（問題の作成者を除く）の場合は本文を伏せて返す
+func (u *CommentUsecase) GetComments(ctx context.Context, questionID int64, req dto.ListCommentsRequest, viewerID string) (*dto.CommentListResponse, error) {
+	question, err := u.getVisibleQuestion(ctx, questionID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := u.normalizePage(req)
+
+	// 次ページの有無を判定するため1件多く取得
+	roots, err := u.commentRepo.GetRootsByQuestionID(ctx, questionID, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(roots) > limit
+	if hasMore {
+		roots = roots[:limit]
+	}
+
+	// 返信をまとめて取得
+	rootIDs := make([]int64, len(roots))
+	for i, root := range roots {
+		rootIDs[i] = root.ID
+	}
+	var replies []*entities.Comment
+	if len(rootIDs) > 0 {
+		replies, err = u.commentRepo.GetRepliesByParentIDs(ctx, rootIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 閲覧者が本文を読めるかどうか
+	visible, err := u.canReadComments(ctx, question, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// レスポンスDTOに変換
+	responses := make([]*dto.CommentResponse, len(roots))
+	index := make(map[int64]*dto.CommentResponse, len(roots))
+	for i, root := range roots {
+		responses[i] = u.toCommentResponse(root, visible)
+		index[root.ID] = responses[i]
+	}
+	for _, reply := range replies {
+		if parent, ok := index[*reply.ParentID]; ok {
+			parent.Replies = append(parent.Replies, u.toCommentResponse(reply, visible))
+		}
+	}
+
+	return &dto.CommentListResponse{
+		Comments: responses,
+		Limit:    limit,
+		Offset:   offset,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// getVisibleQuestion は問題を取得し、閲覧者が見られない問題の場合は NOT_FOUND を返す
+func (u *CommentUsecase) getVisibleQuestion(ctx context.Context, questionID int64, viewerID string) (*questionEntities.Question, error) {
+	question, err := u.questionRepo.GetByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !question.IsVisibleTo(viewerID) {
+		return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	}
+	return question, nil
+}
+
+// canReadComments はユーザーがコメント本文を読み書きできるか（問題の作成者か回答済み）を返す
+func (u *CommentUsecase) canReadComments(ctx context.Context, question *questionEntities.Question, userID string) (bool, error) {
+	if userID == "" {
+		return false, nil
+	}
+	if question.UserID == userID {
+		return true, nil
+	}
+	return u.answerRepo.ExistsByUserAndQuestion(ctx, userID, question.ID)
+}
+
+// toCommentResponse はCommentエンティティをレスポンスDTOに変換
+func (u *CommentUsecase) toCommentResponse(comment *entities.Comment, visible bool) *dto.CommentResponse {
+	response := &dto.CommentResponse{
+		ID:         comment.ID,
+		QuestionID: comment.QuestionID,
+		UserID:     comment.UserID,
+		ParentID:   comment.ParentID,
+		Body:       comment.Body,
+		IsEdited:   comment.IsEdited(),
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+		Replies:    []*dto.CommentResponse{},
+	}
+
+	switch {
+	case comment.IsDeleted():
+		response.Body = ""
+		response.IsDeleted = true
+	case !visible:
+		response.Body = ""
+		response.IsHidden = true
+	}
+
+	return response
+}
+
+// normalizePage はページング指定を既定値・上限に収める
+func (u *CommentUsecase) normalizePage(req dto.ListCommentsRequest) (int, int) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultCommentLimit
+	}
+	if limit > maxCommentLimit {
+		limit = maxCommentLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// validateBody はコメント本文をバリデーション
+func (u *CommentUsecase) validateBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return shared.NewValidationError("body", "コメント本文は必須です")
+	}
+
+	if utf8.RuneCountInString(body) > entities.MaxCommentLength {
+		return shared.NewValidationError("body", "コメントは1000文字以内で入力してください")
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Shittaka_back/internal/application/comment/dto"
+	answerRepositories "Shittaka_back/internal/domain/answer/repositories"
+	"Shittaka_back/internal/domain/comment/entities"
+	"Shittaka_back/internal/domain/comment/repositories"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/comment/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testQuestionID = 1
+	// testAuthorID はテスト用の問題の作成者（回答していない）
+	testAuthorID = "dave"
+)
+
+// fakeAnsweredRepository は回答済みのユーザーだけを返すテスト用リポジトリ
+type fakeAnsweredRepository struct {
+	answerRepositories.AnswerRepository
+	answered map[string]bool
+}
+
+func (r *fakeAnsweredRepository) ExistsByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error) {
+	return questionID == testQuestionID && r.answered[userID], nil
+}
+
+// fakeQuestionRepository は登録した問題だけを返すテスト用リポジトリ
+type fakeQuestionRepository struct {
+	questionRepositories.QuestionRepository
+	questions map[int64]*questionEntities.Question
+}
+
+func (r *fakeQuestionRepository) GetByID(ctx context.Context, id int64) (*questionEntities.Question, error) {
+	question, ok := r.questions[id]
+	if !ok {
+		return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	}
+	return question, nil
+}
+
+// newTestQuestion は testAuthorID が作成した問題を返す
+func newTestQuestion(id int64, status string) *questionEntities.Question {
+	question := questionEntities.NewQuestion(1, testAuthorID, "タイトル", "本文", "")
+	question.ID = id
+	question.Status = status
+	return question
+}
+
+// agedCommentRepository は取得したコメントの投稿日時を age だけ過去にずらすテスト用リポジトリ
+type agedCommentRepository struct {
+	repositories.CommentRepository
+	age time.Duration
+}
+
+func (r *agedCommentRepository) GetByID(ctx context.Context, id int64) (*entities.Comment, error) {
+	comment, err := r.CommentRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	comment.CreatedAt = comment.CreatedAt.Add(-r.age)
+	return comment, nil
+}
+
+func newTestCommentUsecase(commentRepo repositories.CommentRepository) *CommentUsecase {
+	questionRepo := &fakeQuestionRepository{questions: map[int64]*questionEntities.Question{
+		testQuestionID: newTestQuestion(testQuestionID, questionEntities.StatusPublished),
+		2:              newTestQuestion(2, questionEntities.StatusDraft),
+	}}
+	return NewCommentUsecase(commentRepo, &fakeAnsweredRepository{answered: map[string]bool{"alice": true, "bob": true}}, questionRepo)
+}
+
+func TestCreateComment_RequiresAnswer(t *testing.T) {
+	usecase := newTestCommentUsecase(memory.NewCommentRepository())
+
+	_, err := usecase.CreateComment(context.Background(), testQuestionID, dto.CreateCommentRequest{Body: "答えは？"}, "carol", "")
+
+	require.Error(t, err)
+	assert.Equal(t, "NOT_ANSWERED", err.(shared.DomainError).Code)
+}
+
+func TestGetComments_HidesBodyUntilViewerAnswered(t *testing.T) {
+	ctx := context.Background()
+	usecase := newTestCommentUsecase(memory.NewCommentRepository())
+	root, err := usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{Body: "解説が分かりやすい"}, "alice", "")
+	require.NoError(t, err)
+	_, err = usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{ParentID: &root.ID, Body: "同感です"}, "bob", "")
+	require.NoError(t, err)
+
+	for _, viewerID := range []string{"", "carol"} {
+		list, err := usecase.GetComments(ctx, testQuestionID, dto.ListCommentsRequest{}, viewerID)
+		require.NoError(t, err)
+		require.Len(t, list.Comments, 1)
+		assert.True(t, list.Comments[0].IsHidden)
+		assert.Empty(t, list.Comments[0].Body)
+		require.Len(t, list.Comments[0].Replies, 1)
+		assert.True(t, list.Comments[0].Replies[0].IsHidden)
+		assert.Empty(t, list.Comments[0].Replies[0].Body)
+	}
+
+	list, err := usecase.GetComments(ctx, testQuestionID, dto.ListCommentsRequest{}, "bob")
+	require.NoError(t, err)
+	require.Len(t, list.Comments, 1)
+	assert.False(t, list.Comments[0].IsHidden)
+	assert.Equal(t, "解説が分かりやすい", list.Comments[0].Body)
+	require.Len(t, list.Comments[0].Replies, 1)
+	assert.Equal(t, "同感です", list.Comments[0].Replies[0].Body)
+}
+
+func TestGetComments_AuthorCanReadWithoutAnswering(t *testing.T) {
+	ctx := context.Background()
+	usecase := newTestCommentUsecase(memory.NewCommentRepository())
+	_, err := usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{Body: "解説が分かりやすい"}, "alice", "")
+	require.NoError(t, err)
+
+	list, err := usecase.GetComments(ctx, testQuestionID, dto.ListCommentsRequest{}, testAuthorID)
+	require.NoError(t, err)
+	require.Len(t, list.Comments, 1)
+	assert.False(t, list.Comments[0].IsHidden)
+	assert.Equal(t, "解説が分かりやすい", list.Comments[0].Body)
+}
+
+func TestComments_HiddenQuestionIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	usecase := newTestCommentUsecase(memory.NewCommentRepository())
+
+	// 他のユーザーの下書きのコメントは読めず、投稿もできない
+	_, err := usecase.GetComments(ctx, 2, dto.ListCommentsRequest{}, "alice")
+	require.Error(t, err)
+	assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code)
+
+	_, err = usecase.CreateComment(ctx, 2, dto.CreateCommentRequest{Body: "下書きへのコメント"}, "alice", "")
+	require.Error(t, err)
+	assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code)
+
+	// 作成者は自分の下書きのコメントを読める
+	_, err = usecase.GetComments(ctx, 2, dto.ListCommentsRequest{}, testAuthorID)
+	require.NoError(t, err)
+}
+
+func TestCreateComment_RepliesAreOneLevelDeep(t *testing.T) {
+	ctx := context.Background()
+	usecase := newTestCommentUsecase(memory.NewCommentRepository())
+	root, err := usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{Body: "質問です"}, "alice", "")
+	require.NoError(t, err)
+	reply, err := usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{ParentID: &root.ID, Body: "回答です"}, "bob", "")
+	require.NoError(t, err)
+	assert.Equal(t, &root.ID, reply.ParentID)
+
+	_, err = usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{ParentID: &reply.ID, Body: "さらに返信"}, "alice", "")
+
+	require.Error(t, err)
+	assert.Equal(t, "parent_id", err.(shared.ValidationError).Field)
+}
+
+func TestUpdateComment_EditWindow(t *testing.T) {
+	ctx := context.Background()
+	commentRepo := &agedCommentRepository{CommentRepository: memory.NewCommentRepository()}
+	usecase := newTestCommentUsecase(commentRepo)
+	created, err := usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{Body: "誤字あり"}, "alice", "")
+	require.NoError(t, err)
+
+	// 作成者以外は編集できない
+	err = usecase.UpdateComment(ctx, created.ID, dto.UpdateCommentRequest{Body: "乗っ取り"}, "bob", "")
+	require.Error(t, err)
+	assert.Equal(t, "FORBIDDEN", err.(shared.DomainError).Code)
+
+	// 15分以内は編集できる
+	commentRepo.age = entities.CommentEditWindow - time.Minute
+	require.NoError(t, usecase.UpdateComment(ctx, created.ID, dto.UpdateCommentRequest{Body: "誤字なし"}, "alice", ""))
+
+	stored, err := commentRepo.CommentRepository.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "誤字なし", stored.Body)
+
+	// 15分を過ぎると編集できない
+	commentRepo.age = entities.CommentEditWindow + time.Minute
+	err = usecase.UpdateComment(ctx, created.ID, dto.UpdateCommentRequest{Body: "再編集"}, "alice", "")
+	require.Error(t, err)
+	assert.Equal(t, "EDIT_WINDOW_EXPIRED", err.(shared.DomainError).Code)
+}
+
+func TestDeleteComment_SoftDeletesAndKeepsReplies(t *testing.T) {
+	ctx := context.Background()
+	usecase := newTestCommentUsecase(memory.NewCommentRepository())
+	root, err := usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{Body: "消す予定"}, "alice", "")
+	require.NoError(t, err)
+	_, err = usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{ParentID: &root.ID, Body: "返信"}, "bob", "")
+	require.NoError(t, err)
+
+	err = usecase.DeleteComment(ctx, root.ID, "bob", "")
+	require.Error(t, err)
+	assert.Equal(t, "FORBIDDEN", err.(shared.DomainError).Code)
+
+	require.NoError(t, usecase.DeleteComment(ctx, root.ID, "alice", ""))
+
+	// 削除済みのコメントは本文を伏せてスレッドに残り、返信は表示される
+	list, err := usecase.GetComments(ctx, testQuestionID, dto.ListCommentsRequest{}, "alice")
+	require.NoError(t, err)
+	require.Len(t, list.Comments, 1)
+	assert.True(t, list.Comments[0].IsDeleted)
+	assert.Empty(t, list.Comments[0].Body)
+	require.Len(t, list.Comments[0].Replies, 1)
+	assert.Equal(t, "返信", list.Comments[0].Replies[0].Body)
+
+	// 削除済みのコメントは編集・再削除・返信できない
+	err = usecase.UpdateComment(ctx, root.ID, dto.UpdateCommentRequest{Body: "復活"}, "alice", "")
+	require.Error(t, err)
+	assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code)
+	err = usecase.DeleteComment(ctx, root.ID, "alice", "")
+	require.Error(t, err)
+	assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code)
+	_, err = usecase.CreateComment(ctx, testQuestionID, dto.CreateCommentRequest{ParentID: &root.ID, Body: "返信"}, "bob", "")
+	require.Error(t, err)
+	assert.Equal(t, "parent_id", err.(shared.ValidationError).Field)
+}
@@ -0,0 +1,19 @@
+package dto
+
+// daily_dto.goは「今日の一問」関連のデータ転送オブジェクトを定義
+
+import questionDto "Shittaka_back/internal/application/question/dto"
+
+// SetDailyQuestionRequest はキュレーターによる「今日の一問」指定リクエスト
+// Date を省略した場合は当日を指定したものとして扱う
+type SetDailyQuestionRequest struct {
+	Date       string `json:"date"`
+	QuestionID int64  `json:"question_id"`
+}
+
+// DailyQuestionResponse は「今日の一問」レスポンス
+type DailyQuestionResponse struct {
+	Date       string                        `json:"date"`
+	IsOverride bool                          `json:"is_override"`
+	Question   *questionDto.QuestionResponse `json:"question"`
+}
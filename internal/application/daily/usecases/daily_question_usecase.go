@@ -0,0 +1,193 @@
+package usecases
+
+// daily_question_usecase.goは「今日の一問」の選出とキュレーターによる指定を扱う
+
+import (
+	"context"
+	"time"
+
+	"Shittaka_back/internal/application/daily/dto"
+	questionUsecases "Shittaka_back/internal/application/question/usecases"
+	"Shittaka_back/internal/domain/daily/entities"
+	"Shittaka_back/internal/domain/daily/repositories"
+	"Shittaka_back/internal/domain/daily/services"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// DailyQuestionUsecase は「今日の一問」ユースケース
+type DailyQuestionUsecase struct {
+	dailyRepo       repositories.DailyQuestionRepository
+	questionRepo    questionRepositories.QuestionRepository
+	questionUsecase *questionUsecases.QuestionUsecase
+	curatorIDs      map[string]bool
+	noRepeatDays    int
+	location        *time.Location
+	now             func() time.Time
+}
+
+// NewDailyQuestionUsecase は新しいDailyQuestionUsecaseを作成
+// 日付の切り替わりは location（通常は日本時間）で判定する
+func NewDailyQuestionUsecase(dailyRepo repositories.DailyQuestionRepository, questionRepo questionRepositories.QuestionRepository, questionUsecase *questionUsecases.QuestionUsecase, curatorIDs []string, noRepeatDays int, location *time.Location) *DailyQuestionUsecase {
+	curators := make(map[string]bool, len(curatorIDs))
+	for _, id := range curatorIDs {
+		curators[id] = true
+	}
+
+	return &DailyQuestionUsecase{
+		dailyRepo:       dailyRepo,
+		questionRepo:    questionRepo,
+		questionUsecase: questionUsecase,
+		curatorIDs:      curators,
+		noRepeatDays:    noRepeatDays,
+		location:        location,
+		now:             time.Now,
+	}
+}
+
+// GetDailyQuestion は当日の「今日の一問」を取得する
+// まだ決まっていない場合は日付をシードに選出して保存するため、全ユーザーに同じ問題が返る
+func (u *DailyQuestionUsecase) GetDailyQuestion(ctx context.Context) (*dto.DailyQuestionResponse, error) {
+	date := entities.DateKey(u.now(), u.location)
+
+	daily, err := u.dailyRepo.GetByDate(ctx, date)
+	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+		return u.pickAndSave(ctx, date, 0)
+	}
+
+	question, err := u.questionUsecase.GetQuestion(ctx, daily.QuestionID, "")
+	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+		// 選出後に非公開・削除された場合は選び直す
+		return u.pickAndSave(ctx, date, daily.QuestionID)
+	}
+
+	return &dto.DailyQuestionResponse{
+		Date:       daily.Date,
+		IsOverride: daily.IsOverride,
+		Question:   question,
+	}, nil
+}
+
+// SetDailyQuestion はキュレーターが指定した問題を「今日の一問」にする
+func (u *DailyQuestionUsecase) SetDailyQuestion(ctx context.Context, req dto.SetDailyQuestionRequest, userID string) (*dto.DailyQuestionResponse, error) {
+	if !u.isCurator(userID) {
+		return nil, shared.NewDomainError("FORBIDDEN", "キュレーターのみ操作できます")
+	}
+
+	date := req.Date
+	if date == "" {
+		date = entities.DateKey(u.now(), u.location)
+	}
+
+	daily := entities.NewDailyQuestionOverride(date, req.QuestionID, userID)
+	if err := daily.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 公開中の問題のみ指定できる（通報で非公開になった問題は見つからない扱い）
+	question, err := u.questionUsecase.GetQuestion(ctx, req.QuestionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.dailyRepo.Upsert(ctx, daily); err != nil {
+		return nil, err
+	}
+
+	return &dto.DailyQuestionResponse{
+		Date:       daily.Date,
+		IsOverride: true,
+		Question:   question,
+	}, nil
+}
+
+// pickAndSave は公開中の問題から「今日の一問」を選出して保存する
+// excludeID が指定された場合はその問題を候補から外す
+func (u *DailyQuestionUsecase) pickAndSave(ctx context.Context, date string, excludeID int64) (*dto.DailyQuestionResponse, error) {
+	questions, err := u.questionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]int64, 0, len(questions))
+	for _, question := range questions {
+		if question.IsPubliclyVisible() && question.ID != excludeID {
+			candidates = append(candidates, question.ID)
+		}
+	}
+
+	recent, err := u.recentQuestionIDs(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	questionID := services.PickDailyQuestion(date, candidates, recent)
+	if questionID == 0 {
+		return nil, shared.NewDomainError("NOT_FOUND", "出題できる問題がありません")
+	}
+
+	daily := entities.NewDailyQuestion(date, questionID)
+	if excludeID != 0 {
+		err = u.dailyRepo.Upsert(ctx, daily)
+	} else {
+		// 同時に選出された場合も結果は同じなので、先に保存された方を残す
+		err = u.dailyRepo.Create(ctx, daily)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := u.questionUsecase.GetQuestion(ctx, questionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.DailyQuestionResponse{
+		Date:     date,
+		Question: question,
+	}, nil
+}
+
+// recentQuestionIDs は直近 noRepeatDays 日に出題された問題IDを返す（当日分は除く）
+func (u *DailyQuestionUsecase) recentQuestionIDs(ctx context.Context, date string) (map[int64]bool, error) {
+	recent := make(map[int64]bool)
+	if u.noRepeatDays <= 0 {
+		return recent, nil
+	}
+
+	day, err := time.Parse(entities.DateLayout, date)
+	if err != nil {
+		return nil, err
+	}
+	since := day.AddDate(0, 0, -u.noRepeatDays).Format(entities.DateLayout)
+
+	dailies, err := u.dailyRepo.GetSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, daily := range dailies {
+		if daily.Date != date {
+			recent[daily.QuestionID] = true
+		}
+	}
+
+	return recent, nil
+}
+
+// isCurator はユーザーがキュレーターかどうかを判定
+func (u *DailyQuestionUsecase) isCurator(userID string) bool {
+	return userID != "" && u.curatorIDs[userID]
+}
+
+// isNotFound はエラーが NOT_FOUND のドメインエラーかどうかを判定
+func isNotFound(err error) bool {
+	domainErr, ok := err.(shared.DomainError)
+	return ok && domainErr.Code == "NOT_FOUND"
+}
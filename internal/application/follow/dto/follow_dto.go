@@ -0,0 +1,25 @@
+package dto
+
+// follow_dto.goはフォロー関連のデータ転送オブジェクトを定義
+
+import "time"
+
+// ListFollowsRequest はフォロワー・フォロー中の一覧取得リクエスト
+type ListFollowsRequest struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// FollowUserResponse はフォロワー・フォロー中の一覧の1ユーザー
+type FollowUserResponse struct {
+	UserID     string    `json:"user_id"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// FollowListResponse はフォロワー・フォロー中の一覧レスポンス
+type FollowListResponse struct {
+	Users   []*FollowUserResponse `json:"users"`
+	Limit   int                   `json:"limit"`
+	Offset  int                   `json:"offset"`
+	HasMore bool                  `json:"has_more"`
+}
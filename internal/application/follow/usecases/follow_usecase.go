@@ -0,0 +1,139 @@
+package usecases
+
+// follow_usecase.goはユーザー・ジャンルのフォローのユースケースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/application/follow/dto"
+	authEntities "Shittaka_back/internal/domain/auth/entities"
+	authRepositories "Shittaka_back/internal/domain/auth/repositories"
+	"Shittaka_back/internal/domain/follow/entities"
+	"Shittaka_back/internal/domain/follow/repositories"
+	genreRepositories "Shittaka_back/internal/domain/genre/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+const (
+	// defaultFollowLimit は1ページあたりのユーザー数の既定値
+	defaultFollowLimit = 20
+	// maxFollowLimit は1ページあたりのユーザー数の上限
+	maxFollowLimit = 100
+)
+
+// FollowUsecase はフォローユースケース
+type FollowUsecase struct {
+	followRepo repositories.FollowRepository
+	userRepo   authRepositories.UserRepository
+	genreRepo  genreRepositories.GenreRepository
+}
+
+// NewFollowUsecase は新しいFollowUsecaseを作成
+func NewFollowUsecase(followRepo repositories.FollowRepository, userRepo authRepositories.UserRepository, genreRepo genreRepositories.GenreRepository) *FollowUsecase {
+	return &FollowUsecase{
+		followRepo: followRepo,
+		userRepo:   userRepo,
+		genreRepo:  genreRepo,
+	}
+}
+
+// FollowUser はユーザーをフォローする（認証が必要、既にフォローしている場合も成功）
+func (u *FollowUsecase) FollowUser(ctx context.Context, followerID, followeeID string, userToken string) error {
+	follow := entities.NewUserFollow(followerID, followeeID)
+	if err := follow.Validate(); err != nil {
+		return err
+	}
+
+	if err := u.checkUserExists(ctx, followeeID); err != nil {
+		return err
+	}
+
+	return u.followRepo.FollowUser(ctx, follow, userToken)
+}
+
+// UnfollowUser はユーザーのフォローを外す（認証が必要、フォローしていない場合も成功）
+func (u *FollowUsecase) UnfollowUser(ctx context.Context, followerID, followeeID string, userToken string) error {
+	if !authEntities.IsValidUserID(followeeID) {
+		return shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+	return u.followRepo.UnfollowUser(ctx, followerID, followeeID, userToken)
+}
+
+// GetFollowers はユーザーのフォロワーを新しい順に取得する（認証不要）
+func (u *FollowUsecase) GetFollowers(ctx context.Context, userID string, req dto.ListFollowsRequest) (*dto.FollowListResponse, error) {
+	return u.listFollows(ctx, userID, req, u.followRepo.GetFollowers, func(f *entities.UserFollow) string { return f.FollowerID })
+}
+
+// GetFollowing はユーザーがフォローしているユーザーを新しい順に取得する（認証不要）
+func (u *FollowUsecase) GetFollowing(ctx context.Context, userID string, req dto.ListFollowsRequest) (*dto.FollowListResponse, error) {
+	return u.listFollows(ctx, userID, req, u.followRepo.GetFollowing, func(f *entities.UserFollow) string { return f.FolloweeID })
+}
+
+// FollowGenre はジャンルをフォローする（認証が必要、既にフォローしている場合も成功）
+func (u *FollowUsecase) FollowGenre(ctx context.Context, userID string, genreID int64, userToken string) error {
+	if _, err := u.genreRepo.FindByID(ctx, genreID); err != nil {
+		return err
+	}
+	return u.followRepo.FollowGenre(ctx, entities.NewGenreFollow(userID, genreID), userToken)
+}
+
+// UnfollowGenre はジャンルのフォローを外す（認証が必要、フォローしていない場合も成功）
+func (u *FollowUsecase) UnfollowGenre(ctx context.Context, userID string, genreID int64, userToken string) error {
+	return u.followRepo.UnfollowGenre(ctx, userID, genreID, userToken)
+}
+
+// listFollows はフォロー関係の一覧をページングして取得し、相手のユーザーIDの一覧に変換する
+func (u *FollowUsecase) listFollows(ctx context.Context, userID string, req dto.ListFollowsRequest,
+	find func(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error),
+	other func(*entities.UserFollow) string) (*dto.FollowListResponse, error) {
+	if !authEntities.IsValidUserID(userID) {
+		return nil, shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultFollowLimit
+	}
+	if limit > maxFollowLimit {
+		limit = maxFollowLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 続きがあるか判定するため1件多く取得する
+	follows, err := find(ctx, userID, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(follows) > limit
+	if hasMore {
+		follows = follows[:limit]
+	}
+
+	users := make([]*dto.FollowUserResponse, len(follows))
+	for i, follow := range follows {
+		users[i] = &dto.FollowUserResponse{
+			UserID:     other(follow),
+			FollowedAt: follow.CreatedAt,
+		}
+	}
+
+	return &dto.FollowListResponse{
+		Users:   users,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: hasMore,
+	}, nil
+}
+
+// checkUserExists はユーザーが存在するかどうかを確認する
+func (u *FollowUsecase) checkUserExists(ctx context.Context, userID string) error {
+	if !authEntities.IsValidUserID(userID) {
+		return shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+	_, err := u.userRepo.FindByID(ctx, userID)
+	return err
+}
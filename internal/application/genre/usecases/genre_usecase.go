@@ -31,9 +31,10 @@ func (u *GenreUsecase) CreateGenre(ctx context.Context, req dto.CreateGenreReque
 		return nil, err
 	}
 
-	// 同名のジャンルが既に存在するかチェック
-	existingGenre, err := u.genreRepo.FindByName(ctx, req.Name, userToken)
-	if err != nil && !isNotFoundError(err) {
+	// 同名のジャンルが既に存在するかチェック（全角・半角、大文字・小文字の違いは同じ名前とみなす）
+	req.Name = shared.NormalizeLabel(req.Name)
+	existingGenre, err := u.findByLabel(ctx, req.Name)
+	if err != nil {
 		return nil, err
 	}
 	if existingGenre != nil {
@@ -56,6 +57,25 @@ func (u *GenreUsecase) CreateGenre(ctx context.Context, req dto.CreateGenreReque
 	}, nil
 }
 
+// EnsureGenre は同名のジャンルがあればそれを返し、なければ作成する（認証が必要）
+// 作成済みかどうかを created で返す
+func (u *GenreUsecase) EnsureGenre(ctx context.Context, name string, userToken string) (genre *dto.GenreResponse, created bool, err error) {
+	name = shared.NormalizeLabel(name)
+	existingGenre, err := u.findByLabel(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if existingGenre != nil {
+		return &dto.GenreResponse{ID: existingGenre.ID, Name: existingGenre.Name}, false, nil
+	}
+
+	genre, err = u.CreateGenre(ctx, dto.CreateGenreRequest{Name: name}, userToken)
+	if err != nil {
+		return nil, false, err
+	}
+	return genre, true, nil
+}
+
 // GetAllGenres は全てのジャンルを取得する
 func (u *GenreUsecase) GetAllGenres(ctx context.Context) ([]*dto.GenreResponse, error) {
 	genres, err := u.genreRepo.FindAll(ctx)
@@ -87,10 +107,17 @@ func (u *GenreUsecase) validateCreateGenreRequest(req dto.CreateGenreRequest) er
 	return nil
 }
 
-// isNotFoundError はエラーがNot Foundエラーかどうかを判定
-func isNotFoundError(err error) bool {
-	if domainErr, ok := err.(shared.DomainError); ok {
-		return domainErr.Code == "NOT_FOUND"
+// findByLabel は表記ゆれを無視して名前が一致するジャンルを探す（見つからなければ nil）
+func (u *GenreUsecase) findByLabel(ctx context.Context, name string) (*entities.Genre, error) {
+	genres, err := u.genreRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := shared.LabelKey(name)
+	for _, genre := range genres {
+		if shared.LabelKey(genre.Name) == key {
+			return genre, nil
+		}
 	}
-	return false
-}
\ No newline at end of file
+	return nil, nil
+}
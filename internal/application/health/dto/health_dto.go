@@ -0,0 +1,38 @@
+package dto
+
+// health_dto.goは死活監視・準備状態の確認のデータ転送オブジェクトを定義
+
+import "time"
+
+const (
+	// StatusReady は全ての依存先に接続できる状態
+	StatusReady = "ready"
+	// StatusNotReady は接続できない依存先がある状態
+	StatusNotReady = "not_ready"
+
+	// DependencyOK は依存先に接続できた状態
+	DependencyOK = "ok"
+	// DependencyError は依存先に接続できなかった状態
+	DependencyError = "error"
+)
+
+// DependencyStatus は依存先ごとの確認結果
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	// Error は接続できなかった理由の種類（timeout / unavailable）で、詳細はサーバーのログに残す
+	Error string `json:"error,omitempty"`
+}
+
+// ReadinessResponse は準備状態の確認結果
+type ReadinessResponse struct {
+	Status       string             `json:"status"`
+	CheckedAt    time.Time          `json:"checked_at"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+// Ready は全ての依存先に接続できたかどうか
+func (r *ReadinessResponse) Ready() bool {
+	return r.Status == StatusReady
+}
@@ -0,0 +1,104 @@
+package usecases
+
+// readiness_usecase.goは依存先の疎通確認（準備状態の確認）のユースケースを定義
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/application/health/dto"
+	"Shittaka_back/internal/domain/health/repositories"
+)
+
+// ReadinessUsecase は依存先に接続できるかを確認するユースケース
+// 監視からの頻繁な確認で依存先に負荷をかけないよう、結果を cacheTTL の間使い回す
+type ReadinessUsecase struct {
+	checkers []repositories.DependencyChecker
+	timeout  time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	// mu は確認中に届いた他の確認を待たせ、依存先へのリクエストを1回にまとめる
+	mu     sync.Mutex
+	cached *dto.ReadinessResponse
+}
+
+// NewReadinessUsecase は新しいReadinessUsecaseを作成
+// timeout は依存先ごとの確認の上限時間
+func NewReadinessUsecase(checkers []repositories.DependencyChecker, timeout, cacheTTL time.Duration) *ReadinessUsecase {
+	return &ReadinessUsecase{
+		checkers: checkers,
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+	}
+}
+
+// Check は全ての依存先を並行して確認し、依存先ごとの状態と応答時間を返す
+// 前回の確認から cacheTTL が経っていない場合は前回の結果を返す
+func (u *ReadinessUsecase) Check(ctx context.Context) *dto.ReadinessResponse {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.cached != nil && u.now().Sub(u.cached.CheckedAt) < u.cacheTTL {
+		return u.cached
+	}
+
+	dependencies := make([]dto.DependencyStatus, len(u.checkers))
+	var wg sync.WaitGroup
+	for i, checker := range u.checkers {
+		wg.Add(1)
+		go func(i int, checker repositories.DependencyChecker) {
+			defer wg.Done()
+			dependencies[i] = u.checkOne(ctx, checker)
+		}(i, checker)
+	}
+	wg.Wait()
+
+	status := dto.StatusReady
+	for _, dependency := range dependencies {
+		if dependency.Status != dto.DependencyOK {
+			status = dto.StatusNotReady
+		}
+	}
+
+	u.cached = &dto.ReadinessResponse{
+		Status:       status,
+		CheckedAt:    u.now(),
+		Dependencies: dependencies,
+	}
+	return u.cached
+}
+
+// checkOne は依存先を timeout の範囲で確認する
+// 結果は他の確認でも使い回すため、呼び出し元のリクエストが切断されても確認は続ける
+func (u *ReadinessUsecase) checkOne(ctx context.Context, checker repositories.DependencyChecker) dto.DependencyStatus {
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), u.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := checker.Check(checkCtx)
+	result := dto.DependencyStatus{
+		Name:      checker.Name(),
+		Status:    dto.DependencyOK,
+		LatencyMS: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		// エラーには接続先のURLやデータベースのエラー内容が含まれるため、詳細はログにだけ残す
+		log.Printf("Readiness check %s failed: %v", checker.Name(), err)
+		result.Status = dto.DependencyError
+		result.Error = dependencyErrorMessage(checkCtx, err)
+	}
+	return result
+}
+
+// dependencyErrorMessage は公開してよい粒度のエラーの種類を返す
+func dependencyErrorMessage(ctx context.Context, err error) string {
+	if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+		return "timeout"
+	}
+	return "unavailable"
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Shittaka_back/internal/application/health/dto"
+	"Shittaka_back/internal/domain/health/repositories"
+)
+
+// stubChecker は呼び出し回数を数える DependencyChecker
+type stubChecker struct {
+	name  string
+	err   error
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (c *stubChecker) Name() string { return c.name }
+
+func (c *stubChecker) Check(ctx context.Context) error {
+	c.calls.Add(1)
+	select {
+	case <-time.After(c.delay):
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestReadinessUsecase_ReportsEachDependency(t *testing.T) {
+	postgrest := &stubChecker{name: "postgrest"}
+	gotrue := &stubChecker{name: "gotrue", err: errors.New(`Get "https://internal.example.com/auth/v1/health": dial tcp 10.0.0.5:443: connection refused`)}
+	usecase := NewReadinessUsecase([]repositories.DependencyChecker{postgrest, gotrue}, time.Second, time.Minute)
+
+	result := usecase.Check(context.Background())
+	assert.False(t, result.Ready())
+	require.Len(t, result.Dependencies, 2)
+	assert.Equal(t, dto.DependencyStatus{Name: "postgrest", Status: dto.DependencyOK, LatencyMS: result.Dependencies[0].LatencyMS}, result.Dependencies[0])
+	assert.Equal(t, dto.DependencyError, result.Dependencies[1].Status)
+	// 接続先のURLやアドレスは公開しない
+	assert.Equal(t, "unavailable", result.Dependencies[1].Error)
+}
+
+func TestReadinessUsecase_TimesOutSlowDependency(t *testing.T) {
+	slow := &stubChecker{name: "postgres", delay: time.Minute}
+	usecase := NewReadinessUsecase([]repositories.DependencyChecker{slow}, 20*time.Millisecond, 0)
+
+	result := usecase.Check(context.Background())
+	assert.Equal(t, dto.StatusNotReady, result.Status)
+	assert.Equal(t, "timeout", result.Dependencies[0].Error)
+}
+
+func TestReadinessUsecase_CachesResult(t *testing.T) {
+	checker := &stubChecker{name: "gotrue"}
+	usecase := NewReadinessUsecase([]repositories.DependencyChecker{checker}, time.Second, 5*time.Second)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	usecase.now = func() time.Time { return now }
+
+	assert.True(t, usecase.Check(context.Background()).Ready())
+	usecase.Check(context.Background())
+	assert.Equal(t, int32(1), checker.calls.Load())
+
+	// キャッシュの期限を過ぎたら依存先を確認し直す
+	now = now.Add(5 * time.Second)
+	usecase.Check(context.Background())
+	assert.Equal(t, int32(2), checker.calls.Load())
+}
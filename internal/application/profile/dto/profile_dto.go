@@ -0,0 +1,24 @@
+package dto
+
+// profile_dto.goはユーザーの公開プロフィール関連のデータ転送オブジェクトを定義
+
+import "time"
+
+// UserProfileResponse はユーザーの公開プロフィール
+// メールアドレスなど本人以外に見せない情報は含めない
+type UserProfileResponse struct {
+	ID        string             `json:"id"`
+	Username  string             `json:"username"`
+	AvatarURL string             `json:"avatar_url"`
+	JoinedAt  time.Time          `json:"joined_at"`
+	Stats     *UserStatsResponse `json:"stats"`
+}
+
+// UserStatsResponse はユーザーの問題と回答の集計
+type UserStatsResponse struct {
+	QuestionCount       int      `json:"question_count"`
+	ReceivedAnswerCount int      `json:"received_answer_count"`
+	ReceivedCorrectRate *float64 `json:"received_correct_rate"` // 回答が無い場合は null
+	AnswerCount         int      `json:"answer_count"`
+	Accuracy            *float64 `json:"accuracy"` // 回答が無い場合は null
+}
@@ -0,0 +1,59 @@
+package usecases
+
+// profile_usecase.goはユーザーの公開プロフィールのユースケースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/application/profile/dto"
+	authEntities "Shittaka_back/internal/domain/auth/entities"
+	authRepositories "Shittaka_back/internal/domain/auth/repositories"
+	"Shittaka_back/internal/domain/profile/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// ProfileUsecase はユーザープロフィールユースケース
+type ProfileUsecase struct {
+	userRepo  authRepositories.UserRepository
+	statsRepo repositories.UserStatsRepository
+}
+
+// NewProfileUsecase は新しいProfileUsecaseを作成
+func NewProfileUsecase(userRepo authRepositories.UserRepository, statsRepo repositories.UserStatsRepository) *ProfileUsecase {
+	return &ProfileUsecase{
+		userRepo:  userRepo,
+		statsRepo: statsRepo,
+	}
+}
+
+// GetProfile はユーザーの公開プロフィールと集計を取得する（認証不要）
+func (u *ProfileUsecase) GetProfile(ctx context.Context, userID string) (*dto.UserProfileResponse, error) {
+	if !authEntities.IsValidUserID(userID) {
+		return nil, shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := u.statsRepo.GetStats(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// レスポンスDTOに変換（メールアドレスは含めない）
+	return &dto.UserProfileResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		AvatarURL: user.AvatarURL,
+		JoinedAt:  user.CreatedAt,
+		Stats: &dto.UserStatsResponse{
+			QuestionCount:       stats.QuestionCount,
+			ReceivedAnswerCount: stats.ReceivedAnswerCount,
+			ReceivedCorrectRate: stats.ReceivedCorrectRate(),
+			AnswerCount:         stats.AnswerCount,
+			Accuracy:            stats.Accuracy(),
+		},
+	}, nil
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authEntities "Shittaka_back/internal/domain/auth/entities"
+	authRepositories "Shittaka_back/internal/domain/auth/repositories"
+	"Shittaka_back/internal/domain/profile/entities"
+	"Shittaka_back/internal/domain/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = "0b6f7c1e-2a4d-4f8e-9c3b-5d1a2e3f4a5b"
+
+// fakeUserRepository はIDでユーザーを返すテスト用リポジトリ
+type fakeUserRepository struct {
+	authRepositories.UserRepository
+	users map[string]*authEntities.User
+}
+
+func (r *fakeUserRepository) FindByID(ctx context.Context, id string) (*authEntities.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+}
+
+// fakeUserStatsRepository は固定の集計を返すテスト用リポジトリ
+type fakeUserStatsRepository struct {
+	stats *entities.UserStats
+}
+
+func (r *fakeUserStatsRepository) GetStats(ctx context.Context, userID string) (*entities.UserStats, error) {
+	return r.stats, nil
+}
+
+func TestProfileUsecase_GetProfile(t *testing.T) {
+	joinedAt := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	userRepo := &fakeUserRepository{users: map[string]*authEntities.User{
+		testUserID: {ID: testUserID, Email: "alice@example.com", Username: "alice", AvatarURL: "https://example.com/a.png", CreatedAt: joinedAt},
+	}}
+	statsRepo := &fakeUserStatsRepository{stats: &entities.UserStats{
+		QuestionCount:        3,
+		ReceivedAnswerCount:  8,
+		ReceivedCorrectCount: 6,
+	}}
+	usecase := NewProfileUsecase(userRepo, statsRepo)
+
+	t.Run("公開プロフィールと集計を返す", func(t *testing.T) {
+		profile, err := usecase.GetProfile(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", profile.Username)
+		assert.Equal(t, joinedAt, profile.JoinedAt)
+		assert.Equal(t, 3, profile.Stats.QuestionCount)
+		require.NotNil(t, profile.Stats.ReceivedCorrectRate)
+		assert.InDelta(t, 0.75, *profile.Stats.ReceivedCorrectRate, 1e-9)
+		// 回答していないユーザーの正答率は null
+		assert.Nil(t, profile.Stats.Accuracy)
+	})
+
+	t.Run("存在しないユーザー・不正なIDは NOT_FOUND", func(t *testing.T) {
+		for _, id := range []string{"1f9e8d7c-6b5a-4c3d-8e2f-1a0b9c8d7e6f", "1", "../admin"} {
+			_, err := usecase.GetProfile(context.Background(), id)
+			require.Error(t, err, id)
+			assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code, id)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package dto
+
+// export_dto.goは問題のエクスポートに関するデータ転送オブジェクトを定義
+
+// エクスポートできるファイル形式（csv・json はインポートと同じ形式）
+const (
+	ExportFormatCSV  = ImportFormatCSV
+	ExportFormatJSON = ImportFormatJSON
+	ExportFormatAnki = "anki" // Ankiで読み込めるタブ区切りのデッキ
+)
+
+// エクスポートする問題の範囲
+// 正解（is_correct・Ankiの裏面の正解）は自分が作成した問題の分のみ書き出す
+const (
+	ExportScopeMine      = "mine"      // 自分が作成した問題（下書きを含む）
+	ExportScopeGenre     = "genre"     // ジャンル内の公開中の問題
+	ExportScopeBookmarks = "bookmarks" // 自分がブックマークした問題
+)
+
+// ExportQuestionsRequest は問題のエクスポートリクエスト
+type ExportQuestionsRequest struct {
+	Format  string
+	Scope   string
+	GenreID int64 // Scope が genre の場合のみ使用
+}
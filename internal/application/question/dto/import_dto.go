@@ -0,0 +1,60 @@
+package dto
+
+// import_dto.goは問題の一括インポートに関するデータ転送オブジェクトを定義
+
+// インポートできるファイル形式
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+)
+
+// ImportQuestionsRequest は問題の一括インポートリクエスト
+type ImportQuestionsRequest struct {
+	Format  string // csv または json
+	Data    []byte // アップロードされたファイルの中身
+	DryRun  bool   // true の場合は検証のみ行い保存しない
+	Publish bool   // true の場合はインポートした問題をそのまま公開する
+}
+
+// ImportChoice はインポートする選択肢
+type ImportChoice struct {
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct,omitempty"`
+}
+
+// ImportQuestionRow はインポートする問題1件分
+type ImportQuestionRow struct {
+	Title       string         `json:"title"`
+	Body        string         `json:"body"`
+	Explanation string         `json:"explanation"`
+	Genre       string         `json:"genre"` // ジャンル名（存在しなければ作成する）
+	Type        string         `json:"type"`
+	BodyFormat  string         `json:"body_format"`
+	Choices     []ImportChoice `json:"choices"`
+}
+
+// ImportRowError は行ごとの検証エラー
+type ImportRowError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ImportRowResult は行ごとのインポート結果
+type ImportRowResult struct {
+	Row        int              `json:"row"` // CSVはヘッダーを1行目とした行番号、JSONは1始まりの要素番号
+	Title      string           `json:"title"`
+	Genre      string           `json:"genre"`
+	QuestionID int64            `json:"question_id,omitempty"`
+	Errors     []ImportRowError `json:"errors,omitempty"`
+}
+
+// ImportQuestionsResponse は問題の一括インポート結果
+type ImportQuestionsResponse struct {
+	DryRun        bool               `json:"dry_run"`
+	Total         int                `json:"total"`
+	Valid         int                `json:"valid"`
+	Imported      int                `json:"imported"`
+	Failed        int                `json:"failed"`
+	CreatedGenres []string           `json:"created_genres"` // ドライラン時は作成予定のジャンル
+	Rows          []*ImportRowResult `json:"rows"`
+}
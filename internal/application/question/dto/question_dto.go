@@ -1,32 +1,124 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	attachmentDto "Shittaka_back/internal/application/attachment/dto"
+)
 
 // CreateQuestionRequest は問題作成リクエスト
 type CreateQuestionRequest struct {
-	GenreID     int64  `json:"genre_id"`
-	Title       string `json:"title"`
-	Body        string `json:"body"`
-	Explanation string `json:"explanation"`
+	GenreID          int64    `json:"genre_id"`
+	Title            string   `json:"title"`
+	Body             string   `json:"body"`
+	Explanation      string   `json:"explanation"`
+	BodyFormat       string   `json:"body_format"`
+	Type             string   `json:"type"`
+	PartialCredit    bool     `json:"partial_credit"`
+	AcceptedAnswers  []string `json:"accepted_answers"`
+	NumericAnswer    *float64 `json:"numeric_answer"`
+	NumericTolerance float64  `json:"numeric_tolerance"`
+	ShuffleChoices   bool     `json:"shuffle_choices"`
+	Tags             []string `json:"tags"`
 }
 
 // UpdateQuestionRequest は問題更新リクエスト
 type UpdateQuestionRequest struct {
-	Title       string `json:"title"`
-	Body        string `json:"body"`
-	Explanation string `json:"explanation"`
+	Title            string   `json:"title"`
+	Body             string   `json:"body"`
+	Explanation      string   `json:"explanation"`
+	BodyFormat       string   `json:"body_format"`
+	Type             string   `json:"type"`
+	PartialCredit    *bool    `json:"partial_credit"`
+	AcceptedAnswers  []string `json:"accepted_answers"`
+	NumericAnswer    *float64 `json:"numeric_answer"`
+	NumericTolerance *float64 `json:"numeric_tolerance"`
+	ShuffleChoices   *bool    `json:"shuffle_choices"`
+	Tags             []string `json:"tags"` // 指定した場合は付いているタグを置き換える（[] で全て外す）
+}
+
+// ScheduleQuestionRequest は予約公開リクエスト（publish_at が null の場合は予約取り消し）
+type ScheduleQuestionRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// ListQuestionsRequest は問題一覧のページング指定
+type ListQuestionsRequest struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// FeedRequest はフィードの取得リクエスト（cursor は前のレスポンスの next_cursor）
+type FeedRequest struct {
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
 }
 
 // QuestionResponse は問題レスポンス
 type QuestionResponse struct {
-	ID             int64     `json:"id"`
-	GenreID        int64     `json:"genre_id"`
-	UserID         string    `json:"user_id"`
-	Title          string    `json:"title"`
-	Body           string    `json:"body"`
-	Explanation    string    `json:"explanation"`
-	CreatedAt      time.Time `json:"created_at"`
-	Views          int       `json:"views"`
-	CorrectCount   int       `json:"correct_count"`
-	IncorrectCount int       `json:"incorrect_count"`
-}
\ No newline at end of file
+	ID               int64      `json:"id"`
+	GenreID          int64      `json:"genre_id"`
+	UserID           string     `json:"user_id"`
+	Title            string     `json:"title"`
+	Body             string     `json:"body"`
+	Explanation      string     `json:"explanation"`
+	BodyFormat       string     `json:"body_format"`
+	BodyHTML         string     `json:"body_html,omitempty"`        // render=html 指定時のみ
+	ExplanationHTML  string     `json:"explanation_html,omitempty"` // render=html 指定時のみ
+	CreatedAt        time.Time  `json:"created_at"`
+	Views            int        `json:"views"`
+	CorrectCount     int        `json:"correct_count"`
+	IncorrectCount   int        `json:"incorrect_count"`
+	IsHidden         bool       `json:"is_hidden"`
+	Revision         int        `json:"revision"`
+	Status           string     `json:"status"`
+	PublishedAt      *time.Time `json:"published_at"`
+	PublishAt        *time.Time `json:"publish_at"`
+	Type             string     `json:"type"`
+	PartialCredit    bool       `json:"partial_credit"`
+	AcceptedAnswers  []string   `json:"accepted_answers,omitempty"`  // 作成者のみ
+	NumericAnswer    *float64   `json:"numeric_answer,omitempty"`    // 作成者のみ
+	NumericTolerance *float64   `json:"numeric_tolerance,omitempty"` // 作成者のみ
+	ShuffleChoices   bool       `json:"shuffle_choices"`
+	Tags             []string   `json:"tags"`
+
+	// 問題本文に添付された画像
+	Attachments []*attachmentDto.AttachmentResponse `json:"attachments"`
+
+	// 作成時に見つかった同じジャンルの似た問題（警告）
+	SimilarQuestions []*SimilarQuestionResponse `json:"similar_questions,omitempty"`
+}
+
+// QuestionListResponse はページングされた問題一覧レスポンス
+type QuestionListResponse struct {
+	Questions []*QuestionResponse `json:"questions"`
+	Limit     int                 `json:"limit"`
+	Offset    int                 `json:"offset"`
+	HasMore   bool                `json:"has_more"`
+}
+
+// FeedResponse はフィードのレスポンス（next_cursor が空の場合は続きが無い）
+type FeedResponse struct {
+	Questions  []*QuestionResponse `json:"questions"`
+	NextCursor string              `json:"next_cursor"`
+}
+
+// SimilarQuestionResponse は似た問題の警告
+type SimilarQuestionResponse struct {
+	ID         int64   `json:"id"`
+	Title      string  `json:"title"`
+	Similarity float64 `json:"similarity"` // 0〜1
+}
+
+// QuestionRevisionResponse は問題リビジョンレスポンス
+type QuestionRevisionResponse struct {
+	ID          int64     `json:"id"`
+	QuestionID  int64     `json:"question_id"`
+	Revision    int       `json:"revision"`
+	UserID      string    `json:"user_id"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	Explanation string    `json:"explanation"`
+	Diff        string    `json:"diff"`
+	CreatedAt   time.Time `json:"created_at"`
+}
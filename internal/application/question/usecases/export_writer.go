@@ -0,0 +1,201 @@
+package usecases
+
+// export_writer.goはエクスポートする問題をCSV・JSON・Ankiデッキの形式で書き出す処理を定義
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"Shittaka_back/internal/application/question/dto"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/services"
+)
+
+// exportCSVChoiceColumns はCSVに書き出す選択肢の列数
+// これを超える選択肢を持つ問題は JSON でエクスポートする
+const exportCSVChoiceColumns = 10
+
+// exportWriter は問題を1件ずつ書き出すライター
+// withAnswers が false の場合は正解（is_correct・Ankiの裏面の正解）を書き出さない
+type exportWriter interface {
+	writeHeader() error
+	writeQuestion(question *entities.Question, genre string, choices []choiceEntities.Choice, withAnswers bool) error
+	flush() error
+	close() error
+}
+
+// newExportWriter は形式に応じたライターを作成する
+func newExportWriter(format string, w io.Writer) exportWriter {
+	switch format {
+	case dto.ExportFormatJSON:
+		return &jsonExportWriter{w: w}
+	case dto.ExportFormatAnki:
+		writer := csv.NewWriter(w)
+		writer.Comma = '\t'
+		return &ankiExportWriter{w: w, csv: writer}
+	}
+	return &csvExportWriter{csv: csv.NewWriter(w)}
+}
+
+// toImportRow はインポートと同じ形式の行データに変換する
+func toImportRow(question *entities.Question, genre string, choices []choiceEntities.Choice, withAnswers bool) dto.ImportQuestionRow {
+	row := dto.ImportQuestionRow{
+		Title:       question.Title,
+		Body:        question.Body,
+		Explanation: question.Explanation,
+		Genre:       genre,
+		Type:        question.Type,
+		BodyFormat:  question.BodyFormat,
+		Choices:     make([]dto.ImportChoice, len(choices)),
+	}
+	for i, choice := range choices {
+		row.Choices[i] = dto.ImportChoice{Text: choice.Text, IsCorrect: withAnswers && choice.IsCorrect}
+	}
+	return row
+}
+
+// csvExportWriter はインポートと同じ列構成のCSVを書き出す
+type csvExportWriter struct {
+	csv *csv.Writer
+}
+
+func (e *csvExportWriter) writeHeader() error {
+	header := []string{"title", "genre", "body", "explanation", "type", "body_format"}
+	for i := 1; i <= exportCSVChoiceColumns; i++ {
+		header = append(header, "choice"+strconv.Itoa(i))
+	}
+	return e.csv.Write(append(header, "correct"))
+}
+
+func (e *csvExportWriter) writeQuestion(question *entities.Question, genre string, choices []choiceEntities.Choice, withAnswers bool) error {
+	record := []string{question.Title, genre, question.Body, question.Explanation, question.Type, question.BodyFormat}
+	var correct []string
+	for i := 0; i < exportCSVChoiceColumns; i++ {
+		if i >= len(choices) {
+			record = append(record, "")
+			continue
+		}
+		record = append(record, choices[i].Text)
+		if withAnswers && choices[i].IsCorrect {
+			correct = append(correct, strconv.Itoa(i+1))
+		}
+	}
+	return e.csv.Write(append(record, strings.Join(correct, ";")))
+}
+
+func (e *csvExportWriter) flush() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *csvExportWriter) close() error {
+	return e.flush()
+}
+
+// jsonExportWriter はインポートと同じ {"questions": [...]} 形式のJSONを1件ずつ書き出す
+type jsonExportWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (e *jsonExportWriter) writeHeader() error {
+	_, err := io.WriteString(e.w, `{"questions":[`)
+	return err
+}
+
+func (e *jsonExportWriter) writeQuestion(question *entities.Question, genre string, choices []choiceEntities.Choice, withAnswers bool) error {
+	data, err := json.Marshal(toImportRow(question, genre, choices, withAnswers))
+	if err != nil {
+		return err
+	}
+	if e.written {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.written = true
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExportWriter) flush() error {
+	return nil
+}
+
+func (e *jsonExportWriter) close() error {
+	_, err := io.WriteString(e.w, "]}\n")
+	return err
+}
+
+// ankiExportWriter はAnkiの「テキストファイルから読み込む」で取り込めるタブ区切りのデッキを書き出す
+// 表面に問題文と選択肢、裏面に正解と解説、タグにジャンル名を入れる（正解を書き出さない場合の裏面は解説のみ）
+type ankiExportWriter struct {
+	w   io.Writer
+	csv *csv.Writer
+}
+
+func (e *ankiExportWriter) writeHeader() error {
+	_, err := io.WriteString(e.w, "#separator:tab\n#html:true\n#tags column:3\n")
+	return err
+}
+
+func (e *ankiExportWriter) writeQuestion(question *entities.Question, genre string, choices []choiceEntities.Choice, withAnswers bool) error {
+	var front strings.Builder
+	front.WriteString("<b>" + html.EscapeString(question.Title) + "</b>")
+	if question.Body != "" {
+		front.WriteString(services.RenderHTML(question.Body, question.BodyFormat))
+	}
+
+	var correct []string
+	if len(choices) > 0 {
+		front.WriteString("<ol>")
+		for _, choice := range choices {
+			front.WriteString("<li>" + html.EscapeString(choice.Text) + "</li>")
+			if choice.IsCorrect {
+				correct = append(correct, html.EscapeString(choice.Text))
+			}
+		}
+		front.WriteString("</ol>")
+	}
+	switch question.Type {
+	case entities.TypeFreeText:
+		for _, accepted := range question.AcceptedAnswers {
+			correct = append(correct, html.EscapeString(accepted))
+		}
+	case entities.TypeNumeric:
+		if question.NumericAnswer != nil {
+			answer := strconv.FormatFloat(*question.NumericAnswer, 'f', -1, 64)
+			if question.NumericTolerance > 0 {
+				answer += " ±" + strconv.FormatFloat(question.NumericTolerance, 'f', -1, 64)
+			}
+			correct = append(correct, answer)
+		}
+	}
+
+	var sections []string
+	if withAnswers {
+		sections = append(sections, "正解: "+strings.Join(correct, " / "))
+	}
+	if question.Explanation != "" {
+		sections = append(sections, services.RenderHTML(question.Explanation, question.BodyFormat))
+	}
+	back := strings.Join(sections, "<hr>")
+
+	// Ankiのタグは空白で区切られるため、ジャンル名の空白は _ に置き換える
+	tag := strings.Join(strings.Fields(genre), "_")
+	return e.csv.Write([]string{front.String(), back, tag})
+}
+
+func (e *ankiExportWriter) flush() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *ankiExportWriter) close() error {
+	return e.flush()
+}
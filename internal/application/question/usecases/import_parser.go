@@ -0,0 +1,174 @@
+package usecases
+
+// import_parser.goは一括インポートするCSV・JSONを問題の行データに変換する処理を定義
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"Shittaka_back/internal/application/question/dto"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// importRow はファイルから読み取った1行分のデータと読み取り時のエラー
+type importRow struct {
+	row        int
+	data       dto.ImportQuestionRow
+	errors     []dto.ImportRowError
+	unreadable bool // 行自体を読み取れず、内容を検証できない
+}
+
+// utf8BOM は表計算ソフトが書き出すCSVの先頭に付くバイト列
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// parseImportRows は形式に応じてインポートするファイルを行データに変換する
+// ファイル全体が読み取れない場合のみエラーを返し、行単位の問題は importRow.errors に記録する
+func parseImportRows(format string, data []byte) ([]*importRow, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	switch format {
+	case dto.ImportFormatCSV:
+		return parseImportCSV(data)
+	case dto.ImportFormatJSON:
+		return parseImportJSON(data)
+	}
+	return nil, shared.NewValidationError("format", "インポート形式は csv または json を指定してください")
+}
+
+// parseImportCSV はヘッダー付きのCSVを読み取る
+// 列: title(question), body, genre, explanation, type, body_format, choice1..choiceN, correct
+// correct には正解の選択肢の番号（1始まり）を ; または | 区切りで指定する
+func parseImportCSV(data []byte) ([]*importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, shared.NewValidationError("file", "ファイルが空です")
+	}
+	if err != nil {
+		return nil, shared.NewValidationError("file", "CSVのヘッダーを読み取れません")
+	}
+
+	columns := make(map[string]int)
+	var choiceColumns []int
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "question":
+			name = "title"
+		case name == "genre_name":
+			name = "genre"
+		case strings.HasPrefix(name, "choice"):
+			choiceColumns = append(choiceColumns, i)
+			continue
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, shared.NewValidationError("file", "CSVに title 列がありません")
+	}
+	if _, ok := columns["genre"]; !ok {
+		return nil, shared.NewValidationError("file", "CSVに genre 列がありません")
+	}
+
+	var rows []*importRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, &importRow{row: line, unreadable: true, errors: []dto.ImportRowError{{Field: "row", Message: "CSVの行を読み取れません"}}})
+				continue
+			}
+			return nil, shared.NewValidationError("file", "CSVを読み取れません")
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := &importRow{
+			row: line,
+			data: dto.ImportQuestionRow{
+				Title:       field("title"),
+				Body:        field("body"),
+				Explanation: field("explanation"),
+				Genre:       field("genre"),
+				Type:        field("type"),
+				BodyFormat:  field("body_format"),
+			},
+		}
+		for _, i := range choiceColumns {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				row.data.Choices = append(row.data.Choices, dto.ImportChoice{Text: strings.TrimSpace(record[i])})
+			}
+		}
+		if correct := field("correct"); correct != "" {
+			for _, marker := range strings.FieldsFunc(correct, func(r rune) bool { return r == ';' || r == '|' }) {
+				n, err := strconv.Atoi(strings.TrimSpace(marker))
+				if err != nil || n < 1 || n > len(row.data.Choices) {
+					row.errors = append(row.errors, dto.ImportRowError{Field: "correct", Message: "正解には選択肢の番号を指定してください"})
+					break
+				}
+				row.data.Choices[n-1].IsCorrect = true
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseImportJSON は問題の配列、または {"questions": [...]} 形式のJSONを読み取る
+func parseImportJSON(data []byte) ([]*importRow, error) {
+	var items []json.RawMessage
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var wrapper struct {
+			Questions []json.RawMessage `json:"questions"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, shared.NewValidationError("file", "JSONを読み取れません")
+		}
+		items = wrapper.Questions
+	} else if err := json.Unmarshal(trimmed, &items); err != nil {
+		return nil, shared.NewValidationError("file", "JSONを読み取れません")
+	}
+
+	rows := make([]*importRow, len(items))
+	for i, item := range items {
+		row := &importRow{row: i + 1}
+		if err := json.Unmarshal(item, &row.data); err != nil {
+			row.unreadable = true
+			row.errors = append(row.errors, dto.ImportRowError{Field: "row", Message: "問題の形式が正しくありません"})
+		}
+		row.data.Title = strings.TrimSpace(row.data.Title)
+		row.data.Genre = strings.TrimSpace(row.data.Genre)
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// isBlankRecord はCSVの行が全て空欄かどうかを返す
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
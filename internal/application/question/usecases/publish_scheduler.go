@@ -0,0 +1,91 @@
+package usecases
+
+// publish_scheduler.goは予約公開の問題を定期的に公開状態へ切り替える仕組みを定義
+
+import (
+	"context"
+	"log"
+	"time"
+
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/question/services"
+)
+
+// PublishScheduler は予約公開の日時を過ぎた下書きを公開するスケジューラー
+type PublishScheduler struct {
+	questionRepo repositories.QuestionRepository
+	choiceRepo   choiceRepositories.ChoiceRepository
+	now          func() time.Time
+}
+
+// NewPublishScheduler は新しいPublishSchedulerを作成
+func NewPublishScheduler(questionRepo repositories.QuestionRepository, choiceRepo choiceRepositories.ChoiceRepository) *PublishScheduler {
+	return &PublishScheduler{
+		questionRepo: questionRepo,
+		choiceRepo:   choiceRepo,
+		now:          time.Now,
+	}
+}
+
+// PublishDue は公開日時を過ぎた問題を公開し、公開した件数を返す
+// 予約後に正解情報が公開条件を満たさなくなった問題は予約を取り消して下書きに戻す
+// （毎回再試行しないようにし、作成者が予約の外れた下書きとして気付けるようにする）
+func (s *PublishScheduler) PublishDue(ctx context.Context) (int, error) {
+	now := s.now()
+
+	questions, err := s.questionRepo.GetDueForPublish(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, question := range questions {
+		if !question.IsDueForPublish(now) {
+			continue
+		}
+
+		var choices []choiceEntities.Choice
+		if question.UsesChoices() {
+			choices, err = s.choiceRepo.GetByQuestionID(ctx, question.ID)
+			if err != nil {
+				return published, err
+			}
+		}
+		if err := services.ValidateAnswerKey(question, choices); err != nil {
+			log.Printf("Scheduled question %d was not published and its schedule was cancelled: %v", question.ID, err)
+			if err := s.questionRepo.CancelScheduledPublish(ctx, question.ID); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := question.Publish(now); err != nil {
+			continue
+		}
+		if err := s.questionRepo.PublishScheduled(ctx, question); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// Run は interval ごとにPublishDueを実行し、ctx が終了したら停止する
+func (s *PublishScheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.PublishDue(ctx); err != nil {
+				log.Printf("Publish scheduler error: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeScheduledRepository は予約公開に関するメソッドだけを実装するテスト用リポジトリ
+type fakeScheduledRepository struct {
+	repositories.QuestionRepository
+	due       []*entities.Question
+	published []int64
+	cancelled []int64
+}
+
+func (r *fakeScheduledRepository) GetDueForPublish(ctx context.Context, now time.Time) ([]*entities.Question, error) {
+	return r.due, nil
+}
+
+func (r *fakeScheduledRepository) PublishScheduled(ctx context.Context, question *entities.Question) error {
+	r.published = append(r.published, question.ID)
+	return nil
+}
+
+func (r *fakeScheduledRepository) CancelScheduledPublish(ctx context.Context, id int64) error {
+	r.cancelled = append(r.cancelled, id)
+	return nil
+}
+
+// fakeChoiceRepository は問題IDごとの選択肢を返すテスト用リポジトリ
+type fakeChoiceRepository struct {
+	choiceRepositories.ChoiceRepository
+	choices map[int64][]choiceEntities.Choice
+}
+
+func (r *fakeChoiceRepository) GetByQuestionID(ctx context.Context, questionID int64) ([]choiceEntities.Choice, error) {
+	return r.choices[questionID], nil
+}
+
+func TestPublishScheduler_PublishesDueQuestions(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	repo := &fakeScheduledRepository{due: []*entities.Question{
+		{ID: 1, Status: entities.StatusDraft, PublishAt: &past},
+		{ID: 2, Status: entities.StatusDraft, PublishAt: &past},
+		{ID: 3, Status: entities.StatusDraft, PublishAt: &future},
+	}}
+	valid := []choiceEntities.Choice{{IsCorrect: true}, {IsCorrect: false}}
+	choiceRepo := &fakeChoiceRepository{choices: map[int64][]choiceEntities.Choice{
+		1: valid,
+		3: valid,
+	}}
+
+	scheduler := NewPublishScheduler(repo, choiceRepo)
+	scheduler.now = func() time.Time { return now }
+
+	count, err := scheduler.PublishDue(context.Background())
+	assert.NoError(t, err)
+	// 2 は選択肢が公開条件を満たさず、3 はまだ公開日時前
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []int64{1}, repo.published)
+	assert.Equal(t, entities.StatusPublished, repo.due[0].Status)
+	assert.Nil(t, repo.due[0].PublishAt)
+	assert.Equal(t, entities.StatusDraft, repo.due[1].Status)
+	// 公開できなかった 2 は予約が取り消され、次回以降は再試行しない
+	assert.Equal(t, []int64{2}, repo.cancelled)
+}
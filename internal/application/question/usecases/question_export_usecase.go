@@ -0,0 +1,189 @@
+package usecases
+
+// question_export_usecase.goは問題をCSV・JSON・Ankiデッキとしてエクスポートするユースケースを定義
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"Shittaka_back/internal/application/question/dto"
+	bookmarkRepositories "Shittaka_back/internal/domain/bookmark/repositories"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	genreRepositories "Shittaka_back/internal/domain/genre/repositories"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// exportPageSize は1回に取得して書き出す問題数
+const exportPageSize = 200
+
+// QuestionExportUsecase は問題のエクスポートユースケース
+type QuestionExportUsecase struct {
+	questionRepo repositories.QuestionRepository
+	choiceRepo   choiceRepositories.ChoiceRepository
+	genreRepo    genreRepositories.GenreRepository
+	bookmarkRepo bookmarkRepositories.BookmarkRepository
+}
+
+// NewQuestionExportUsecase は新しいQuestionExportUsecaseを作成
+func NewQuestionExportUsecase(questionRepo repositories.QuestionRepository, choiceRepo choiceRepositories.ChoiceRepository, genreRepo genreRepositories.GenreRepository, bookmarkRepo bookmarkRepositories.BookmarkRepository) *QuestionExportUsecase {
+	return &QuestionExportUsecase{
+		questionRepo: questionRepo,
+		choiceRepo:   choiceRepo,
+		genreRepo:    genreRepo,
+		bookmarkRepo: bookmarkRepo,
+	}
+}
+
+// QuestionExport は検証済みのエクスポート
+// レスポンスヘッダーを書き込んでから Stream で本体を書き出す
+type QuestionExport struct {
+	ContentType string
+	Filename    string
+
+	usecase    *QuestionExportUsecase
+	format     string
+	queries    []repositories.QuestionPageQuery // 順に書き出す問題の条件
+	userID     string                           // 正解を書き出す問題の作成者（未ログインの場合は空）
+	userToken  string
+	genreNames map[int64]string
+}
+
+// PrepareExport はエクスポートの条件を検証し、書き出しの準備をする
+// 書き出しを始める前にエラーを返せるよう、検証はここで済ませる
+func (u *QuestionExportUsecase) PrepareExport(ctx context.Context, req dto.ExportQuestionsRequest, userID string, userToken string) (*QuestionExport, error) {
+	export := &QuestionExport{usecase: u, format: req.Format, userID: userID, userToken: userToken}
+	switch req.Format {
+	case dto.ExportFormatCSV:
+		export.ContentType = "text/csv; charset=utf-8"
+	case dto.ExportFormatJSON:
+		export.ContentType = "application/json"
+	case dto.ExportFormatAnki:
+		export.ContentType = "text/tab-separated-values; charset=utf-8"
+	default:
+		return nil, shared.NewValidationError("format", "エクスポート形式は csv・json・anki のいずれかを指定してください")
+	}
+
+	ext := req.Format
+	if req.Format == dto.ExportFormatAnki {
+		ext = "txt"
+	}
+
+	switch req.Scope {
+	case dto.ExportScopeMine:
+		if userID == "" {
+			return nil, shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+		}
+		export.queries = []repositories.QuestionPageQuery{{UserID: userID}}
+		export.Filename = "my-questions." + ext
+	case dto.ExportScopeGenre:
+		if req.GenreID == 0 {
+			return nil, shared.NewValidationError("genre_id", "ジャンルIDは必須です")
+		}
+		if _, err := u.genreRepo.FindByID(ctx, req.GenreID); err != nil {
+			return nil, err
+		}
+		// ジャンル単位のエクスポートは誰でも取得できるため、公開中の問題に限る
+		export.queries = []repositories.QuestionPageQuery{{GenreID: req.GenreID, PublishedOnly: true}}
+		export.userToken = ""
+		export.Filename = fmt.Sprintf("genre-%d-questions.%s", req.GenreID, ext)
+	case dto.ExportScopeBookmarks:
+		if userID == "" {
+			return nil, shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+		}
+		ids, err := u.bookmarkRepo.GetQuestionIDs(ctx, userID, userToken)
+		if err != nil {
+			return nil, err
+		}
+		// 条件に含めるIDの数を抑えるため、ブックマークした問題IDを1ページ分ずつに分けて取得する
+		for start := 0; start < len(ids); start += exportPageSize {
+			end := min(start+exportPageSize, len(ids))
+			export.queries = append(export.queries, repositories.QuestionPageQuery{IDs: ids[start:end]})
+		}
+		export.Filename = "bookmarked-questions." + ext
+	default:
+		return nil, shared.NewValidationError("scope", "エクスポートの範囲は mine・genre・bookmarks のいずれかを指定してください")
+	}
+
+	genres, err := u.genreRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	export.genreNames = make(map[int64]string, len(genres))
+	for _, genre := range genres {
+		export.genreNames[genre.ID] = genre.Name
+	}
+
+	return export, nil
+}
+
+// Stream は問題をID順に少しずつ取得しながら w に書き出す
+// 全件をメモリに載せないよう、ページごとに書き出して w がフラッシュできればフラッシュする
+func (e *QuestionExport) Stream(ctx context.Context, w io.Writer) error {
+	writer := newExportWriter(e.format, w)
+	if err := writer.writeHeader(); err != nil {
+		return err
+	}
+
+	for _, query := range e.queries {
+		if err := e.streamQuery(ctx, writer, w, query); err != nil {
+			return err
+		}
+	}
+
+	return writer.close()
+}
+
+// streamQuery は条件に合う問題をページごとに取得して書き出す
+func (e *QuestionExport) streamQuery(ctx context.Context, writer exportWriter, w io.Writer, query repositories.QuestionPageQuery) error {
+	query.Limit = exportPageSize
+	for {
+		questions, err := e.usecase.questionRepo.GetPage(ctx, query, e.userToken)
+		if err != nil {
+			return err
+		}
+		if len(questions) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(questions))
+		for i, question := range questions {
+			ids[i] = question.ID
+		}
+		choices, err := e.usecase.choiceRepo.GetByQuestionIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		choicesByQuestion := make(map[int64][]choiceEntities.Choice)
+		for _, choice := range choices {
+			choicesByQuestion[choice.QuestionID] = append(choicesByQuestion[choice.QuestionID], choice)
+		}
+
+		for _, question := range questions {
+			// ブックマークした後に閲覧できなくなった問題は書き出さない
+			if !question.IsVisibleTo(e.userID) {
+				continue
+			}
+			questionChoices := choicesByQuestion[question.ID]
+			choiceEntities.SortByPosition(questionChoices)
+			// 正解は作成者にのみ書き出す
+			withAnswers := e.userID != "" && question.UserID == e.userID
+			if err := writer.writeQuestion(question, e.genreNames[question.GenreID], questionChoices, withAnswers); err != nil {
+				return err
+			}
+		}
+		if err := writer.flush(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+
+		if len(questions) < query.Limit {
+			return nil
+		}
+		query.AfterID = questions[len(questions)-1].ID
+	}
+}
@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"Shittaka_back/internal/application/question/dto"
+	bookmarkEntities "Shittaka_back/internal/domain/bookmark/entities"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	genreEntities "Shittaka_back/internal/domain/genre/entities"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+	bookmarkMemory "Shittaka_back/internal/infrastructure/bookmark/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExportQuestionRepository は条件に合う問題をID順にページングして返すテスト用リポジトリ
+type fakeExportQuestionRepository struct {
+	repositories.QuestionRepository
+	questions []*entities.Question
+	pages     int
+}
+
+func (r *fakeExportQuestionRepository) GetPage(ctx context.Context, query repositories.QuestionPageQuery, userToken string) ([]*entities.Question, error) {
+	r.pages++
+	var page []*entities.Question
+	for _, question := range r.questions {
+		if question.ID <= query.AfterID {
+			continue
+		}
+		if query.UserID != "" && question.UserID != query.UserID {
+			continue
+		}
+		if query.GenreID != 0 && question.GenreID != query.GenreID {
+			continue
+		}
+		if len(query.IDs) > 0 && !slices.Contains(query.IDs, question.ID) {
+			continue
+		}
+		if query.PublishedOnly && !question.IsPublished() {
+			continue
+		}
+		page = append(page, question)
+		if len(page) == query.Limit {
+			break
+		}
+	}
+	return page, nil
+}
+
+// fakeExportChoiceRepository は問題IDごとの選択肢をまとめて返すテスト用リポジトリ
+type fakeExportChoiceRepository struct {
+	choiceRepositories.ChoiceRepository
+	choices []choiceEntities.Choice
+}
+
+func (r *fakeExportChoiceRepository) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]choiceEntities.Choice, error) {
+	var result []choiceEntities.Choice
+	for _, choice := range r.choices {
+		for _, id := range questionIDs {
+			if choice.QuestionID == id {
+				result = append(result, choice)
+			}
+		}
+	}
+	return result, nil
+}
+
+func newTestExportUsecase() (*QuestionExportUsecase, *fakeExportQuestionRepository) {
+	questionRepo := &fakeExportQuestionRepository{questions: []*entities.Question{
+		{ID: 1, GenreID: 1, UserID: "user-1", Title: "鎌倉幕府の成立", Body: "いつ？", Explanation: "諸説あり", Type: entities.TypeSingleChoice, BodyFormat: entities.BodyFormatPlain, Status: entities.StatusPublished},
+		{ID: 2, GenreID: 2, UserID: "user-1", Title: "下書き", Type: entities.TypeTrueFalse, BodyFormat: entities.BodyFormatMarkdown, Status: entities.StatusDraft},
+		{ID: 3, GenreID: 1, UserID: "user-2", Title: "他人の問題", Type: entities.TypeSingleChoice, BodyFormat: entities.BodyFormatPlain, Status: entities.StatusPublished},
+	}}
+	choiceRepo := &fakeExportChoiceRepository{choices: []choiceEntities.Choice{
+		{ID: 12, QuestionID: 1, Text: "1192年", Position: 2},
+		{ID: 11, QuestionID: 1, Text: "1185年", IsCorrect: true, Position: 1},
+		{ID: 21, QuestionID: 2, Text: "○", Position: 1},
+		{ID: 22, QuestionID: 2, Text: "×", IsCorrect: true, Position: 2},
+		{ID: 31, QuestionID: 3, Text: "A", IsCorrect: true, Position: 1},
+	}}
+	genreRepo := &fakeGenreRepository{genres: []*genreEntities.Genre{{ID: 1, Name: "日本 史"}, {ID: 2, Name: "クイズ"}}}
+	bookmarkRepo := bookmarkMemory.NewBookmarkRepository()
+	for _, id := range []int64{1, 2, 3} {
+		if err := bookmarkRepo.Add(context.Background(), bookmarkEntities.NewBookmark("user-2", id), ""); err != nil {
+			panic(err)
+		}
+	}
+	return NewQuestionExportUsecase(questionRepo, choiceRepo, genreRepo, bookmarkRepo), questionRepo
+}
+
+func exportToBytes(t *testing.T, usecase *QuestionExportUsecase, req dto.ExportQuestionsRequest, userID string) []byte {
+	export, err := usecase.PrepareExport(context.Background(), req, userID, "token")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, export.Stream(context.Background(), &buf))
+	return buf.Bytes()
+}
+
+func TestQuestionExport_RoundTripsWithImport(t *testing.T) {
+	usecase, _ := newTestExportUsecase()
+
+	for _, format := range []string{dto.ExportFormatCSV, dto.ExportFormatJSON} {
+		data := exportToBytes(t, usecase, dto.ExportQuestionsRequest{Format: format, Scope: dto.ExportScopeMine}, "user-1")
+
+		rows, err := parseImportRows(format, data)
+		require.NoError(t, err, format)
+		require.Len(t, rows, 2, format)
+		assert.Empty(t, rows[0].errors, format)
+		assert.Equal(t, "鎌倉幕府の成立", rows[0].data.Title, format)
+		assert.Equal(t, "日本 史", rows[0].data.Genre, format)
+		assert.Equal(t, "諸説あり", rows[0].data.Explanation, format)
+		assert.Equal(t, []dto.ImportChoice{{Text: "1185年", IsCorrect: true}, {Text: "1192年"}}, rows[0].data.Choices, format)
+		assert.Equal(t, entities.TypeTrueFalse, rows[1].data.Type, format)
+		assert.Equal(t, entities.BodyFormatMarkdown, rows[1].data.BodyFormat, format)
+	}
+}
+
+func TestQuestionExport_GenreScopeIsPublishedOnly(t *testing.T) {
+	usecase, _ := newTestExportUsecase()
+
+	// 作成者には自分の問題の正解のみ書き出す
+	data := exportToBytes(t, usecase, dto.ExportQuestionsRequest{Format: dto.ExportFormatAnki, Scope: dto.ExportScopeGenre, GenreID: 1}, "user-1")
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, "#separator:tab", lines[0])
+	fields := strings.Split(lines[3], "\t")
+	require.Len(t, fields, 3)
+	assert.Contains(t, fields[0], "<li>1185年</li>")
+	assert.Contains(t, fields[1], "正解: 1185年")
+	assert.Equal(t, "日本_史", fields[2])
+	assert.Contains(t, lines[4], "他人の問題")
+	assert.NotContains(t, lines[4], "正解")
+}
+
+func TestQuestionExport_OmitsAnswersForNonAuthors(t *testing.T) {
+	usecase, _ := newTestExportUsecase()
+
+	for _, format := range []string{dto.ExportFormatCSV, dto.ExportFormatJSON, dto.ExportFormatAnki} {
+		data := exportToBytes(t, usecase, dto.ExportQuestionsRequest{Format: format, Scope: dto.ExportScopeGenre, GenreID: 1}, "")
+		assert.NotContains(t, string(data), "is_correct", format)
+		assert.NotContains(t, string(data), "正解", format)
+
+		if format != dto.ExportFormatAnki {
+			rows, err := parseImportRows(format, data)
+			require.NoError(t, err, format)
+			require.Len(t, rows, 2, format)
+			assert.Equal(t, []dto.ImportChoice{{Text: "1185年"}, {Text: "1192年"}}, rows[0].data.Choices, format)
+		}
+	}
+}
+
+func TestQuestionExport_BookmarkScope(t *testing.T) {
+	usecase, _ := newTestExportUsecase()
+
+	_, err := usecase.PrepareExport(context.Background(), dto.ExportQuestionsRequest{Format: dto.ExportFormatJSON, Scope: dto.ExportScopeBookmarks}, "", "")
+	assert.Equal(t, "UNAUTHORIZED", err.(shared.DomainError).Code)
+
+	// 他のユーザーの下書きはブックマークしていても書き出さず、正解は自分の問題の分のみ書き出す
+	data := exportToBytes(t, usecase, dto.ExportQuestionsRequest{Format: dto.ExportFormatJSON, Scope: dto.ExportScopeBookmarks}, "user-2")
+	rows, err := parseImportRows(dto.ImportFormatJSON, data)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "鎌倉幕府の成立", rows[0].data.Title)
+	assert.Equal(t, []dto.ImportChoice{{Text: "1185年"}, {Text: "1192年"}}, rows[0].data.Choices)
+	assert.Equal(t, "他人の問題", rows[1].data.Title)
+	assert.Equal(t, []dto.ImportChoice{{Text: "A", IsCorrect: true}}, rows[1].data.Choices)
+
+	// ブックマークが無い場合は空のエクスポートになる
+	data = exportToBytes(t, usecase, dto.ExportQuestionsRequest{Format: dto.ExportFormatJSON, Scope: dto.ExportScopeBookmarks}, "user-3")
+	assert.JSONEq(t, `{"questions":[]}`, string(data))
+}
+
+func TestQuestionExport_StreamsInPages(t *testing.T) {
+	usecase, questionRepo := newTestExportUsecase()
+	questionRepo.questions = nil
+	for i := 1; i <= exportPageSize+1; i++ {
+		questionRepo.questions = append(questionRepo.questions, &entities.Question{ID: int64(i), GenreID: 1, UserID: "user-1", Title: fmt.Sprintf("問題%d", i), Status: entities.StatusDraft})
+	}
+
+	data := exportToBytes(t, usecase, dto.ExportQuestionsRequest{Format: dto.ExportFormatJSON, Scope: dto.ExportScopeMine}, "user-1")
+
+	rows, err := parseImportRows(dto.ImportFormatJSON, data)
+	require.NoError(t, err)
+	assert.Len(t, rows, exportPageSize+1)
+	assert.Equal(t, 2, questionRepo.pages)
+}
+
+func TestQuestionExport_RejectsInvalidRequest(t *testing.T) {
+	usecase, _ := newTestExportUsecase()
+	ctx := context.Background()
+
+	_, err := usecase.PrepareExport(ctx, dto.ExportQuestionsRequest{Format: "xml", Scope: dto.ExportScopeMine}, "user-1", "token")
+	assert.IsType(t, shared.ValidationError{}, err)
+
+	_, err = usecase.PrepareExport(ctx, dto.ExportQuestionsRequest{Format: dto.ExportFormatCSV, Scope: dto.ExportScopeMine}, "", "")
+	assert.Equal(t, "UNAUTHORIZED", err.(shared.DomainError).Code)
+
+	_, err = usecase.PrepareExport(ctx, dto.ExportQuestionsRequest{Format: dto.ExportFormatCSV, Scope: dto.ExportScopeGenre, GenreID: 99}, "", "")
+	assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code)
+}
@@ -0,0 +1,137 @@
+package usecases
+
+// question_feed_usecase.goはフォローしている作成者・ジャンルの新しい問題をまとめたフィードのユースケースを定義
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Shittaka_back/internal/application/question/dto"
+	followRepositories "Shittaka_back/internal/domain/follow/repositories"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// QuestionFeedUsecase はフィードのユースケース
+type QuestionFeedUsecase struct {
+	questionRepo repositories.QuestionRepository
+	followRepo   followRepositories.FollowRepository
+	questions    *QuestionUsecase
+}
+
+// NewQuestionFeedUsecase は新しいQuestionFeedUsecaseを作成
+// レスポンスへの変換（タグ・添付画像の付与）は questions に任せる
+func NewQuestionFeedUsecase(questionRepo repositories.QuestionRepository, followRepo followRepositories.FollowRepository, questions *QuestionUsecase) *QuestionFeedUsecase {
+	return &QuestionFeedUsecase{
+		questionRepo: questionRepo,
+		followRepo:   followRepo,
+		questions:    questions,
+	}
+}
+
+// GetFeed はフォローしている作成者とジャンルの公開中の問題を、公開日時の新しい順にカーソルでページングして取得する（認証が必要）
+// 新しい問題が公開されてもページがずれないよう、オフセットではなく最後に返した問題の位置をカーソルにする
+func (u *QuestionFeedUsecase) GetFeed(ctx context.Context, userID string, req dto.FeedRequest) (*dto.FeedResponse, error) {
+	if userID == "" {
+		return nil, shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultQuestionListLimit
+	}
+	if limit > maxQuestionListLimit {
+		limit = maxQuestionListLimit
+	}
+
+	var before *repositories.FeedPosition
+	if req.Cursor != "" {
+		position, err := decodeFeedCursor(req.Cursor)
+		if err != nil {
+			return nil, shared.NewValidationError("cursor", "カーソルの形式が正しくありません")
+		}
+		before = position
+	}
+
+	authorIDs, err := u.followRepo.GetFollowingIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	genreIDs, err := u.followRepo.GetFollowedGenreIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.FeedResponse{Questions: []*dto.QuestionResponse{}}
+	if len(authorIDs) == 0 && len(genreIDs) == 0 {
+		return response, nil
+	}
+
+	// 続きがあるか判定するため1件多く取得する
+	questions, err := u.questionRepo.GetFeed(ctx, repositories.QuestionFeedQuery{
+		AuthorIDs: authorIDs,
+		GenreIDs:  genreIDs,
+		Before:    before,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(questions) > limit
+	if hasMore {
+		questions = questions[:limit]
+	}
+
+	// レスポンスDTOに変換
+	for _, question := range questions {
+		response.Questions = append(response.Questions, u.questions.toQuestionResponse(question, userID))
+	}
+	if err := u.questions.attachDetails(ctx, response.Questions); err != nil {
+		return nil, err
+	}
+
+	if hasMore {
+		// 公開中の問題は公開日時を持つ（公開日時の列を追加する前の問題もマイグレーションで作成日時を設定している）
+		last := questions[len(questions)-1]
+		publishedAt := last.CreatedAt
+		if last.PublishedAt != nil {
+			publishedAt = *last.PublishedAt
+		}
+		response.NextCursor = encodeFeedCursor(repositories.FeedPosition{PublishedAt: publishedAt, ID: last.ID})
+	}
+
+	return response, nil
+}
+
+// encodeFeedCursor はフィード上の位置をクライアントに渡すカーソル文字列に変換
+func encodeFeedCursor(position repositories.FeedPosition) string {
+	raw := fmt.Sprintf("%s,%d", position.PublishedAt.UTC().Format(time.RFC3339Nano), position.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeFeedCursor はカーソル文字列をフィード上の位置に戻す
+func decodeFeedCursor(cursor string) (*repositories.FeedPosition, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	publishedAt, id, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid feed cursor %q", raw)
+	}
+
+	position := &repositories.FeedPosition{}
+	if position.PublishedAt, err = time.Parse(time.RFC3339Nano, publishedAt); err != nil {
+		return nil, err
+	}
+	if position.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, err
+	}
+	return position, nil
+}
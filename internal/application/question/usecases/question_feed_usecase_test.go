@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"Shittaka_back/internal/application/question/dto"
+	followRepositories "Shittaka_back/internal/domain/follow/repositories"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFeedQuestionRepository は作成者・ジャンルで絞り込んだ問題を公開日時とIDの降順に返すテスト用リポジトリ
+type fakeFeedQuestionRepository struct {
+	repositories.QuestionRepository
+	questions []*entities.Question
+}
+
+func (r *fakeFeedQuestionRepository) GetFeed(ctx context.Context, query repositories.QuestionFeedQuery) ([]*entities.Question, error) {
+	var matched []*entities.Question
+	for _, question := range r.questions {
+		source := false
+		for _, id := range query.AuthorIDs {
+			source = source || question.UserID == id
+		}
+		for _, id := range query.GenreIDs {
+			source = source || question.GenreID == id
+		}
+		if !source {
+			continue
+		}
+		if before := query.Before; before != nil {
+			if question.PublishedAt.After(before.PublishedAt) || question.PublishedAt.Equal(before.PublishedAt) && question.ID >= before.ID {
+				continue
+			}
+		}
+		matched = append(matched, question)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].PublishedAt.Equal(*matched[j].PublishedAt) {
+			return matched[i].PublishedAt.After(*matched[j].PublishedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+	return matched, nil
+}
+
+// fakeFeedFollowRepository はフォローしているユーザー・ジャンルを返すテスト用リポジトリ
+type fakeFeedFollowRepository struct {
+	followRepositories.FollowRepository
+	users  []string
+	genres []int64
+}
+
+func (r *fakeFeedFollowRepository) GetFollowingIDs(ctx context.Context, userID string) ([]string, error) {
+	return r.users, nil
+}
+
+func (r *fakeFeedFollowRepository) GetFollowedGenreIDs(ctx context.Context, userID string) ([]int64, error) {
+	return r.genres, nil
+}
+
+func TestQuestionFeed_PagesWithCursor(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		published := base.Add(time.Duration(minutes) * time.Minute)
+		return &published
+	}
+	questionRepo := &fakeFeedQuestionRepository{questions: []*entities.Question{
+		{ID: 1, UserID: "author", GenreID: 9, PublishedAt: at(0)},
+		{ID: 2, UserID: "other", GenreID: 5, PublishedAt: at(10)},
+		{ID: 3, UserID: "author", GenreID: 9, PublishedAt: at(10)}, // 公開日時が同じ問題はIDの大きい順
+		{ID: 4, UserID: "stranger", GenreID: 9, PublishedAt: at(20)},
+		{ID: 5, UserID: "other", GenreID: 5, PublishedAt: at(30)},
+	}}
+	followRepo := &fakeFeedFollowRepository{users: []string{"author"}, genres: []int64{5}}
+	usecase := NewQuestionFeedUsecase(questionRepo, followRepo, NewQuestionUsecase(questionRepo, nil, nil, nil, nil, nil, nil, nil))
+
+	var ids []int64
+	req := dto.FeedRequest{Limit: 2}
+	for page := 0; page < 5; page++ {
+		feed, err := usecase.GetFeed(context.Background(), "reader", req)
+		require.NoError(t, err)
+		for _, question := range feed.Questions {
+			ids = append(ids, question.ID)
+		}
+		if feed.NextCursor == "" {
+			break
+		}
+		req.Cursor = feed.NextCursor
+	}
+
+	assert.Equal(t, []int64{5, 3, 2, 1}, ids)
+}
+
+func TestQuestionFeed_EmptyAndInvalid(t *testing.T) {
+	questionRepo := &fakeFeedQuestionRepository{}
+	usecase := NewQuestionFeedUsecase(questionRepo, &fakeFeedFollowRepository{}, NewQuestionUsecase(questionRepo, nil, nil, nil, nil, nil, nil, nil))
+
+	feed, err := usecase.GetFeed(context.Background(), "reader", dto.FeedRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, feed.Questions)
+	assert.Empty(t, feed.NextCursor)
+
+	_, err = usecase.GetFeed(context.Background(), "reader", dto.FeedRequest{Cursor: "not-a-cursor"})
+	assert.IsType(t, shared.ValidationError{}, err)
+
+	_, err = usecase.GetFeed(context.Background(), "", dto.FeedRequest{})
+	assert.Equal(t, "UNAUTHORIZED", err.(shared.DomainError).Code)
+}
@@ -0,0 +1,306 @@
+package usecases
+
+// question_import_usecase.goは問題をCSV・JSONから一括でインポートするユースケースを定義
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	genreUsecases "Shittaka_back/internal/application/genre/usecases"
+	"Shittaka_back/internal/application/question/dto"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/question/services"
+	"Shittaka_back/internal/domain/shared"
+)
+
+const (
+	// MaxImportRows は1回のインポートで受け付ける最大行数
+	MaxImportRows = 1000
+	// importBatchSize は1回のリクエストでまとめて保存する問題数
+	importBatchSize = 50
+)
+
+// QuestionImportUsecase は問題の一括インポートユースケース
+type QuestionImportUsecase struct {
+	questionRepo repositories.QuestionRepository
+	revisionRepo repositories.QuestionRevisionRepository
+	choiceRepo   choiceRepositories.ChoiceRepository
+	unitOfWork   repositories.QuestionUnitOfWork
+	genreUsecase *genreUsecases.GenreUsecase
+}
+
+// NewQuestionImportUsecase は新しいQuestionImportUsecaseを作成
+// unitOfWork が nil の場合（トランザクションに対応しない保存先）は失敗したバッチの問題を削除して取り消す
+func NewQuestionImportUsecase(questionRepo repositories.QuestionRepository, revisionRepo repositories.QuestionRevisionRepository, choiceRepo choiceRepositories.ChoiceRepository, unitOfWork repositories.QuestionUnitOfWork, genreUsecase *genreUsecases.GenreUsecase) *QuestionImportUsecase {
+	return &QuestionImportUsecase{
+		questionRepo: questionRepo,
+		revisionRepo: revisionRepo,
+		choiceRepo:   choiceRepo,
+		unitOfWork:   unitOfWork,
+		genreUsecase: genreUsecase,
+	}
+}
+
+// importCandidate は検証を通過し保存を待つ問題
+type importCandidate struct {
+	result   *dto.ImportRowResult
+	question *entities.Question
+	choices  []choiceEntities.Choice
+}
+
+// ImportQuestions はファイルの全行を検証し、ドライランでなければ有効な行をまとめて保存する
+// 無効な行があっても有効な行は保存し、行ごとの結果をレポートとして返す
+func (u *QuestionImportUsecase) ImportQuestions(ctx context.Context, req dto.ImportQuestionsRequest, userID string, userToken string) (*dto.ImportQuestionsResponse, error) {
+	rows, err := parseImportRows(req.Format, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, shared.NewValidationError("file", "インポートする問題がありません")
+	}
+	if len(rows) > MaxImportRows {
+		return nil, shared.NewValidationError("file", "一度にインポートできる問題は1000件までです")
+	}
+
+	genres, err := u.genreUsecase.GetAllGenres(ctx)
+	if err != nil {
+		return nil, err
+	}
+	genreIDs := make(map[string]int64, len(genres))
+	for _, genre := range genres {
+		genreIDs[genreKey(genre.Name)] = genre.ID
+	}
+
+	response := &dto.ImportQuestionsResponse{
+		DryRun:        req.DryRun,
+		Total:         len(rows),
+		CreatedGenres: []string{},
+		Rows:          make([]*dto.ImportRowResult, len(rows)),
+	}
+
+	// 全行を検証し、存在しないジャンルを洗い出す
+	var candidates []*importCandidate
+	var missingGenres []string
+	seenMissing := make(map[string]bool)
+	for i, row := range rows {
+		result := &dto.ImportRowResult{Row: row.row, Title: row.data.Title, Genre: row.data.Genre}
+		response.Rows[i] = result
+		if row.unreadable {
+			result.Errors = row.errors
+			continue
+		}
+
+		candidate := u.validateRow(row, userID, req.Publish)
+		result.Errors = append(row.errors, candidate.result.Errors...)
+		if len(result.Errors) > 0 {
+			continue
+		}
+		candidate.result = result
+		candidates = append(candidates, candidate)
+
+		key := genreKey(row.data.Genre)
+		if _, ok := genreIDs[key]; !ok && !seenMissing[key] {
+			seenMissing[key] = true
+			missingGenres = append(missingGenres, row.data.Genre)
+		}
+	}
+	response.Valid = len(candidates)
+
+	if req.DryRun {
+		response.CreatedGenres = append(response.CreatedGenres, missingGenres...)
+		response.Failed = response.Total - response.Valid
+		return response, nil
+	}
+
+	// 存在しないジャンルを作成する（失敗したジャンルの行は保存しない）
+	genreErrors := make(map[string]string)
+	for _, name := range missingGenres {
+		genre, created, err := u.genreUsecase.EnsureGenre(ctx, name, userToken)
+		if err != nil {
+			genreErrors[genreKey(name)] = importErrorMessage(err, "ジャンルの作成に失敗しました")
+			continue
+		}
+		genreIDs[genreKey(name)] = genre.ID
+		if created {
+			response.CreatedGenres = append(response.CreatedGenres, genre.Name)
+		}
+	}
+
+	var pending []*importCandidate
+	for _, candidate := range candidates {
+		key := genreKey(candidate.result.Genre)
+		if message, ok := genreErrors[key]; ok {
+			candidate.result.Errors = append(candidate.result.Errors, dto.ImportRowError{Field: "genre", Message: message})
+			continue
+		}
+		candidate.question.GenreID = genreIDs[key]
+		pending = append(pending, candidate)
+	}
+
+	for start := 0; start < len(pending); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		u.commitBatch(ctx, pending[start:end], userID, userToken)
+	}
+
+	for _, result := range response.Rows {
+		if result.QuestionID != 0 {
+			response.Imported++
+		} else {
+			response.Failed++
+		}
+	}
+	return response, nil
+}
+
+// validateRow は1行分のデータを検証し、保存する問題と選択肢を組み立てる
+// 検証エラーは返り値の result.Errors に記録する
+func (u *QuestionImportUsecase) validateRow(row *importRow, userID string, publish bool) *importCandidate {
+	candidate := &importCandidate{result: &dto.ImportRowResult{}}
+	addError := func(field, message string) {
+		candidate.result.Errors = append(candidate.result.Errors, dto.ImportRowError{Field: field, Message: message})
+	}
+
+	data := row.data
+	if data.Title == "" {
+		addError("title", "問題タイトルは必須です")
+	} else if len(data.Title) > 200 {
+		addError("title", "問題タイトルは200文字以内で入力してください")
+	}
+	if data.Genre == "" {
+		addError("genre", "ジャンル名は必須です")
+	} else if len(data.Genre) > 50 {
+		addError("genre", "ジャンル名は50文字以内で入力してください")
+	}
+
+	question := entities.NewQuestion(0, userID, data.Title, data.Body, data.Explanation)
+	if data.Type != "" {
+		question.Type = data.Type
+	}
+	if data.BodyFormat != "" {
+		question.BodyFormat = data.BodyFormat
+	}
+	if !entities.IsValidType(question.Type) {
+		addError("type", "問題の種類が不正です")
+		return candidate
+	}
+	if !question.UsesChoices() {
+		addError("type", "インポートできるのは選択肢で回答する問題のみです")
+		return candidate
+	}
+	if !entities.IsValidBodyFormat(question.BodyFormat) {
+		addError("body_format", "本文の形式は plain または markdown を指定してください")
+	}
+
+	choices := make([]choiceEntities.Choice, len(data.Choices))
+	for i, choice := range data.Choices {
+		if strings.TrimSpace(choice.Text) == "" {
+			addError("choices", "選択肢の本文は必須です")
+		}
+		choices[i] = choiceEntities.Choice{Text: strings.TrimSpace(choice.Text), IsCorrect: choice.IsCorrect, Position: i + 1}
+	}
+	if err := services.ValidateAnswerKey(question, choices); err != nil {
+		if validationErr, ok := err.(shared.ValidationError); ok {
+			addError(validationErr.Field, validationErr.Message)
+		} else {
+			addError("choices", err.Error())
+		}
+	}
+
+	if publish {
+		question.Publish(time.Now())
+	}
+	candidate.question = question
+	candidate.choices = choices
+	return candidate
+}
+
+// commitBatch は問題・初版リビジョン・選択肢をまとめて保存する
+// 途中で失敗した場合は保存を取り消し、バッチ内の全行を失敗として記録する
+func (u *QuestionImportUsecase) commitBatch(ctx context.Context, batch []*importCandidate, userID string, userToken string) {
+	questions := make([]*entities.Question, len(batch))
+	for i, candidate := range batch {
+		questions[i] = candidate.question
+	}
+
+	repos := repositories.QuestionWriteRepositories{
+		Questions: u.questionRepo,
+		Revisions: u.revisionRepo,
+		Choices:   u.choiceRepo,
+	}
+	var created []*entities.Question
+	err := runInTransaction(ctx, u.unitOfWork, repos, func(repos repositories.QuestionWriteRepositories) error {
+		var err error
+		if created, err = repos.Questions.CreateBatch(ctx, questions, userToken); err != nil {
+			return err
+		}
+
+		revisions := make([]*entities.QuestionRevision, len(created))
+		var choices []choiceEntities.Choice
+		for i, question := range created {
+			revisions[i] = entities.NewQuestionRevision(question, userID, services.BuildRevisionDiff(nil, question))
+			for _, choice := range batch[i].choices {
+				choice.QuestionID = question.ID
+				choices = append(choices, choice)
+			}
+		}
+
+		if _, err := repos.Revisions.CreateBatch(ctx, revisions, userToken); err != nil {
+			return err
+		}
+		_, err = repos.Choices.CreateBatch(ctx, choices, userToken)
+		return err
+	})
+	if err != nil {
+		// トランザクションで保存した場合は既に取り消されている
+		if u.unitOfWork == nil {
+			u.rollbackBatch(ctx, created, userToken)
+		}
+		markBatchFailed(batch, err)
+		return
+	}
+
+	for i, question := range created {
+		batch[i].result.QuestionID = question.ID
+	}
+}
+
+// rollbackBatch は保存に失敗したバッチで作成済みの問題を削除する
+// トランザクションを使えない保存先で、リビジョン・選択肢の保存に失敗した場合に使う
+func (u *QuestionImportUsecase) rollbackBatch(ctx context.Context, created []*entities.Question, userToken string) {
+	for _, question := range created {
+		// 削除に失敗しても下書きとして残るだけなので、エラーは無視する
+		_ = u.questionRepo.Delete(ctx, question.ID, userToken)
+	}
+}
+
+// markBatchFailed はバッチ内の全行に保存失敗のエラーを記録する
+func markBatchFailed(batch []*importCandidate, err error) {
+	message := importErrorMessage(err, "問題の保存に失敗しました")
+	for _, candidate := range batch {
+		candidate.result.Errors = append(candidate.result.Errors, dto.ImportRowError{Field: "row", Message: message})
+	}
+}
+
+// importErrorMessage はレポートに載せるエラーメッセージを返す
+// 内部エラーの詳細は利用者に見せず、既定のメッセージに置き換える
+func importErrorMessage(err error, fallback string) string {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		return e.Message
+	case shared.DomainError:
+		return e.Message
+	}
+	return fallback
+}
+
+// genreKey はジャンル名を照合するためのキーを返す（ジャンル作成時と同じ表記ゆれの規則を使う）
+func genreKey(name string) string {
+	return shared.LabelKey(name)
+}
@@ -0,0 +1,262 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	genreUsecases "Shittaka_back/internal/application/genre/usecases"
+	"Shittaka_back/internal/application/question/dto"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	genreEntities "Shittaka_back/internal/domain/genre/entities"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeImportQuestionRepository は一括作成した問題に連番のIDを振るテスト用リポジトリ
+type fakeImportQuestionRepository struct {
+	repositories.QuestionRepository
+	created []*entities.Question
+	deleted []int64
+}
+
+func (r *fakeImportQuestionRepository) CreateBatch(ctx context.Context, questions []*entities.Question, userToken string) ([]*entities.Question, error) {
+	result := make([]*entities.Question, len(questions))
+	for i, question := range questions {
+		created := *question
+		created.ID = int64(len(r.created) + 1)
+		r.created = append(r.created, &created)
+		result[i] = &created
+	}
+	return result, nil
+}
+
+func (r *fakeImportQuestionRepository) Delete(ctx context.Context, id int64, userToken string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+// fakeImportRevisionRepository はリビジョンの一括作成を記録するテスト用リポジトリ
+type fakeImportRevisionRepository struct {
+	repositories.QuestionRevisionRepository
+	created []*entities.QuestionRevision
+}
+
+func (r *fakeImportRevisionRepository) CreateBatch(ctx context.Context, revisions []*entities.QuestionRevision, userToken string) ([]*entities.QuestionRevision, error) {
+	r.created = append(r.created, revisions...)
+	return revisions, nil
+}
+
+// fakeImportChoiceRepository は選択肢の一括作成を記録するテスト用リポジトリ
+// err を設定した場合は一括作成に失敗する
+type fakeImportChoiceRepository struct {
+	choiceRepositories.ChoiceRepository
+	created []choiceEntities.Choice
+	err     error
+}
+
+func (r *fakeImportChoiceRepository) CreateBatch(ctx context.Context, choices []choiceEntities.Choice, userToken string) ([]choiceEntities.Choice, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.created = append(r.created, choices...)
+	return choices, nil
+}
+
+// fakeUnitOfWork は保持しているリポジトリで fn を実行し、呼び出し回数と取り消したかを記録するテスト用ユニットオブワーク
+type fakeUnitOfWork struct {
+	repos      repositories.QuestionWriteRepositories
+	calls      int
+	rolledBack bool
+}
+
+func (u *fakeUnitOfWork) Do(ctx context.Context, fn func(repos repositories.QuestionWriteRepositories) error) error {
+	u.calls++
+	if err := fn(u.repos); err != nil {
+		u.rolledBack = true
+		return err
+	}
+	return nil
+}
+
+// fakeGenreRepository はメモリ上でジャンルを管理するテスト用リポジトリ
+type fakeGenreRepository struct {
+	genres []*genreEntities.Genre
+}
+
+func (r *fakeGenreRepository) Create(ctx context.Context, genre *genreEntities.Genre, userToken string) (*genreEntities.Genre, error) {
+	created := *genre
+	created.ID = int64(len(r.genres) + 1)
+	r.genres = append(r.genres, &created)
+	return &created, nil
+}
+
+func (r *fakeGenreRepository) FindByID(ctx context.Context, id int64) (*genreEntities.Genre, error) {
+	for _, genre := range r.genres {
+		if genre.ID == id {
+			return genre, nil
+		}
+	}
+	return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
+}
+
+func (r *fakeGenreRepository) FindAll(ctx context.Context) ([]*genreEntities.Genre, error) {
+	return r.genres, nil
+}
+
+func (r *fakeGenreRepository) FindByName(ctx context.Context, name string, userToken string) (*genreEntities.Genre, error) {
+	for _, genre := range r.genres {
+		if genre.Name == name {
+			return genre, nil
+		}
+	}
+	return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
+}
+
+func newTestImportUsecase() (*QuestionImportUsecase, *fakeImportQuestionRepository, *fakeImportChoiceRepository, *fakeGenreRepository) {
+	questionRepo := &fakeImportQuestionRepository{}
+	choiceRepo := &fakeImportChoiceRepository{}
+	genreRepo := &fakeGenreRepository{genres: []*genreEntities.Genre{{ID: 1, Name: "歴史"}}}
+	usecase := NewQuestionImportUsecase(questionRepo, &fakeImportRevisionRepository{}, choiceRepo, nil, genreUsecases.NewGenreUsecase(genreRepo))
+	return usecase, questionRepo, choiceRepo, genreRepo
+}
+
+const importCSV = "title,genre,body,explanation,choice1,choice2,choice3,correct\n" +
+	"鎌倉幕府の成立,歴史,いつ？,諸説あり,1185年,1192年,1221年,1\n" +
+	"水の化学式,化学,,,H2O,CO2,,1\n" +
+	",歴史,,,A,B,,1\n" +
+	"正解なし,歴史,,,A,B,,3\n"
+
+func TestQuestionImport_DryRunReportsRowErrors(t *testing.T) {
+	usecase, questionRepo, _, genreRepo := newTestImportUsecase()
+
+	res, err := usecase.ImportQuestions(context.Background(), dto.ImportQuestionsRequest{
+		Format: dto.ImportFormatCSV,
+		Data:   []byte(importCSV),
+		DryRun: true,
+	}, "user-1", "token")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, res.Total)
+	assert.Equal(t, 2, res.Valid)
+	assert.Equal(t, 2, res.Failed)
+	assert.Equal(t, []string{"化学"}, res.CreatedGenres)
+	assert.Equal(t, 4, res.Rows[2].Row)
+	assert.Equal(t, "title", res.Rows[2].Errors[0].Field)
+	assert.Equal(t, "correct", res.Rows[3].Errors[0].Field)
+
+	// ドライランでは何も保存しない
+	assert.Empty(t, questionRepo.created)
+	assert.Len(t, genreRepo.genres, 1)
+}
+
+func TestQuestionImport_CommitsValidRows(t *testing.T) {
+	usecase, questionRepo, choiceRepo, genreRepo := newTestImportUsecase()
+
+	res, err := usecase.ImportQuestions(context.Background(), dto.ImportQuestionsRequest{
+		Format:  dto.ImportFormatCSV,
+		Data:    []byte(importCSV),
+		Publish: true,
+	}, "user-1", "token")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, res.Imported)
+	assert.Equal(t, 2, res.Failed)
+	assert.Equal(t, []string{"化学"}, res.CreatedGenres)
+	assert.Len(t, genreRepo.genres, 2)
+
+	require.Len(t, questionRepo.created, 2)
+	assert.Equal(t, int64(1), questionRepo.created[0].GenreID)
+	assert.Equal(t, int64(2), questionRepo.created[1].GenreID)
+	assert.Equal(t, entities.StatusPublished, questionRepo.created[0].Status)
+	assert.Equal(t, questionRepo.created[0].ID, res.Rows[0].QuestionID)
+
+	require.Len(t, choiceRepo.created, 5)
+	assert.Equal(t, questionRepo.created[0].ID, choiceRepo.created[0].QuestionID)
+	assert.True(t, choiceRepo.created[0].IsCorrect)
+	assert.Equal(t, 3, choiceRepo.created[2].Position)
+}
+
+func TestQuestionImport_JSON(t *testing.T) {
+	usecase, questionRepo, _, _ := newTestImportUsecase()
+
+	data := `{"questions": [
+		{"title": "○×", "genre": "歴史", "type": "true_false", "choices": [{"text": "○", "is_correct": true}, {"text": "×"}]},
+		{"title": "自由記述", "genre": "歴史", "type": "free_text"},
+		"壊れた行"
+	]}`
+	res, err := usecase.ImportQuestions(context.Background(), dto.ImportQuestionsRequest{
+		Format: dto.ImportFormatJSON,
+		Data:   []byte(data),
+	}, "user-1", "token")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, res.Imported)
+	assert.Equal(t, "type", res.Rows[1].Errors[0].Field)
+	assert.Equal(t, "row", res.Rows[2].Errors[0].Field)
+	require.Len(t, questionRepo.created, 1)
+	assert.Equal(t, entities.TypeTrueFalse, questionRepo.created[0].Type)
+	assert.Equal(t, entities.StatusDraft, questionRepo.created[0].Status)
+}
+
+func TestQuestionImport_RejectsBrokenFile(t *testing.T) {
+	usecase, _, _, _ := newTestImportUsecase()
+
+	_, err := usecase.ImportQuestions(context.Background(), dto.ImportQuestionsRequest{
+		Format: dto.ImportFormatCSV,
+		Data:   []byte("body,explanation\nx,y\n"),
+	}, "user-1", "token")
+	assert.IsType(t, shared.ValidationError{}, err)
+
+	_, err = usecase.ImportQuestions(context.Background(), dto.ImportQuestionsRequest{
+		Format: "xml",
+		Data:   []byte("<questions/>"),
+	}, "user-1", "token")
+	assert.IsType(t, shared.ValidationError{}, err)
+}
+
+func TestQuestionImport_FailedBatchIsRolledBack(t *testing.T) {
+	request := dto.ImportQuestionsRequest{Format: dto.ImportFormatCSV, Data: []byte(importCSV)}
+	genres := func() *genreUsecases.GenreUsecase {
+		return genreUsecases.NewGenreUsecase(&fakeGenreRepository{genres: []*genreEntities.Genre{{ID: 1, Name: "歴史"}}})
+	}
+
+	t.Run("トランザクションが無い場合は作成済みの問題を削除する", func(t *testing.T) {
+		questionRepo := &fakeImportQuestionRepository{}
+		choiceRepo := &fakeImportChoiceRepository{err: errors.New("insert failed")}
+		usecase := NewQuestionImportUsecase(questionRepo, &fakeImportRevisionRepository{}, choiceRepo, nil, genres())
+
+		res, err := usecase.ImportQuestions(context.Background(), request, "user-1", "token")
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, res.Imported)
+		assert.Equal(t, []int64{1, 2}, questionRepo.deleted)
+		assert.Equal(t, "row", res.Rows[0].Errors[0].Field)
+	})
+
+	t.Run("トランザクションがある場合はまとめて取り消す", func(t *testing.T) {
+		questionRepo := &fakeImportQuestionRepository{}
+		unitOfWork := &fakeUnitOfWork{repos: repositories.QuestionWriteRepositories{
+			Questions: questionRepo,
+			Revisions: &fakeImportRevisionRepository{},
+			Choices:   &fakeImportChoiceRepository{err: errors.New("insert failed")},
+		}}
+		// 書き込みはユニットオブワークのリポジトリだけで行う
+		usecase := NewQuestionImportUsecase(nil, nil, nil, unitOfWork, genres())
+
+		res, err := usecase.ImportQuestions(context.Background(), request, "user-1", "token")
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, res.Imported)
+		assert.Equal(t, 1, unitOfWork.calls)
+		assert.True(t, unitOfWork.rolledBack)
+		assert.Empty(t, questionRepo.deleted)
+		assert.Zero(t, res.Rows[0].QuestionID)
+		assert.Equal(t, "row", res.Rows[0].Errors[0].Field)
+	})
+}
@@ -3,26 +3,57 @@ package usecases
 import (
 	"context"
 	"strings"
+	"time"
 
+	attachmentDto "Shittaka_back/internal/application/attachment/dto"
 	"Shittaka_back/internal/application/question/dto"
+	attachmentRepositories "Shittaka_back/internal/domain/attachment/repositories"
+	authEntities "Shittaka_back/internal/domain/auth/entities"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
 	"Shittaka_back/internal/domain/question/entities"
 	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/question/services"
 	"Shittaka_back/internal/domain/shared"
+	tagEntities "Shittaka_back/internal/domain/tag/entities"
+	tagRepositories "Shittaka_back/internal/domain/tag/repositories"
+)
+
+const (
+	// defaultQuestionListLimit は1ページあたりの問題数の既定値
+	defaultQuestionListLimit = 20
+	// maxQuestionListLimit は1ページあたりの問題数の上限
+	maxQuestionListLimit = 100
 )
 
 // QuestionUsecase は問題ユースケース
 type QuestionUsecase struct {
-	questionRepo repositories.QuestionRepository
+	questionRepo   repositories.QuestionRepository
+	revisionRepo   repositories.QuestionRevisionRepository
+	choiceRepo     choiceRepositories.ChoiceRepository
+	attachmentRepo attachmentRepositories.AttachmentRepository
+	tagRepo        tagRepositories.TagRepository
+	unitOfWork     repositories.QuestionUnitOfWork
+	viewCounter    *ViewCounter
+	duplicates     *services.DuplicateChecker
 }
 
 // NewQuestionUsecase は新しいQuestionUsecaseを作成
-func NewQuestionUsecase(questionRepo repositories.QuestionRepository) *QuestionUsecase {
+// unitOfWork が nil の場合（トランザクションに対応しない保存先）は各リポジトリで順に書き込む
+func NewQuestionUsecase(questionRepo repositories.QuestionRepository, revisionRepo repositories.QuestionRevisionRepository, choiceRepo choiceRepositories.ChoiceRepository, attachmentRepo attachmentRepositories.AttachmentRepository, tagRepo tagRepositories.TagRepository, unitOfWork repositories.QuestionUnitOfWork, viewCounter *ViewCounter, duplicates *services.DuplicateChecker) *QuestionUsecase {
 	return &QuestionUsecase{
-		questionRepo: questionRepo,
+		questionRepo:   questionRepo,
+		revisionRepo:   revisionRepo,
+		choiceRepo:     choiceRepo,
+		attachmentRepo: attachmentRepo,
+		tagRepo:        tagRepo,
+		unitOfWork:     unitOfWork,
+		viewCounter:    viewCounter,
+		duplicates:     duplicates,
 	}
 }
 
-// CreateQuestion は新しい問題を作成する（認証が必要）
+// CreateQuestion は新しい問題を下書きとして作成する（認証が必要）
 func (u *QuestionUsecase) CreateQuestion(ctx context.Context, req dto.CreateQuestionRequest, userID string, userToken string) (*dto.QuestionResponse, error) {
 	// バリデーション
 	if err := u.validateCreateQuestionRequest(req); err != nil {
@@ -31,31 +62,114 @@ func (u *QuestionUsecase) CreateQuestion(ctx context.Context, req dto.CreateQues
 
 	// 問題エンティティを作成
 	question := entities.NewQuestion(req.GenreID, userID, req.Title, req.Body, req.Explanation)
+	if req.Type != "" {
+		question.Type = req.Type
+	}
+	question.PartialCredit = req.PartialCredit
+	question.AcceptedAnswers = req.AcceptedAnswers
+	question.NumericAnswer = req.NumericAnswer
+	question.NumericTolerance = req.NumericTolerance
+	question.ShuffleChoices = req.ShuffleChoices
+	if req.BodyFormat != "" {
+		question.BodyFormat = req.BodyFormat
+	}
 
 	// エンティティレベルでのバリデーション
 	if err := question.Validate(); err != nil {
 		return nil, err
 	}
+	tags, err := tagEntities.NewTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じジャンルの似た問題を探す（ほぼ同じ問題があれば作成しない）
+	similar, err := u.findSimilarQuestions(ctx, question, userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// 問題・初版のリビジョン・タグをまとめて保存（ユーザートークンを渡してRLS適用）
+	var createdQuestion *entities.Question
+	err = u.inTransaction(ctx, func(repos repositories.QuestionWriteRepositories) error {
+		var err error
+		if createdQuestion, err = repos.Questions.Create(ctx, question, userToken); err != nil {
+			return err
+		}
+
+		// 初版をリビジョン1として記録
+		if err := recordRevision(ctx, repos.Revisions, nil, createdQuestion, userID, userToken); err != nil {
+			return err
+		}
 
-	// リポジトリに保存（ユーザートークンを渡してRLS適用）
-	createdQuestion, err := u.questionRepo.Create(ctx, question, userToken)
+		// タグを付ける
+		tags, err = setTags(ctx, repos.Tags, createdQuestion.ID, tags, userToken)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// レスポンスDTOに変換
-	return &dto.QuestionResponse{
-		ID:             createdQuestion.ID,
-		GenreID:        createdQuestion.GenreID,
-		UserID:         createdQuestion.UserID,
-		Title:          createdQuestion.Title,
-		Body:           createdQuestion.Body,
-		Explanation:    createdQuestion.Explanation,
-		CreatedAt:      createdQuestion.CreatedAt,
-		Views:          createdQuestion.Views,
-		CorrectCount:   createdQuestion.CorrectCount,
-		IncorrectCount: createdQuestion.IncorrectCount,
-	}, nil
+	response := u.toQuestionResponse(createdQuestion, userID)
+	response.Tags = tagEntities.TagNames(tags)
+	for _, s := range similar {
+		response.SimilarQuestions = append(response.SimilarQuestions, &dto.SimilarQuestionResponse{
+			ID:         s.Question.ID,
+			Title:      s.Question.Title,
+			Similarity: s.Similarity,
+		})
+	}
+	return response, nil
+}
+
+// findSimilarQuestions は同じジャンルで閲覧できる問題（公開中の問題と自分の下書き）から似た問題を探す
+func (u *QuestionUsecase) findSimilarQuestions(ctx context.Context, question *entities.Question, userToken string) ([]services.SimilarQuestion, error) {
+	if u.duplicates == nil {
+		return nil, nil
+	}
+
+	// 他のユーザーの下書きや非公開になった問題のタイトルを警告で漏らさないよう、公開中の問題と自分の問題を別々に取得する
+	existing, err := u.getAllPages(ctx, repositories.QuestionPageQuery{GenreID: question.GenreID, PublishedOnly: true}, userToken)
+	if err != nil {
+		return nil, err
+	}
+	own, err := u.getAllPages(ctx, repositories.QuestionPageQuery{GenreID: question.GenreID, UserID: question.UserID}, userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(existing))
+	for _, q := range existing {
+		seen[q.ID] = true
+	}
+	for _, q := range own {
+		if !seen[q.ID] {
+			existing = append(existing, q)
+		}
+	}
+
+	return u.duplicates.Check(question, existing)
+}
+
+// scanPageSize は条件に合う問題を全て取得するときに1回で取得する問題数
+const scanPageSize = 500
+
+// getAllPages は条件に合う問題をページごとに取得して全てを返す
+func (u *QuestionUsecase) getAllPages(ctx context.Context, query repositories.QuestionPageQuery, userToken string) ([]*entities.Question, error) {
+	var questions []*entities.Question
+	query.Limit = scanPageSize
+	for {
+		page, err := u.questionRepo.GetPage(ctx, query, userToken)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, page...)
+		if len(page) < query.Limit {
+			return questions, nil
+		}
+		query.AfterID = page[len(page)-1].ID
+	}
 }
 
 // UpdateQuestion は問題を更新する（作成者のみ）
@@ -77,18 +191,320 @@ func (u *QuestionUsecase) UpdateQuestion(ctx context.Context, id int64, req dto.
 	}
 
 	// 問題を更新（空でない場合のみ更新）
+	updatedQuestion := *existingQuestion
 	if strings.TrimSpace(req.Title) != "" {
-		existingQuestion.Title = req.Title
+		updatedQuestion.Title = req.Title
 	}
 	if req.Body != "" {
-		existingQuestion.Body = req.Body
+		updatedQuestion.Body = req.Body
 	}
 	if req.Explanation != "" {
-		existingQuestion.Explanation = req.Explanation
+		updatedQuestion.Explanation = req.Explanation
+	}
+
+	// 問題の種類は回答の形式が変わるため下書きの間のみ変更できる
+	if req.Type != "" && req.Type != existingQuestion.Type {
+		if existingQuestion.Status != entities.StatusDraft {
+			return shared.NewDomainError("INVALID_TRANSITION", "公開後は問題の種類を変更できません")
+		}
+		updatedQuestion.Type = req.Type
+	}
+	if req.PartialCredit != nil {
+		updatedQuestion.PartialCredit = *req.PartialCredit
+	}
+	if req.AcceptedAnswers != nil {
+		updatedQuestion.AcceptedAnswers = req.AcceptedAnswers
+	}
+	if req.NumericAnswer != nil {
+		updatedQuestion.NumericAnswer = req.NumericAnswer
+	}
+	if req.NumericTolerance != nil {
+		updatedQuestion.NumericTolerance = *req.NumericTolerance
+	}
+	if req.ShuffleChoices != nil {
+		updatedQuestion.ShuffleChoices = *req.ShuffleChoices
+	}
+	if req.BodyFormat != "" {
+		updatedQuestion.BodyFormat = req.BodyFormat
+	}
+	if err := updatedQuestion.Validate(); err != nil {
+		return err
+	}
+
+	// タグは問題の内容ではないため、リビジョンを進めずに置き換える
+	var tags []*tagEntities.Tag
+	if req.Tags != nil {
+		if tags, err = tagEntities.NewTags(req.Tags); err != nil {
+			return err
+		}
+	}
+
+	// 問題の更新・リビジョン・タグをまとめて保存
+	return u.inTransaction(ctx, func(repos repositories.QuestionWriteRepositories) error {
+		if err := saveNewRevision(ctx, repos, existingQuestion, &updatedQuestion, userID, userToken); err != nil {
+			return err
+		}
+
+		if req.Tags != nil {
+			if _, err := setTags(ctx, repos.Tags, id, tags, userToken); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// setTags は問題に付いたタグを置き換え、IDを埋めたタグを返す
+func setTags(ctx context.Context, tagRepo tagRepositories.TagRepository, questionID int64, tags []*tagEntities.Tag, userToken string) ([]*tagEntities.Tag, error) {
+	if tagRepo == nil {
+		return tags, nil
+	}
+
+	ensured, err := tagRepo.EnsureTags(ctx, tags, userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]int64, len(ensured))
+	for i, tag := range ensured {
+		tagIDs[i] = tag.ID
+	}
+	if err := tagRepo.SetQuestionTags(ctx, questionID, tagIDs, userToken); err != nil {
+		return nil, err
+	}
+	return ensured, nil
+}
+
+// GetRevisions は問題の編集履歴を新しい順に取得する
+func (u *QuestionUsecase) GetRevisions(ctx context.Context, id int64, viewerID string) ([]*dto.QuestionRevisionResponse, error) {
+	// 問題の存在チェック
+	question, err := u.getVisibleQuestion(ctx, id, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := u.revisionRepo.GetByQuestionID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// レスポンスDTOに変換（正解の情報の差分は作成者にのみ返す）
+	responses := make([]*dto.QuestionRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = u.toQuestionRevisionResponse(revision)
+		if viewerID == "" || viewerID != question.UserID {
+			responses[i].Diff = services.RedactAnswerKey(revision.Diff)
+		}
+	}
+
+	return responses, nil
+}
+
+// RevertQuestion は問題を指定したリビジョンの内容に戻す（作成者のみ）
+// 履歴は書き換えず、過去の内容で新しいリビジョンを作成する
+func (u *QuestionUsecase) RevertQuestion(ctx context.Context, id int64, revision int, userID string, userToken string) (*dto.QuestionResponse, error) {
+	// 既存の問題を取得
+	existingQuestion, err := u.questionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 作成者かどうかチェック
+	if existingQuestion.UserID != userID {
+		return nil, shared.NewDomainError("FORBIDDEN", "この問題を更新する権限がありません")
+	}
+
+	target, err := u.revisionRepo.GetByRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	revertedQuestion := *existingQuestion
+	revertedQuestion.Title = target.Title
+	revertedQuestion.Body = target.Body
+	revertedQuestion.Explanation = target.Explanation
+
+	// 正解の情報も戻す（記録する前のリビジョンの場合は現在の正解の情報を使う）
+	if target.HasAnswerKey() {
+		// 問題の種類は回答の形式が変わるため下書きの間のみ変更できる
+		if target.Type != existingQuestion.Type && existingQuestion.Status != entities.StatusDraft {
+			return nil, shared.NewDomainError("INVALID_TRANSITION", "公開後は問題の種類が異なるリビジョンに戻せません")
+		}
+		revertedQuestion.Type = target.Type
+		revertedQuestion.PartialCredit = target.PartialCredit
+		revertedQuestion.AcceptedAnswers = target.AcceptedAnswers
+		revertedQuestion.NumericAnswer = target.NumericAnswer
+		revertedQuestion.NumericTolerance = target.NumericTolerance
+	}
+	if err := revertedQuestion.Validate(); err != nil {
+		return nil, err
+	}
+
+	err = u.inTransaction(ctx, func(repos repositories.QuestionWriteRepositories) error {
+		return saveNewRevision(ctx, repos, existingQuestion, &revertedQuestion, userID, userToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toQuestionResponse(&revertedQuestion, userID), nil
+}
+
+// saveNewRevision は変更があればリビジョン番号を進めて問題を更新し、履歴を記録する
+// 表示設定（選択肢のシャッフル・本文の形式）のみの変更はリビジョンを進めずに保存する
+func saveNewRevision(ctx context.Context, repos repositories.QuestionWriteRepositories, prev, next *entities.Question, userID string, userToken string) error {
+	contentChanged := services.HasContentChanges(prev, next)
+	settingsChanged := prev.ShuffleChoices != next.ShuffleChoices || prev.BodyFormat != next.BodyFormat
+	if !contentChanged && !settingsChanged {
+		return nil
+	}
+	if contentChanged {
+		next.Revision = prev.Revision + 1
 	}
 
 	// リポジトリで更新
-	return u.questionRepo.Update(ctx, existingQuestion, userToken)
+	if err := repos.Questions.Update(ctx, next, userToken); err != nil {
+		return err
+	}
+
+	if !contentChanged {
+		return nil
+	}
+	return recordRevision(ctx, repos.Revisions, prev, next, userID, userToken)
+}
+
+// recordRevision は問題の現在の内容をリビジョンとして記録する
+func recordRevision(ctx context.Context, revisionRepo repositories.QuestionRevisionRepository, prev, next *entities.Question, userID string, userToken string) error {
+	diff := services.BuildRevisionDiff(prev, next)
+	revision := entities.NewQuestionRevision(next, userID, diff)
+
+	_, err := revisionRepo.Create(ctx, revision, userToken)
+	return err
+}
+
+// inTransaction は問題とそれに付随するデータの書き込みを fn で行う
+// ユニットオブワークがあれば1つのトランザクションで行い、fn が失敗した場合は全て取り消す
+func (u *QuestionUsecase) inTransaction(ctx context.Context, fn func(repos repositories.QuestionWriteRepositories) error) error {
+	return runInTransaction(ctx, u.unitOfWork, repositories.QuestionWriteRepositories{
+		Questions: u.questionRepo,
+		Revisions: u.revisionRepo,
+		Choices:   u.choiceRepo,
+		Tags:      u.tagRepo,
+	}, fn)
+}
+
+// runInTransaction は unitOfWork があれば fn をそのトランザクションで実行する
+// 無い場合（Supabase・メモリ）はトランザクションを使わず repos でそのまま実行する
+func runInTransaction(ctx context.Context, unitOfWork repositories.QuestionUnitOfWork, repos repositories.QuestionWriteRepositories, fn func(repos repositories.QuestionWriteRepositories) error) error {
+	if unitOfWork == nil {
+		return fn(repos)
+	}
+	return unitOfWork.Do(ctx, fn)
+}
+
+// PublishQuestion は問題を公開する（作成者のみ）
+// 選択肢が2つ以上あり、正解が1つ以上ある場合のみ公開できる
+func (u *QuestionUsecase) PublishQuestion(ctx context.Context, id int64, userID string, userToken string) (*dto.QuestionResponse, error) {
+	question, err := u.getOwnQuestion(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.validateAnswerKey(ctx, question); err != nil {
+		return nil, err
+	}
+
+	if err := question.Publish(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return u.saveStatus(ctx, question, userID, userToken)
+}
+
+// ScheduleQuestion は下書きの問題を指定日時に公開するよう予約する（作成者のみ）
+// PublishAt が nil の場合は予約を取り消す
+func (u *QuestionUsecase) ScheduleQuestion(ctx context.Context, id int64, req dto.ScheduleQuestionRequest, userID string, userToken string) (*dto.QuestionResponse, error) {
+	question, err := u.getOwnQuestion(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 予約時点でも公開条件を満たしているか確認する
+	if req.PublishAt != nil {
+		if err := u.validateAnswerKey(ctx, question); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := question.SchedulePublish(req.PublishAt, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return u.saveStatus(ctx, question, userID, userToken)
+}
+
+// UnpublishQuestion は問題を下書きに戻す（作成者のみ）
+func (u *QuestionUsecase) UnpublishQuestion(ctx context.Context, id int64, userID string, userToken string) (*dto.QuestionResponse, error) {
+	question, err := u.getOwnQuestion(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := question.Unpublish(); err != nil {
+		return nil, err
+	}
+
+	return u.saveStatus(ctx, question, userID, userToken)
+}
+
+// ArchiveQuestion は問題をアーカイブする（作成者のみ）
+func (u *QuestionUsecase) ArchiveQuestion(ctx context.Context, id int64, userID string, userToken string) (*dto.QuestionResponse, error) {
+	question, err := u.getOwnQuestion(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := question.Archive(); err != nil {
+		return nil, err
+	}
+
+	return u.saveStatus(ctx, question, userID, userToken)
+}
+
+// validateAnswerKey は問題を公開できるだけの正解情報がそろっているかを検証する
+func (u *QuestionUsecase) validateAnswerKey(ctx context.Context, question *entities.Question) error {
+	var choices []choiceEntities.Choice
+	if question.UsesChoices() {
+		var err error
+		choices, err = u.choiceRepo.GetByQuestionID(ctx, question.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return services.ValidateAnswerKey(question, choices)
+}
+
+// getOwnQuestion は作成者本人の問題を取得する
+func (u *QuestionUsecase) getOwnQuestion(ctx context.Context, id int64, userID string) (*entities.Question, error) {
+	question, err := u.questionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 作成者かどうかチェック
+	if question.UserID != userID {
+		return nil, shared.NewDomainError("FORBIDDEN", "この問題を更新する権限がありません")
+	}
+
+	return question, nil
+}
+
+// saveStatus は問題の公開状態を保存してレスポンスDTOを返す
+func (u *QuestionUsecase) saveStatus(ctx context.Context, question *entities.Question, userID string, userToken string) (*dto.QuestionResponse, error) {
+	if err := u.questionRepo.UpdateStatus(ctx, question, userToken); err != nil {
+		return nil, err
+	}
+	return u.toQuestionResponse(question, userID), nil
 }
 
 // DeleteQuestion は問題を削除する（作成者のみ）
@@ -109,25 +525,49 @@ func (u *QuestionUsecase) DeleteQuestion(ctx context.Context, id int64, userID s
 }
 
 // GetQuestion は問題を取得する
-func (u *QuestionUsecase) GetQuestion(ctx context.Context, id int64) (*dto.QuestionResponse, error) {
-	question, err := u.questionRepo.GetByID(ctx, id)
+// 公開中でない問題は作成者（viewerID）以外には存在しないものとして扱う
+func (u *QuestionUsecase) GetQuestion(ctx context.Context, id int64, viewerID string) (*dto.QuestionResponse, error) {
+	question, err := u.getVisibleQuestion(ctx, id, viewerID)
 	if err != nil {
 		return nil, err
 	}
 
 	// レスポンスDTOに変換
-	return &dto.QuestionResponse{
-		ID:             question.ID,
-		GenreID:        question.GenreID,
-		UserID:         question.UserID,
-		Title:          question.Title,
-		Body:           question.Body,
-		Explanation:    question.Explanation,
-		CreatedAt:      question.CreatedAt,
-		Views:          question.Views,
-		CorrectCount:   question.CorrectCount,
-		IncorrectCount: question.IncorrectCount,
-	}, nil
+	response := u.toQuestionResponse(question, viewerID)
+	if err := u.attachDetails(ctx, []*dto.QuestionResponse{response}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// getVisibleQuestion は閲覧者が閲覧できる問題を取得する
+func (u *QuestionUsecase) getVisibleQuestion(ctx context.Context, id int64, viewerID string) (*entities.Question, error) {
+	question, err := u.questionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !question.IsVisibleTo(viewerID) {
+		return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	}
+
+	return question, nil
+}
+
+// RecordView は問題の閲覧を記録する
+// 誰でも閲覧できる問題のみを対象とし、作成者自身の閲覧は数えない
+// viewerKey はユーザーID（未ログインの場合はIP）で、一定時間内の重複閲覧は数えない
+func (u *QuestionUsecase) RecordView(question *dto.QuestionResponse, viewerID string, viewerKey string) {
+	if u.viewCounter == nil {
+		return
+	}
+	if question.Status != entities.StatusPublished || question.IsHidden {
+		return
+	}
+	if viewerID != "" && question.UserID == viewerID {
+		return
+	}
+	u.viewCounter.Record(question.ID, viewerKey)
 }
 
 // GetQuestionsByUser はユーザーの問題一覧を取得する
@@ -140,24 +580,25 @@ func (u *QuestionUsecase) GetQuestionsByUser(ctx context.Context, userID string,
 	// レスポンスDTOに変換
 	responses := make([]*dto.QuestionResponse, len(questions))
 	for i, question := range questions {
-		responses[i] = &dto.QuestionResponse{
-			ID:             question.ID,
-			GenreID:        question.GenreID,
-			UserID:         question.UserID,
-			Title:          question.Title,
-			Body:           question.Body,
-			Explanation:    question.Explanation,
-			CreatedAt:      question.CreatedAt,
-			Views:          question.Views,
-			CorrectCount:   question.CorrectCount,
-			IncorrectCount: question.IncorrectCount,
-		}
+		responses[i] = u.toQuestionResponse(question, userID)
+	}
+	if err := u.attachDetails(ctx, responses); err != nil {
+		return nil, err
 	}
 
 	return responses, nil
 }
 
-// GetAllQuestions は全ての問題を取得する
+// GetPublishedQuestionsByUser は他のユーザーから見えるユーザーの問題（公開中のもの）を新しい順にページングして取得する
+func (u *QuestionUsecase) GetPublishedQuestionsByUser(ctx context.Context, userID string, req dto.ListQuestionsRequest) (*dto.QuestionListResponse, error) {
+	if !authEntities.IsValidUserID(userID) {
+		return nil, shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+
+	return u.getPublishedPage(ctx, repositories.QuestionPageQuery{UserID: userID}, req)
+}
+
+// GetAllQuestions は公開中の問題を全て取得する
 func (u *QuestionUsecase) GetAllQuestions(ctx context.Context) ([]*dto.QuestionResponse, error) {
 	questions, err := u.questionRepo.GetAll(ctx)
 	if err != nil {
@@ -167,23 +608,199 @@ func (u *QuestionUsecase) GetAllQuestions(ctx context.Context) ([]*dto.QuestionR
 	// レスポンスDTOに変換
 	responses := make([]*dto.QuestionResponse, len(questions))
 	for i, question := range questions {
-		responses[i] = &dto.QuestionResponse{
-			ID:             question.ID,
-			GenreID:        question.GenreID,
-			UserID:         question.UserID,
-			Title:          question.Title,
-			Body:           question.Body,
-			Explanation:    question.Explanation,
-			CreatedAt:      question.CreatedAt,
-			Views:          question.Views,
-			CorrectCount:   question.CorrectCount,
-			IncorrectCount: question.IncorrectCount,
-		}
+		responses[i] = u.toQuestionResponse(question, "")
+	}
+	if err := u.attachDetails(ctx, responses); err != nil {
+		return nil, err
 	}
 
 	return responses, nil
 }
 
+// GetQuestionsByTag はタグが付いた公開中の問題を新しい順にページングして取得する
+// タグ名は全角・半角、大文字・小文字の違いを無視して照合する
+func (u *QuestionUsecase) GetQuestionsByTag(ctx context.Context, tag string, req dto.ListQuestionsRequest) (*dto.QuestionListResponse, error) {
+	slug := shared.LabelKey(tag)
+	if slug == "" {
+		return nil, shared.NewValidationError("tag", "タグ名を指定してください")
+	}
+
+	return u.getPublishedPage(ctx, repositories.QuestionPageQuery{TagSlug: slug}, req)
+}
+
+// getPublishedPage は条件に合う公開中の問題を新しい順にページングして取得する
+func (u *QuestionUsecase) getPublishedPage(ctx context.Context, query repositories.QuestionPageQuery, req dto.ListQuestionsRequest) (*dto.QuestionListResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultQuestionListLimit
+	}
+	if limit > maxQuestionListLimit {
+		limit = maxQuestionListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 続きがあるか判定するため1件多く取得する
+	query.PublishedOnly = true
+	query.NewestFirst = true
+	query.Offset = offset
+	query.Limit = limit + 1
+	questions, err := u.questionRepo.GetPage(ctx, query, "")
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(questions) > limit
+	if hasMore {
+		questions = questions[:limit]
+	}
+
+	// レスポンスDTOに変換
+	responses := make([]*dto.QuestionResponse, len(questions))
+	for i, question := range questions {
+		responses[i] = u.toQuestionResponse(question, "")
+	}
+	if err := u.attachDetails(ctx, responses); err != nil {
+		return nil, err
+	}
+
+	return &dto.QuestionListResponse{
+		Questions: responses,
+		Limit:     limit,
+		Offset:    offset,
+		HasMore:   hasMore,
+	}, nil
+}
+
+// RenderHTML は問題文・解説を本文の形式に従ってサニタイズ済みのHTMLに変換し、レスポンスに設定する
+func (u *QuestionUsecase) RenderHTML(responses ...*dto.QuestionResponse) {
+	for _, response := range responses {
+		response.BodyHTML = services.RenderHTML(response.Body, response.BodyFormat)
+		response.ExplanationHTML = services.RenderHTML(response.Explanation, response.BodyFormat)
+	}
+}
+
+// attachDetails は問題本文に添付された画像と問題に付いたタグをレスポンスにまとめて設定する
+func (u *QuestionUsecase) attachDetails(ctx context.Context, responses []*dto.QuestionResponse) error {
+	if err := u.attachImages(ctx, responses); err != nil {
+		return err
+	}
+	return u.attachTags(ctx, responses)
+}
+
+// attachTags は問題に付いたタグをまとめて取得してレスポンスに設定する
+func (u *QuestionUsecase) attachTags(ctx context.Context, responses []*dto.QuestionResponse) error {
+	if u.tagRepo == nil || len(responses) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+	}
+
+	tags, err := u.tagRepo.GetByQuestionIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, response := range responses {
+		if questionTags, ok := tags[response.ID]; ok {
+			response.Tags = tagEntities.TagNames(questionTags)
+		}
+	}
+	return nil
+}
+
+// attachImages は問題本文に添付された画像をレスポンスにまとめて設定する
+// 選択肢に添付された画像は選択肢のレスポンスに含めるためここでは除く
+func (u *QuestionUsecase) attachImages(ctx context.Context, responses []*dto.QuestionResponse) error {
+	if u.attachmentRepo == nil || len(responses) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(responses))
+	byID := make(map[int64]*dto.QuestionResponse, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+		byID[response.ID] = response
+	}
+
+	attachments, err := u.attachmentRepo.GetByQuestionIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if response, ok := byID[attachment.QuestionID]; ok && !attachment.IsForChoice() {
+			response.Attachments = append(response.Attachments, attachmentDto.NewAttachmentResponse(attachment))
+		}
+	}
+	return nil
+}
+
+// toQuestionResponse はQuestionエンティティをレスポンスDTOに変換
+// 採点に使う正解（accepted_answers / numeric_answer / numeric_tolerance）は閲覧者が作成者の場合のみ含める
+func (u *QuestionUsecase) toQuestionResponse(question *entities.Question, viewerID string) *dto.QuestionResponse {
+	response := &dto.QuestionResponse{
+		ID:             question.ID,
+		GenreID:        question.GenreID,
+		UserID:         question.UserID,
+		Title:          question.Title,
+		Body:           question.Body,
+		Explanation:    question.Explanation,
+		BodyFormat:     question.BodyFormat,
+		CreatedAt:      question.CreatedAt,
+		Views:          question.Views + u.pendingViews(question.ID),
+		CorrectCount:   question.CorrectCount,
+		IncorrectCount: question.IncorrectCount,
+		IsHidden:       question.IsHidden,
+		Revision:       question.Revision,
+		Status:         question.Status,
+		PublishedAt:    question.PublishedAt,
+		PublishAt:      question.PublishAt,
+
+		Type:             question.Type,
+		PartialCredit:    question.PartialCredit,
+		ShuffleChoices:   question.ShuffleChoices,
+		Tags:             []string{},
+		Attachments:      []*attachmentDto.AttachmentResponse{},
+	}
+
+	if viewerID != "" && viewerID == question.UserID {
+		tolerance := question.NumericTolerance
+		response.AcceptedAnswers = question.AcceptedAnswers
+		response.NumericAnswer = question.NumericAnswer
+		response.NumericTolerance = &tolerance
+	}
+	return response
+}
+
+// toQuestionRevisionResponse はQuestionRevisionエンティティをレスポンスDTOに変換
+func (u *QuestionUsecase) toQuestionRevisionResponse(revision *entities.QuestionRevision) *dto.QuestionRevisionResponse {
+	return &dto.QuestionRevisionResponse{
+		ID:          revision.ID,
+		QuestionID:  revision.QuestionID,
+		Revision:    revision.Revision,
+		UserID:      revision.UserID,
+		Title:       revision.Title,
+		Body:        revision.Body,
+		Explanation: revision.Explanation,
+		Diff:        revision.Diff,
+		CreatedAt:   revision.CreatedAt,
+	}
+}
+
+// pendingViews はまだ書き込まれていない閲覧数を返す
+func (u *QuestionUsecase) pendingViews(questionID int64) int {
+	if u.viewCounter == nil {
+		return 0
+	}
+	return u.viewCounter.Pending(questionID)
+}
+
 // validateCreateQuestionRequest は問題作成リクエストをバリデーション
 func (u *QuestionUsecase) validateCreateQuestionRequest(req dto.CreateQuestionRequest) error {
 	if req.GenreID == 0 {
@@ -198,16 +815,34 @@ func (u *QuestionUsecase) validateCreateQuestionRequest(req dto.CreateQuestionRe
 		return shared.NewValidationError("title", "問題タイトルは200文字以内で入力してください")
 	}
 
+	if req.Type != "" && !entities.IsValidType(req.Type) {
+		return shared.NewValidationError("type", "問題の種類が不正です")
+	}
+
+	if req.BodyFormat != "" && !entities.IsValidBodyFormat(req.BodyFormat) {
+		return shared.NewValidationError("body_format", "本文の形式は plain または markdown を指定してください")
+	}
+
 	return nil
 }
 
 // validateUpdateQuestionRequest は問題更新リクエストをバリデーション
 func (u *QuestionUsecase) validateUpdateQuestionRequest(req dto.UpdateQuestionRequest) error {
 	// 全てのフィールドが空の場合はエラー
-	if strings.TrimSpace(req.Title) == "" && req.Body == "" && req.Explanation == "" {
+	if strings.TrimSpace(req.Title) == "" && req.Body == "" && req.Explanation == "" &&
+		req.Type == "" && req.PartialCredit == nil && req.AcceptedAnswers == nil &&
+		req.NumericAnswer == nil && req.NumericTolerance == nil && req.ShuffleChoices == nil && req.BodyFormat == "" && req.Tags == nil {
 		return shared.NewValidationError("fields", "更新する内容を入力してください")
 	}
 
+	if req.Type != "" && !entities.IsValidType(req.Type) {
+		return shared.NewValidationError("type", "問題の種類が不正です")
+	}
+
+	if req.BodyFormat != "" && !entities.IsValidBodyFormat(req.BodyFormat) {
+		return shared.NewValidationError("body_format", "本文の形式は plain または markdown を指定してください")
+	}
+
 	// タイトルが指定されている場合の文字数チェック
 	if strings.TrimSpace(req.Title) != "" && len(req.Title) > 200 {
 		return shared.NewValidationError("title", "問題タイトルは200文字以内で入力してください")
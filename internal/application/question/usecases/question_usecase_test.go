@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Shittaka_back/internal/application/question/dto"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/question/services"
+	"Shittaka_back/internal/domain/shared"
+	choiceMemory "Shittaka_back/internal/infrastructure/choice/memory"
+	questionMemory "Shittaka_back/internal/infrastructure/question/memory"
+	tagMemory "Shittaka_back/internal/infrastructure/tag/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestQuestionUsecase はメモリ上のリポジトリを使うユースケースを作成
+// duplicates を渡した場合は似た問題の警告も行う
+func newTestQuestionUsecase(duplicates *services.DuplicateChecker) (*QuestionUsecase, repositories.QuestionRepository) {
+	questionRepo := questionMemory.NewQuestionRepository(nil)
+	usecase := NewQuestionUsecase(questionRepo, questionMemory.NewQuestionRevisionRepository(), choiceMemory.NewChoiceRepository(), nil, nil, nil, nil, duplicates)
+	return usecase, questionRepo
+}
+
+// publish はテスト用に問題を公開状態にする
+func publish(t *testing.T, questionRepo repositories.QuestionRepository, id int64) {
+	ctx := context.Background()
+	question, err := questionRepo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.NoError(t, question.Publish(time.Now()))
+	require.NoError(t, questionRepo.UpdateStatus(ctx, question, ""))
+}
+
+func TestGetQuestion_AnswerKeyOnlyForAuthor(t *testing.T) {
+	ctx := context.Background()
+	usecase, questionRepo := newTestQuestionUsecase(nil)
+
+	answer := 1192.0
+	created, err := usecase.CreateQuestion(ctx, dto.CreateQuestionRequest{
+		GenreID:          1,
+		Title:            "鎌倉幕府の成立年は？",
+		Body:             "西暦で答えてください",
+		Type:             entities.TypeNumeric,
+		NumericAnswer:    &answer,
+		NumericTolerance: 7,
+	}, "author", "")
+	require.NoError(t, err)
+	require.NotNil(t, created.NumericAnswer)
+	publish(t, questionRepo, created.ID)
+
+	// 作成者には正解を返す
+	own, err := usecase.GetQuestion(ctx, created.ID, "author")
+	require.NoError(t, err)
+	require.NotNil(t, own.NumericAnswer)
+	assert.Equal(t, answer, *own.NumericAnswer)
+	require.NotNil(t, own.NumericTolerance)
+	assert.Equal(t, 7.0, *own.NumericTolerance)
+
+	// 作成者以外・未ログインには正解を返さない
+	for _, viewerID := range []string{"", "other"} {
+		response, err := usecase.GetQuestion(ctx, created.ID, viewerID)
+		require.NoError(t, err)
+		assert.Nil(t, response.NumericAnswer, viewerID)
+		assert.Nil(t, response.NumericTolerance, viewerID)
+		assert.Nil(t, response.AcceptedAnswers, viewerID)
+	}
+
+	list, err := usecase.GetAllQuestions(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Nil(t, list[0].NumericAnswer)
+
+	// 編集履歴の差分にも正解を含めない
+	revisions, err := usecase.GetRevisions(ctx, created.ID, "other")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.NotContains(t, revisions[0].Diff, "1192")
+	ownRevisions, err := usecase.GetRevisions(ctx, created.ID, "author")
+	require.NoError(t, err)
+	assert.Contains(t, ownRevisions[0].Diff, "1192")
+}
+
+func TestCreateQuestion_SimilarQuestionsOnlyFromVisibleQuestions(t *testing.T) {
+	ctx := context.Background()
+	usecase, questionRepo := newTestQuestionUsecase(services.NewDuplicateChecker(0.5, 1.1))
+
+	request := dto.CreateQuestionRequest{
+		GenreID: 1,
+		Title:   "日本で一番高い山はどこ？",
+		Body:    "標高が最も高い山の名前を答えてください",
+	}
+	create := func(userID string) int64 {
+		created, err := usecase.CreateQuestion(ctx, request, userID, "")
+		require.NoError(t, err)
+		return created.ID
+	}
+
+	// 他のユーザーの下書き・非公開になった問題・公開中の問題と、自分の下書き
+	otherDraft := create("other")
+	otherHidden := create("other")
+	publish(t, questionRepo, otherHidden)
+	require.NoError(t, questionRepo.SetHidden(ctx, otherHidden, true))
+	otherPublished := create("other")
+	publish(t, questionRepo, otherPublished)
+	ownDraft := create("author")
+
+	created, err := usecase.CreateQuestion(ctx, request, "author", "")
+	require.NoError(t, err)
+
+	var ids []int64
+	for _, s := range created.SimilarQuestions {
+		ids = append(ids, s.ID)
+	}
+	assert.ElementsMatch(t, []int64{otherPublished, ownDraft}, ids)
+	assert.NotContains(t, ids, otherDraft)
+	assert.NotContains(t, ids, otherHidden)
+}
+
+func TestRevertQuestion_RestoresAnswerKey(t *testing.T) {
+	ctx := context.Background()
+	usecase, questionRepo := newTestQuestionUsecase(nil)
+
+	created, err := usecase.CreateQuestion(ctx, dto.CreateQuestionRequest{
+		GenreID:         1,
+		Title:           "日本で一番高い山は？",
+		Type:            entities.TypeFreeText,
+		AcceptedAnswers: []string{"富士山"},
+	}, "author", "")
+	require.NoError(t, err)
+
+	// 下書きの間に種類と正解を変更する（リビジョン2）
+	height := 3776.0
+	require.NoError(t, usecase.UpdateQuestion(ctx, created.ID, dto.UpdateQuestionRequest{
+		Title:         "富士山の標高は？",
+		Type:          entities.TypeNumeric,
+		NumericAnswer: &height,
+	}, "author", ""))
+
+	reverted, err := usecase.RevertQuestion(ctx, created.ID, 1, "author", "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, reverted.Revision)
+	assert.Equal(t, entities.TypeFreeText, reverted.Type)
+	assert.Equal(t, []string{"富士山"}, reverted.AcceptedAnswers)
+	assert.Nil(t, reverted.NumericAnswer)
+
+	// 公開後は種類の異なるリビジョンには戻せない
+	publish(t, questionRepo, created.ID)
+	_, err = usecase.RevertQuestion(ctx, created.ID, 2, "author", "")
+	require.Error(t, err)
+	assert.Equal(t, "INVALID_TRANSITION", err.(shared.DomainError).Code)
+
+	question, err := questionRepo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TypeFreeText, question.Type)
+	assert.Equal(t, []string{"富士山"}, question.AcceptedAnswers)
+}
+
+func TestCreateQuestion_WritesInOneUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	questionRepo := questionMemory.NewQuestionRepository(nil)
+	revisionRepo := questionMemory.NewQuestionRevisionRepository()
+	tagRepo := tagMemory.NewTagRepository()
+	unitOfWork := &fakeUnitOfWork{repos: repositories.QuestionWriteRepositories{
+		Questions: questionRepo,
+		Revisions: revisionRepo,
+		Tags:      tagRepo,
+	}}
+	usecase := NewQuestionUsecase(questionRepo, revisionRepo, choiceMemory.NewChoiceRepository(), nil, tagRepo, unitOfWork, nil, nil)
+
+	created, err := usecase.CreateQuestion(ctx, dto.CreateQuestionRequest{
+		GenreID: 1,
+		Title:   "日本で一番高い山は？",
+		Tags:    []string{"地理"},
+	}, "author", "")
+	require.NoError(t, err)
+
+	// 問題・初版のリビジョン・タグを1回のトランザクションで保存する
+	assert.Equal(t, 1, unitOfWork.calls)
+	assert.False(t, unitOfWork.rolledBack)
+	assert.Equal(t, []string{"地理"}, created.Tags)
+	revisions, err := revisionRepo.GetByQuestionID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+
+	// 内容の変更も問題の更新とリビジョンを1回のトランザクションで保存する
+	require.NoError(t, usecase.UpdateQuestion(ctx, created.ID, dto.UpdateQuestionRequest{Title: "日本で二番目に高い山は？"}, "author", ""))
+	assert.Equal(t, 2, unitOfWork.calls)
+	revisions, err = revisionRepo.GetByQuestionID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 2)
+}
+
+func TestRecordView_OnlyPublicQuestionsByOthers(t *testing.T) {
+	ctx := context.Background()
+	questionRepo := questionMemory.NewQuestionRepository(nil)
+	viewCounter := NewViewCounter(questionRepo, time.Hour)
+	usecase := NewQuestionUsecase(questionRepo, questionMemory.NewQuestionRevisionRepository(), choiceMemory.NewChoiceRepository(), nil, nil, nil, viewCounter, nil)
+
+	created, err := usecase.CreateQuestion(ctx, dto.CreateQuestionRequest{GenreID: 1, Title: "タイトル", Body: "本文"}, "author", "")
+	require.NoError(t, err)
+
+	// 下書きは作成者しか見られないため数えない
+	draft, err := usecase.GetQuestion(ctx, created.ID, "author")
+	require.NoError(t, err)
+	usecase.RecordView(draft, "author", "author")
+	assert.Equal(t, 0, viewCounter.Pending(created.ID))
+
+	publish(t, questionRepo, created.ID)
+	published, err := usecase.GetQuestion(ctx, created.ID, "")
+	require.NoError(t, err)
+
+	// 作成者自身の閲覧は数えない
+	usecase.RecordView(published, "author", "author")
+	assert.Equal(t, 0, viewCounter.Pending(created.ID))
+
+	usecase.RecordView(published, "", "192.0.2.1")
+	usecase.RecordView(published, "other", "other")
+	assert.Equal(t, 2, viewCounter.Pending(created.ID))
+}
@@ -0,0 +1,127 @@
+package usecases
+
+// view_counter.goは問題の閲覧数をメモリ上で集計し、まとめて書き込む仕組みを定義
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/question/repositories"
+)
+
+// viewKey は重複判定に使う「誰がどの問題を見たか」の組
+type viewKey struct {
+	questionID int64
+	viewerKey  string
+}
+
+// ViewCounter は閲覧数をバッファリングするカウンター
+// 同じ閲覧者（ユーザーIDまたはIP）による window 内の再閲覧は数えない
+type ViewCounter struct {
+	questionRepo repositories.QuestionRepository
+	window       time.Duration
+	now          func() time.Time
+
+	mu      sync.Mutex
+	seen    map[viewKey]time.Time
+	pending map[int64]int
+}
+
+// NewViewCounter は新しいViewCounterを作成
+func NewViewCounter(questionRepo repositories.QuestionRepository, window time.Duration) *ViewCounter {
+	return &ViewCounter{
+		questionRepo: questionRepo,
+		window:       window,
+		now:          time.Now,
+		seen:         make(map[viewKey]time.Time),
+		pending:      make(map[int64]int),
+	}
+}
+
+// Record は閲覧を記録し、カウント対象になった場合は true を返す
+func (c *ViewCounter) Record(questionID int64, viewerKey string) bool {
+	if viewerKey == "" {
+		return false
+	}
+
+	key := viewKey{questionID: questionID, viewerKey: viewerKey}
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if last, ok := c.seen[key]; ok && now.Sub(last) < c.window {
+		return false
+	}
+
+	c.seen[key] = now
+	c.pending[questionID]++
+	return true
+}
+
+// Pending はまだ書き込まれていない閲覧数を返す
+func (c *ViewCounter) Pending(questionID int64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.pending[questionID]
+}
+
+// Flush は溜まった閲覧数をリポジトリへまとめて書き込む
+// 書き込みに失敗した分は次回のFlushで再送する
+func (c *ViewCounter) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	batch := c.pending
+	c.pending = make(map[int64]int)
+	c.pruneSeen()
+	c.mu.Unlock()
+
+	var firstErr error
+	for questionID, delta := range batch {
+		if err := c.questionRepo.IncrementViews(ctx, questionID, delta); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			c.mu.Lock()
+			c.pending[questionID] += delta
+			c.mu.Unlock()
+		}
+	}
+
+	return firstErr
+}
+
+// Run は interval ごとにFlushを実行し、ctx が終了したら最後にもう一度Flushする
+func (c *ViewCounter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Flush(ctx); err != nil {
+				log.Printf("View counter flush error: %v", err)
+			}
+		case <-ctx.Done():
+			// 終了時は新しいコンテキストで残りを書き込む
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := c.Flush(flushCtx); err != nil {
+				log.Printf("View counter final flush error: %v", err)
+			}
+			cancel()
+			return
+		}
+	}
+}
+
+// pruneSeen は重複判定の期間を過ぎた記録を削除する（mu を保持した状態で呼ぶ）
+func (c *ViewCounter) pruneSeen() {
+	now := c.now()
+	for key, last := range c.seen {
+		if now.Sub(last) >= c.window {
+			delete(c.seen, key)
+		}
+	}
+}
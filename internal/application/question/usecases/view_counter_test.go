@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Shittaka_back/internal/domain/question/repositories"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeViewRepository は IncrementViews だけを記録するテスト用リポジトリ
+type fakeViewRepository struct {
+	repositories.QuestionRepository
+	increments map[int64]int
+	err        error
+}
+
+func (r *fakeViewRepository) IncrementViews(ctx context.Context, id int64, delta int) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.increments[id] += delta
+	return nil
+}
+
+func TestViewCounter_DeduplicatesWithinWindow(t *testing.T) {
+	repo := &fakeViewRepository{increments: map[int64]int{}}
+	counter := NewViewCounter(repo, 10*time.Minute)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+
+	assert.True(t, counter.Record(1, "user-a"))
+	assert.False(t, counter.Record(1, "user-a"))
+	assert.True(t, counter.Record(1, "ip:192.0.2.1"))
+	assert.True(t, counter.Record(2, "user-a"))
+	assert.False(t, counter.Record(1, ""))
+	assert.Equal(t, 2, counter.Pending(1))
+
+	// 期間を過ぎれば同じ閲覧者でも再度カウントされる
+	now = now.Add(10 * time.Minute)
+	assert.True(t, counter.Record(1, "user-a"))
+
+	assert.NoError(t, counter.Flush(context.Background()))
+	assert.Equal(t, map[int64]int{1: 3, 2: 1}, repo.increments)
+	assert.Equal(t, 0, counter.Pending(1))
+}
+
+func TestViewCounter_FlushRetriesOnError(t *testing.T) {
+	repo := &fakeViewRepository{increments: map[int64]int{}, err: errors.New("unavailable")}
+	counter := NewViewCounter(repo, time.Minute)
+
+	counter.Record(1, "user-a")
+	counter.Record(1, "user-b")
+
+	assert.Error(t, counter.Flush(context.Background()))
+	assert.Equal(t, 2, counter.Pending(1))
+
+	repo.err = nil
+	assert.NoError(t, counter.Flush(context.Background()))
+	assert.Equal(t, 2, repo.increments[1])
+}
@@ -0,0 +1,36 @@
+package dto
+
+// report_dto.goは通報関連のデータ転送オブジェクトを定義
+
+import "time"
+
+// CreateReportRequest は通報作成リクエスト
+type CreateReportRequest struct {
+	Reason  string `json:"reason"`
+	Comment string `json:"comment"`
+}
+
+// ResolveReportRequest は通報対応リクエスト
+type ResolveReportRequest struct {
+	Status string `json:"status"`
+}
+
+// ListReportsRequest は通報一覧取得リクエスト
+type ListReportsRequest struct {
+	Status string `json:"status"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// ReportResponse は通報レスポンス
+type ReportResponse struct {
+	ID         int64      `json:"id"`
+	QuestionID int64      `json:"question_id"`
+	UserID     string     `json:"user_id"`
+	Reason     string     `json:"reason"`
+	Comment    string     `json:"comment"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	ResolvedBy string     `json:"resolved_by"`
+}
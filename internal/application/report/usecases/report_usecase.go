@@ -0,0 +1,233 @@
+package usecases
+
+// report_usecase.goは通報とモデレーション関連のユースケースを定義
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"Shittaka_back/internal/application/report/dto"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/report/entities"
+	"Shittaka_back/internal/domain/report/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+const (
+	// defaultReportLimit は1ページあたりの通報件数の既定値
+	defaultReportLimit = 50
+	// maxReportLimit は1ページあたりの通報件数の上限
+	maxReportLimit = 200
+)
+
+// ReportUsecase は通報ユースケース
+type ReportUsecase struct {
+	reportRepo    repositories.ReportRepository
+	questionRepo  questionRepositories.QuestionRepository
+	moderatorIDs  map[string]bool
+	hideThreshold int
+}
+
+// NewReportUsecase は新しいReportUsecaseを作成
+// hideThreshold 件以上の未対応通報が集まった問題は自動的に非公開になる
+func NewReportUsecase(reportRepo repositories.ReportRepository, questionRepo questionRepositories.QuestionRepository, moderatorIDs []string, hideThreshold int) *ReportUsecase {
+	moderators := make(map[string]bool, len(moderatorIDs))
+	for _, id := range moderatorIDs {
+		moderators[id] = true
+	}
+
+	return &ReportUsecase{
+		reportRepo:    reportRepo,
+		questionRepo:  questionRepo,
+		moderatorIDs:  moderators,
+		hideThreshold: hideThreshold,
+	}
+}
+
+// CreateReport は問題を通報する（認証が必要）
+func (u *ReportUsecase) CreateReport(ctx context.Context, questionID int64, req dto.CreateReportRequest, userID string, userToken string) (*dto.ReportResponse, error) {
+	// バリデーション
+	if err := u.validateCreateReportRequest(req); err != nil {
+		return nil, err
+	}
+
+	// 問題の存在チェック
+	question, err := u.questionRepo.GetByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じユーザーによる重複通報を防ぐ
+	exists, err := u.reportRepo.ExistsOpenByUserAndQuestion(ctx, userID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, shared.NewDomainError("REPORT_EXISTS", "この問題は既に通報済みです")
+	}
+
+	// 通報エンティティを作成
+	report := entities.NewReport(questionID, userID, req.Reason, req.Comment)
+
+	// エンティティレベルでのバリデーション
+	if err := report.Validate(); err != nil {
+		return nil, err
+	}
+
+	// リポジトリに保存（ユーザートークンを渡してRLS適用）
+	createdReport, err := u.reportRepo.Create(ctx, report, userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// しきい値を超えたら問題を自動的に非公開にする
+	if !question.IsHidden {
+		openCount, err := u.reportRepo.CountOpenByQuestionID(ctx, questionID)
+		if err != nil {
+			return nil, err
+		}
+		if u.hideThreshold > 0 && openCount >= u.hideThreshold {
+			if err := u.questionRepo.SetHidden(ctx, questionID, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return u.toReportResponse(createdReport), nil
+}
+
+// ListReports はモデレーター向けに通報一覧を取得する
+func (u *ReportUsecase) ListReports(ctx context.Context, req dto.ListReportsRequest, userID string) ([]*dto.ReportResponse, error) {
+	// モデレーターかどうかチェック
+	if !u.isModerator(userID) {
+		return nil, shared.NewDomainError("FORBIDDEN", "通報一覧を閲覧する権限がありません")
+	}
+
+	status := req.Status
+	if status == "" {
+		status = entities.StatusOpen
+	}
+	if !entities.IsValidStatus(status) {
+		return nil, shared.NewValidationError("status", "状態はopen・accepted・rejectedのいずれかを指定してください")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultReportLimit
+	}
+	if limit > maxReportLimit {
+		limit = maxReportLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	reports, err := u.reportRepo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// レスポンスDTOに変換
+	responses := make([]*dto.ReportResponse, len(reports))
+	for i, report := range reports {
+		responses[i] = u.toReportResponse(report)
+	}
+
+	return responses, nil
+}
+
+// ResolveReport は通報を受理または却下する（モデレーターのみ）
+func (u *ReportUsecase) ResolveReport(ctx context.Context, id int64, req dto.ResolveReportRequest, userID string) (*dto.ReportResponse, error) {
+	// モデレーターかどうかチェック
+	if !u.isModerator(userID) {
+		return nil, shared.NewDomainError("FORBIDDEN", "通報を処理する権限がありません")
+	}
+
+	// 既存の通報を取得
+	report, err := u.reportRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 状態遷移（open → accepted / rejected）
+	if err := report.Resolve(req.Status, userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.reportRepo.UpdateStatus(ctx, report); err != nil {
+		return nil, err
+	}
+
+	// 問題の公開状態を見直す
+	if err := u.refreshQuestionVisibility(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return u.toReportResponse(report), nil
+}
+
+// refreshQuestionVisibility は通報の対応結果に応じて問題の公開状態を更新する
+func (u *ReportUsecase) refreshQuestionVisibility(ctx context.Context, report *entities.Report) error {
+	// 受理された通報は問題を非公開にする
+	if report.HidesQuestion() {
+		return u.questionRepo.SetHidden(ctx, report.QuestionID, true)
+	}
+
+	// 却下された場合、残りの未対応通報がしきい値未満なら公開に戻す
+	if report.Status != entities.StatusRejected {
+		return nil
+	}
+
+	question, err := u.questionRepo.GetByID(ctx, report.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !question.IsHidden {
+		return nil
+	}
+
+	openCount, err := u.reportRepo.CountOpenByQuestionID(ctx, report.QuestionID)
+	if err != nil {
+		return err
+	}
+	if u.hideThreshold <= 0 || openCount < u.hideThreshold {
+		return u.questionRepo.SetHidden(ctx, report.QuestionID, false)
+	}
+
+	return nil
+}
+
+// isModerator はユーザーがモデレーターかどうかを判定
+func (u *ReportUsecase) isModerator(userID string) bool {
+	return userID != "" && u.moderatorIDs[userID]
+}
+
+// toReportResponse はReportエンティティをレスポンスDTOに変換
+func (u *ReportUsecase) toReportResponse(report *entities.Report) *dto.ReportResponse {
+	return &dto.ReportResponse{
+		ID:         report.ID,
+		QuestionID: report.QuestionID,
+		UserID:     report.UserID,
+		Reason:     report.Reason,
+		Comment:    report.Comment,
+		Status:     report.Status,
+		CreatedAt:  report.CreatedAt,
+		ResolvedAt: report.ResolvedAt,
+		ResolvedBy: report.ResolvedBy,
+	}
+}
+
+// validateCreateReportRequest は通報作成リクエストをバリデーション
+func (u *ReportUsecase) validateCreateReportRequest(req dto.CreateReportRequest) error {
+	if !entities.IsValidReason(req.Reason) {
+		return shared.NewValidationError("reason", "通報理由はwrong_answer・offensive・duplicate・typoのいずれかを指定してください")
+	}
+
+	if utf8.RuneCountInString(req.Comment) > entities.MaxReportCommentLength {
+		return shared.NewValidationError("comment", "コメントは500文字以内で入力してください")
+	}
+
+	return nil
+}
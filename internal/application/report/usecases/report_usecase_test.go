@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"Shittaka_back/internal/application/report/dto"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/report/entities"
+	"Shittaka_back/internal/domain/shared"
+	questionMemory "Shittaka_back/internal/infrastructure/question/memory"
+	reportMemory "Shittaka_back/internal/infrastructure/report/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestReportUsecase はしきい値2件で自動的に非公開にするユースケースと公開中の問題を作成
+func newTestReportUsecase(t *testing.T) (*ReportUsecase, questionRepositories.QuestionRepository, int64) {
+	questionRepo := questionMemory.NewQuestionRepository(nil)
+	question, err := questionRepo.Create(context.Background(), &questionEntities.Question{
+		Title:  "鎌倉幕府の成立年は？",
+		UserID: "author",
+		Status: questionEntities.StatusPublished,
+	}, "")
+	require.NoError(t, err)
+
+	usecase := NewReportUsecase(reportMemory.NewReportRepository(), questionRepo, []string{"moderator"}, 2)
+	return usecase, questionRepo, question.ID
+}
+
+func isHidden(t *testing.T, questionRepo questionRepositories.QuestionRepository, id int64) bool {
+	question, err := questionRepo.GetByID(context.Background(), id)
+	require.NoError(t, err)
+	return question.IsHidden
+}
+
+func TestCreateReport_HidesQuestionAtThreshold(t *testing.T) {
+	ctx := context.Background()
+	usecase, questionRepo, questionID := newTestReportUsecase(t)
+
+	_, err := usecase.CreateReport(ctx, questionID, dto.CreateReportRequest{Reason: entities.ReasonWrongAnswer}, "alice", "")
+	require.NoError(t, err)
+	assert.False(t, isHidden(t, questionRepo, questionID))
+
+	// 同じユーザーの重複通報は数えない
+	_, err = usecase.CreateReport(ctx, questionID, dto.CreateReportRequest{Reason: entities.ReasonOffensive}, "alice", "")
+	require.Error(t, err)
+	assert.Equal(t, "REPORT_EXISTS", err.(shared.DomainError).Code)
+	assert.False(t, isHidden(t, questionRepo, questionID))
+
+	_, err = usecase.CreateReport(ctx, questionID, dto.CreateReportRequest{Reason: entities.ReasonOffensive}, "bob", "")
+	require.NoError(t, err)
+	assert.True(t, isHidden(t, questionRepo, questionID))
+
+	// 非公開になった問題は作成者以外から見えない
+	question, err := questionRepo.GetByID(ctx, questionID)
+	require.NoError(t, err)
+	assert.False(t, question.IsVisibleTo(""))
+	assert.False(t, question.IsVisibleTo("alice"))
+	assert.True(t, question.IsVisibleTo("author"))
+}
+
+func TestResolveReport_StatusTransitions(t *testing.T) {
+	ctx := context.Background()
+	usecase, questionRepo, questionID := newTestReportUsecase(t)
+	first, err := usecase.CreateReport(ctx, questionID, dto.CreateReportRequest{Reason: entities.ReasonWrongAnswer}, "alice", "")
+	require.NoError(t, err)
+	second, err := usecase.CreateReport(ctx, questionID, dto.CreateReportRequest{Reason: entities.ReasonWrongAnswer}, "bob", "")
+	require.NoError(t, err)
+	require.True(t, isHidden(t, questionRepo, questionID))
+
+	// モデレーター以外は対応できない
+	_, err = usecase.ResolveReport(ctx, first.ID, dto.ResolveReportRequest{Status: entities.StatusRejected}, "alice")
+	require.Error(t, err)
+	assert.Equal(t, "FORBIDDEN", err.(shared.DomainError).Code)
+
+	// 却下して未対応の通報がしきい値を下回ると公開に戻る
+	resolved, err := usecase.ResolveReport(ctx, first.ID, dto.ResolveReportRequest{Status: entities.StatusRejected}, "moderator")
+	require.NoError(t, err)
+	assert.Equal(t, entities.StatusRejected, resolved.Status)
+	assert.Equal(t, "moderator", resolved.ResolvedBy)
+	assert.NotNil(t, resolved.ResolvedAt)
+	assert.False(t, isHidden(t, questionRepo, questionID))
+
+	// 対応済みの通報は変更できない
+	_, err = usecase.ResolveReport(ctx, first.ID, dto.ResolveReportRequest{Status: entities.StatusAccepted}, "moderator")
+	require.Error(t, err)
+	assert.Equal(t, "INVALID_TRANSITION", err.(shared.DomainError).Code)
+
+	// open には戻せない
+	_, err = usecase.ResolveReport(ctx, second.ID, dto.ResolveReportRequest{Status: entities.StatusOpen}, "moderator")
+	require.Error(t, err)
+	assert.Equal(t, "status", err.(shared.ValidationError).Field)
+
+	// 受理すると非公開になる
+	resolved, err = usecase.ResolveReport(ctx, second.ID, dto.ResolveReportRequest{Status: entities.StatusAccepted}, "moderator")
+	require.NoError(t, err)
+	assert.Equal(t, entities.StatusAccepted, resolved.Status)
+	assert.True(t, isHidden(t, questionRepo, questionID))
+
+	open, err := usecase.ListReports(ctx, dto.ListReportsRequest{}, "moderator")
+	require.NoError(t, err)
+	assert.Empty(t, open)
+}
+
+func TestResolveReport_AcceptedTypoKeepsQuestionPublic(t *testing.T) {
+	ctx := context.Background()
+	usecase, questionRepo, questionID := newTestReportUsecase(t)
+	report, err := usecase.CreateReport(ctx, questionID, dto.CreateReportRequest{Reason: entities.ReasonTypo}, "alice", "")
+	require.NoError(t, err)
+
+	_, err = usecase.ResolveReport(ctx, report.ID, dto.ResolveReportRequest{Status: entities.StatusAccepted}, "moderator")
+	require.NoError(t, err)
+	assert.False(t, isHidden(t, questionRepo, questionID))
+}
@@ -0,0 +1,10 @@
+package dto
+
+// tag_dto.goはタグ関連のデータ転送オブジェクトを定義
+
+// TagResponse はタグレスポンス
+type TagResponse struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	QuestionCount int    `json:"question_count"`
+}
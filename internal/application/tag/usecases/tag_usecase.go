@@ -0,0 +1,67 @@
+package usecases
+
+// tag_usecase.goはタグの候補表示・集計のユースケースを定義
+
+import (
+	"context"
+	"sort"
+
+	"Shittaka_back/internal/application/tag/dto"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/domain/tag/repositories"
+)
+
+const (
+	// DefaultTagLimit はタグ一覧で返す既定の件数
+	DefaultTagLimit = 10
+	// MaxTagLimit はタグ一覧で返す最大の件数
+	MaxTagLimit = 100
+)
+
+// TagUsecase はタグユースケース
+type TagUsecase struct {
+	tagRepo repositories.TagRepository
+}
+
+// NewTagUsecase は新しいTagUsecaseを作成
+func NewTagUsecase(tagRepo repositories.TagRepository) *TagUsecase {
+	return &TagUsecase{
+		tagRepo: tagRepo,
+	}
+}
+
+// SearchTags は入力途中の文字列に前方一致するタグを、問題数の多い順に最大 limit 件返す
+// query が空の場合は全てのタグから問題数の多い順に返す
+func (u *TagUsecase) SearchTags(ctx context.Context, query string, limit int) ([]*dto.TagResponse, error) {
+	if limit <= 0 {
+		limit = DefaultTagLimit
+	}
+	if limit > MaxTagLimit {
+		return nil, shared.NewValidationError("limit", "limitは100以下で指定してください")
+	}
+
+	tags, err := u.tagRepo.SearchBySlugPrefix(ctx, shared.LabelKey(query))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].QuestionCount != tags[j].QuestionCount {
+			return tags[i].QuestionCount > tags[j].QuestionCount
+		}
+		return tags[i].Slug < tags[j].Slug
+	})
+	if len(tags) > limit {
+		tags = tags[:limit]
+	}
+
+	responses := make([]*dto.TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = &dto.TagResponse{
+			ID:            tag.ID,
+			Name:          tag.Name,
+			QuestionCount: tag.QuestionCount,
+		}
+	}
+	return responses, nil
+}
@@ -6,25 +6,34 @@ import (
 )
 
 // Answer は回答履歴のドメインエンティティ
+// 問題の種類に応じて ChoiceID / ChoiceIDs / TextAnswer / NumericAnswer のいずれかを使う
 type Answer struct {
-	ID         int64     `json:"id"`
-	UserID     string    `json:"user_id"`
-	QuestionID int64     `json:"question_id"`
-	ChoiceID   int64     `json:"choice_id"`
-	AnsweredAt time.Time `json:"answered_at"`
+	ID               int64     `json:"id"`
+	UserID           string    `json:"user_id"`
+	QuestionID       int64     `json:"question_id"`
+	ChoiceID         int64     `json:"choice_id"`
+	ChoiceIDs        []int64   `json:"choice_ids"`
+	TextAnswer       string    `json:"text_answer"`
+	NumericAnswer    *float64  `json:"numeric_answer"`
+	IsCorrect        bool      `json:"is_correct"`
+	Score            float64   `json:"score"`
+	AnsweredAt       time.Time `json:"answered_at"`
+	QuestionRevision int       `json:"question_revision"`
 }
 
 // NewAnswer は新しいAnswerエンティティを作成
-func NewAnswer(userID string, questionID, choiceID int64) *Answer {
+func NewAnswer(userID string, questionID, choiceID int64, questionRevision int) *Answer {
 	return &Answer{
-		UserID:     userID,
-		QuestionID: questionID,
-		ChoiceID:   choiceID,
-		AnsweredAt: time.Now(),
+		UserID:           userID,
+		QuestionID:       questionID,
+		ChoiceID:         choiceID,
+		AnsweredAt:       time.Now(),
+		QuestionRevision: questionRevision,
 	}
 }
 
 // Validate はAnswerエンティティのバリデーションを行う
+// 問題の種類ごとの回答内容の検証は採点時に行う
 func (a *Answer) Validate() error {
 	if a.UserID == "" {
 		return shared.NewValidationError("user_id", "user_id is required")
@@ -32,8 +41,14 @@ func (a *Answer) Validate() error {
 	if a.QuestionID == 0 {
 		return shared.NewValidationError("question_id", "question_id is required")
 	}
-	if a.ChoiceID == 0 {
-		return shared.NewValidationError("choice_id", "choice_id is required")
+	if a.ChoiceID == 0 && len(a.ChoiceIDs) == 0 && a.TextAnswer == "" && a.NumericAnswer == nil {
+		return shared.NewValidationError("answer", "answer is required")
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// SetGrade は採点結果を設定する
+func (a *Answer) SetGrade(isCorrect bool, score float64) {
+	a.IsCorrect = isCorrect
+	a.Score = score
+}
@@ -1,8 +1,8 @@
 package repositories
 
 import (
-	"context"
 	"Shittaka_back/internal/domain/answer/entities"
+	"context"
 )
 
 // AnswerRepository は回答履歴リポジトリのインターフェース
@@ -10,4 +10,5 @@ type AnswerRepository interface {
 	Create(ctx context.Context, answer *entities.Answer, userToken string) (*entities.Answer, error)
 	GetByUserID(ctx context.Context, userID string) ([]*entities.Answer, error)
 	GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.Answer, error)
-}
\ No newline at end of file
+	ExistsByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error)
+}
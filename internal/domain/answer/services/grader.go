@@ -0,0 +1,132 @@
+package services
+
+// grader.goは問題の種類ごとに回答を採点するドメインサービスを定義
+
+import (
+	"math"
+
+	"Shittaka_back/internal/domain/answer/entities"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// numericEpsilon は浮動小数点の誤差を吸収するための許容値
+const numericEpsilon = 1e-9
+
+// GradeResult は採点結果
+// Score は 0〜1 で、部分点のない問題では正解なら 1、不正解なら 0
+type GradeResult struct {
+	IsCorrect bool
+	Score     float64
+}
+
+// Grade は問題の種類に応じて回答を採点する
+// choices は選択肢で回答する種類の問題のときだけ参照する
+func Grade(question *questionEntities.Question, choices []choiceEntities.Choice, answer *entities.Answer) (GradeResult, error) {
+	switch question.Type {
+	case questionEntities.TypeMultipleSelect:
+		return gradeMultipleSelect(question, choices, answer)
+	case questionEntities.TypeFreeText:
+		return gradeFreeText(question, answer)
+	case questionEntities.TypeNumeric:
+		return gradeNumeric(question, answer)
+	default:
+		// 単一選択・○×問題（種類が未設定の既存の問題も単一選択として扱う）
+		return gradeSingleChoice(choices, answer)
+	}
+}
+
+// gradeSingleChoice は選んだ選択肢が正解かどうかで採点する
+func gradeSingleChoice(choices []choiceEntities.Choice, answer *entities.Answer) (GradeResult, error) {
+	if answer.ChoiceID == 0 {
+		return GradeResult{}, shared.NewValidationError("choice_id", "choice_id is required")
+	}
+
+	for _, choice := range choices {
+		if choice.ID == answer.ChoiceID {
+			return newResult(choice.IsCorrect), nil
+		}
+	}
+	return GradeResult{}, shared.NewValidationError("choice_id", "この問題の選択肢ではありません")
+}
+
+// gradeMultipleSelect は正解の選択肢を過不足なく選んだ場合に正解とする
+// 部分点ありの問題では (選んだ正解数 - 選んだ不正解数) / 正解数 を点数とする（0未満は0）
+func gradeMultipleSelect(question *questionEntities.Question, choices []choiceEntities.Choice, answer *entities.Answer) (GradeResult, error) {
+	if len(answer.ChoiceIDs) == 0 {
+		return GradeResult{}, shared.NewValidationError("choice_ids", "choice_ids is required")
+	}
+
+	correct := make(map[int64]bool, len(choices))
+	known := make(map[int64]bool, len(choices))
+	for _, choice := range choices {
+		known[choice.ID] = true
+		if choice.IsCorrect {
+			correct[choice.ID] = true
+		}
+	}
+
+	selected := make(map[int64]bool, len(answer.ChoiceIDs))
+	hits, misses := 0, 0
+	for _, id := range answer.ChoiceIDs {
+		if !known[id] {
+			return GradeResult{}, shared.NewValidationError("choice_ids", "この問題の選択肢ではありません")
+		}
+		if selected[id] {
+			continue
+		}
+		selected[id] = true
+		if correct[id] {
+			hits++
+		} else {
+			misses++
+		}
+	}
+
+	if hits == len(correct) && misses == 0 {
+		return newResult(true), nil
+	}
+	if !question.PartialCredit || len(correct) == 0 {
+		return newResult(false), nil
+	}
+
+	score := float64(hits-misses) / float64(len(correct))
+	return GradeResult{IsCorrect: false, Score: math.Max(0, score)}, nil
+}
+
+// gradeFreeText は正規化した回答が正解の表記のいずれかと一致すれば正解とする
+func gradeFreeText(question *questionEntities.Question, answer *entities.Answer) (GradeResult, error) {
+	text := shared.NormalizeAnswerText(answer.TextAnswer)
+	if text == "" {
+		return GradeResult{}, shared.NewValidationError("text_answer", "text_answer is required")
+	}
+
+	for _, accepted := range question.AcceptedAnswers {
+		if text == shared.NormalizeAnswerText(accepted) {
+			return newResult(true), nil
+		}
+	}
+	return newResult(false), nil
+}
+
+// gradeNumeric は正解との差が許容誤差以内なら正解とする
+func gradeNumeric(question *questionEntities.Question, answer *entities.Answer) (GradeResult, error) {
+	if answer.NumericAnswer == nil {
+		return GradeResult{}, shared.NewValidationError("numeric_answer", "numeric_answer is required")
+	}
+	if question.NumericAnswer == nil {
+		return newResult(false), nil
+	}
+
+	diff := math.Abs(*answer.NumericAnswer - *question.NumericAnswer)
+	return newResult(diff <= question.NumericTolerance+numericEpsilon), nil
+}
+
+// newResult は部分点のない採点結果を作成する
+func newResult(isCorrect bool) GradeResult {
+	if isCorrect {
+		return GradeResult{IsCorrect: true, Score: 1}
+	}
+	return GradeResult{IsCorrect: false, Score: 0}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"testing"
+
+	"Shittaka_back/internal/domain/answer/entities"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestGrade_SingleChoice(t *testing.T) {
+	question := &questionEntities.Question{Type: questionEntities.TypeSingleChoice}
+	choices := []choiceEntities.Choice{{ID: 1, IsCorrect: true}, {ID: 2}}
+
+	result, err := Grade(question, choices, &entities.Answer{ChoiceID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, GradeResult{IsCorrect: true, Score: 1}, result)
+
+	result, err = Grade(question, choices, &entities.Answer{ChoiceID: 2})
+	assert.NoError(t, err)
+	assert.False(t, result.IsCorrect)
+
+	_, err = Grade(question, choices, &entities.Answer{ChoiceID: 99})
+	assert.Error(t, err)
+}
+
+func TestGrade_MultipleSelect(t *testing.T) {
+	question := &questionEntities.Question{Type: questionEntities.TypeMultipleSelect}
+	choices := []choiceEntities.Choice{{ID: 1, IsCorrect: true}, {ID: 2, IsCorrect: true}, {ID: 3}, {ID: 4}}
+
+	result, err := Grade(question, choices, &entities.Answer{ChoiceIDs: []int64{2, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, GradeResult{IsCorrect: true, Score: 1}, result)
+
+	// 部分点なしでは一部正解は0点
+	result, err = Grade(question, choices, &entities.Answer{ChoiceIDs: []int64{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, GradeResult{IsCorrect: false, Score: 0}, result)
+
+	question.PartialCredit = true
+	result, err = Grade(question, choices, &entities.Answer{ChoiceIDs: []int64{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, GradeResult{IsCorrect: false, Score: 0.5}, result)
+
+	// 不正解を選ぶと減点され、0未満にはならない
+	result, err = Grade(question, choices, &entities.Answer{ChoiceIDs: []int64{1, 3, 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, GradeResult{IsCorrect: false, Score: 0}, result)
+}
+
+func TestGrade_FreeText(t *testing.T) {
+	question := &questionEntities.Question{
+		Type:            questionEntities.TypeFreeText,
+		AcceptedAnswers: []string{"ふじさん", "Mt. Fuji"},
+	}
+
+	for _, text := range []string{"フジサン", "ﾌｼﾞｻﾝ", " ふじさん ", "ＭＴ． ＦＵＪＩ"} {
+		result, err := Grade(question, nil, &entities.Answer{TextAnswer: text})
+		assert.NoError(t, err)
+		assert.True(t, result.IsCorrect, text)
+	}
+
+	result, err := Grade(question, nil, &entities.Answer{TextAnswer: "きたさん"})
+	assert.NoError(t, err)
+	assert.False(t, result.IsCorrect)
+}
+
+func TestGrade_Numeric(t *testing.T) {
+	question := &questionEntities.Question{
+		Type:             questionEntities.TypeNumeric,
+		NumericAnswer:    floatPtr(3.14),
+		NumericTolerance: 0.01,
+	}
+
+	result, err := Grade(question, nil, &entities.Answer{NumericAnswer: floatPtr(3.15)})
+	assert.NoError(t, err)
+	assert.True(t, result.IsCorrect)
+
+	result, err = Grade(question, nil, &entities.Answer{NumericAnswer: floatPtr(3.2)})
+	assert.NoError(t, err)
+	assert.False(t, result.IsCorrect)
+
+	_, err = Grade(question, nil, &entities.Answer{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package entities
+
+// attachment.goは問題・選択肢に添付する画像のドメインエンティティを定義
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// Attachment は問題または選択肢に添付された画像のドメインエンティティ
+// ChoiceID が nil の場合は問題本文への添付、指定がある場合はその選択肢への添付
+type Attachment struct {
+	ID          int64     `json:"id"`
+	QuestionID  int64     `json:"question_id"`
+	ChoiceID    *int64    `json:"choice_id"`
+	UserID      string    `json:"user_id"`
+	StorageKey  string    `json:"storage_key"` // ストレージ上のファイルのキー
+	URL         string    `json:"url"`         // 公開URL
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewAttachment は新しいAttachmentエンティティを作成
+func NewAttachment(questionID int64, choiceID *int64, userID, contentType string, size int64, width, height int) *Attachment {
+	return &Attachment{
+		QuestionID:  questionID,
+		ChoiceID:    choiceID,
+		UserID:      userID,
+		ContentType: contentType,
+		Size:        size,
+		Width:       width,
+		Height:      height,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Validate はAttachmentエンティティのバリデーションを行う
+func (a *Attachment) Validate() error {
+	if a.QuestionID == 0 {
+		return shared.NewValidationError("question_id", "question_id is required")
+	}
+	if a.UserID == "" {
+		return shared.NewValidationError("user_id", "user_id is required")
+	}
+	if a.StorageKey == "" {
+		return shared.NewValidationError("storage_key", "storage_key is required")
+	}
+	return nil
+}
+
+// IsForChoice は選択肢への添付かどうかを返す
+func (a *Attachment) IsForChoice() bool {
+	return a.ChoiceID != nil
+}
+
+// AssignStorageKey は推測されにくいストレージ上のキーを割り当てる
+// 例: questions/12/3f9a...c1.png
+func (a *Attachment) AssignStorageKey() error {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	a.StorageKey = fmt.Sprintf("questions/%d/%s%s", a.QuestionID, hex.EncodeToString(buf), extensionFor(a.ContentType))
+	return nil
+}
+
+// extensionFor はContent-Typeに対応するファイル拡張子を返す
+func extensionFor(contentType string) string {
+	switch contentType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package repositories
+
+// attachment_repository.goは添付画像リポジトリのインターフェースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/attachment/entities"
+)
+
+// AttachmentRepository は添付画像のメタデータを保存するリポジトリのインターフェース
+type AttachmentRepository interface {
+	// Create は新しい添付画像を登録する（認証が必要）
+	Create(ctx context.Context, attachment *entities.Attachment, userToken string) (*entities.Attachment, error)
+
+	// GetByID はIDで添付画像を取得する
+	GetByID(ctx context.Context, id int64) (*entities.Attachment, error)
+
+	// GetByQuestionIDs は問題（とその選択肢）に添付された画像をまとめて取得する
+	GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]*entities.Attachment, error)
+
+	// Delete は添付画像を削除する（認証が必要）
+	Delete(ctx context.Context, id int64, userToken string) error
+}
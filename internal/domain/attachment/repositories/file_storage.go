@@ -0,0 +1,15 @@
+package repositories
+
+// file_storage.goは添付画像のファイル本体を保存するストレージのインターフェースを定義
+
+import "context"
+
+// FileStorage は添付画像のファイル本体を保存するストレージのインターフェース
+// ローカルファイルシステムとSupabase Storageの実装を設定で切り替える
+type FileStorage interface {
+	// Put はファイルを key に保存し、公開URLを返す
+	Put(ctx context.Context, key, contentType string, data []byte) (string, error)
+
+	// Delete は key のファイルを削除する（存在しない場合はエラーにしない）
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,73 @@
+package services
+
+// image_validator.goはアップロードされた画像の検証を定義
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"  // GIFのデコーダーを登録
+	_ "image/jpeg" // JPEGのデコーダーを登録
+	_ "image/png"  // PNGのデコーダーを登録
+	"net/http"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// SupportedImageTypes は添付できる画像の種類
+var SupportedImageTypes = []string{"image/png", "image/jpeg", "image/gif"}
+
+// ImageLimits は添付画像の制限
+type ImageLimits struct {
+	MaxBytes  int64 // ファイルサイズの上限
+	MaxWidth  int   // 幅の上限（ピクセル）
+	MaxHeight int   // 高さの上限（ピクセル）
+}
+
+// ImageInfo は検証済みの画像の情報
+type ImageInfo struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// ValidateImage は画像のサイズ・種類・縦横のピクセル数を検証する
+// 種類はクライアントが申告した Content-Type ではなくファイルの中身から判定する
+func ValidateImage(data []byte, limits ImageLimits) (*ImageInfo, error) {
+	if len(data) == 0 {
+		return nil, shared.NewValidationError("file", "ファイルが空です")
+	}
+	if limits.MaxBytes > 0 && int64(len(data)) > limits.MaxBytes {
+		return nil, shared.NewDomainError("FILE_TOO_LARGE", fmt.Sprintf("ファイルサイズは%dバイト以下にしてください", limits.MaxBytes))
+	}
+
+	contentType := http.DetectContentType(data)
+	if !isSupportedImageType(contentType) {
+		return nil, shared.NewDomainError("UNSUPPORTED_MEDIA_TYPE", "PNG・JPEG・GIF形式の画像のみ添付できます")
+	}
+
+	// ヘッダーだけ偽装したファイルを弾くため、判定した種類でデコードできることも確認する
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || "image/"+format != contentType || config.Width <= 0 || config.Height <= 0 {
+		return nil, shared.NewValidationError("file", "画像を読み込めませんでした")
+	}
+	if (limits.MaxWidth > 0 && config.Width > limits.MaxWidth) || (limits.MaxHeight > 0 && config.Height > limits.MaxHeight) {
+		return nil, shared.NewValidationError("file", fmt.Sprintf("画像は%dx%dピクセル以下にしてください", limits.MaxWidth, limits.MaxHeight))
+	}
+
+	return &ImageInfo{
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+	}, nil
+}
+
+// isSupportedImageType は添付できる画像の種類かどうかを返す
+func isSupportedImageType(contentType string) bool {
+	for _, t := range SupportedImageTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"Shittaka_back/internal/domain/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestValidateImage(t *testing.T) {
+	limits := ImageLimits{MaxBytes: 1 << 20, MaxWidth: 100, MaxHeight: 100}
+
+	info, err := ValidateImage(encodePNG(t, 40, 30), limits)
+	require.NoError(t, err)
+	assert.Equal(t, &ImageInfo{ContentType: "image/png", Width: 40, Height: 30}, info)
+
+	// 縦横の上限を超える
+	_, err = ValidateImage(encodePNG(t, 101, 10), limits)
+	assert.IsType(t, shared.ValidationError{}, err)
+
+	// サイズの上限を超える
+	_, err = ValidateImage(encodePNG(t, 10, 10), ImageLimits{MaxBytes: 10})
+	assert.Equal(t, "FILE_TOO_LARGE", err.(shared.DomainError).Code)
+
+	// 画像以外
+	_, err = ValidateImage([]byte("<html><body>not an image</body></html>"), limits)
+	assert.Equal(t, "UNSUPPORTED_MEDIA_TYPE", err.(shared.DomainError).Code)
+
+	// PNGのシグネチャだけを持つ壊れたファイル
+	_, err = ValidateImage([]byte("\x89PNG\r\n\x1a\nbroken"), limits)
+	assert.IsType(t, shared.ValidationError{}, err)
+
+	_, err = ValidateImage(nil, limits)
+	assert.Error(t, err)
+}
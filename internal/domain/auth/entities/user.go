@@ -12,6 +12,7 @@ type User struct {
 	ID        string
 	Email     string
 	Username  string
+	AvatarURL string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -38,3 +39,23 @@ func (u *User) Validate() error {
 	}
 	return nil
 }
+
+// IsValidUserID はユーザーIDがUUIDの形式かどうかを判定する
+func IsValidUserID(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for i, r := range id {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+package repositories
+
+// token_verifier.goはアクセストークンの検証のインターフェースを定義
+
+import "time"
+
+// TokenVerifier はアクセストークンの署名と有効期限を検証する
+type TokenVerifier interface {
+	// Verify はトークンを検証し、トークンの持ち主のユーザーIDを返す（検証に失敗した場合は UNAUTHORIZED）
+	Verify(token string, now time.Time) (string, error)
+}
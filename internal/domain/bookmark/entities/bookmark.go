@@ -0,0 +1,22 @@
+package entities
+
+// bookmark.goは問題のブックマークのドメインエンティティを定義
+
+import "time"
+
+// Bookmark はユーザーが問題をブックマークしている関係
+// ブックマークは本人だけが閲覧・エクスポートできる
+type Bookmark struct {
+	UserID     string    `json:"user_id"`
+	QuestionID int64     `json:"question_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewBookmark は新しいBookmarkエンティティを作成
+func NewBookmark(userID string, questionID int64) *Bookmark {
+	return &Bookmark{
+		UserID:     userID,
+		QuestionID: questionID,
+		CreatedAt:  time.Now(),
+	}
+}
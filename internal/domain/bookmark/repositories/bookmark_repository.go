@@ -0,0 +1,24 @@
+package repositories
+
+// bookmark_repository.goはブックマークリポジトリのインターフェースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/bookmark/entities"
+)
+
+// BookmarkRepository はブックマークリポジトリのインターフェース
+type BookmarkRepository interface {
+	// Add は問題をブックマークする（既にブックマークしている場合は何もしない、認証が必要）
+	Add(ctx context.Context, bookmark *entities.Bookmark, userToken string) error
+
+	// Remove はブックマークを外す（認証が必要）
+	Remove(ctx context.Context, userID string, questionID int64, userToken string) error
+
+	// GetByUserID はユーザーのブックマークを新しい順に取得する（認証が必要）
+	GetByUserID(ctx context.Context, userID string, limit, offset int, userToken string) ([]*entities.Bookmark, error)
+
+	// GetQuestionIDs はユーザーがブックマークしている全ての問題IDをID順に取得する（認証が必要）
+	GetQuestionIDs(ctx context.Context, userID string, userToken string) ([]int64, error)
+}
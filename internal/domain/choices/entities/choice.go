@@ -5,4 +5,5 @@ type Choice struct {
 	QuestionID int64  `json:"question_id"` // 紐づく問題のID (FK -> questions.id)
 	Text       string `json:"text"`        // 選択肢の本文
 	IsCorrect  bool   `json:"is_correct"`  // 正解かどうか
+	Position   int    `json:"position"`    // 表示順（1始まり）
 }
@@ -0,0 +1,72 @@
+package entities
+
+// choice_order.goは選択肢の表示順の並べ替えとシャッフルを定義
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// SortByPosition は選択肢を表示順に並べ替える（表示順が同じ場合はID順）
+func SortByPosition(choices []Choice) {
+	sort.SliceStable(choices, func(i, j int) bool {
+		if choices[i].Position != choices[j].Position {
+			return choices[i].Position < choices[j].Position
+		}
+		return choices[i].ID < choices[j].ID
+	})
+}
+
+// ShuffleForViewer は閲覧者と問題ごとに固定されたシードで選択肢をシャッフルする
+// 同じ閲覧者が同じ問題を何度開いても同じ並びになる
+func ShuffleForViewer(choices []Choice, viewerKey string, questionID int64) {
+	SortByPosition(choices)
+
+	h := fnv.New64a()
+	h.Write([]byte(fmt.Sprintf("%s:%d", viewerKey, questionID)))
+	r := rand.New(rand.NewSource(int64(h.Sum64())))
+	r.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+}
+
+// BuildPositions は並び順に指定された選択肢IDから選択肢ごとの表示順を作る
+// orderedIDs は問題の全ての選択肢を重複なく含んでいる必要がある
+func BuildPositions(choices []Choice, orderedIDs []int64) (map[int64]int, error) {
+	if len(orderedIDs) != len(choices) {
+		return nil, shared.NewValidationError("choice_ids", "全ての選択肢を指定してください")
+	}
+
+	known := make(map[int64]bool, len(choices))
+	for _, choice := range choices {
+		known[choice.ID] = true
+	}
+
+	positions := make(map[int64]int, len(orderedIDs))
+	for i, id := range orderedIDs {
+		if !known[id] {
+			return nil, shared.NewValidationError("choice_ids", "この問題の選択肢ではありません")
+		}
+		if _, dup := positions[id]; dup {
+			return nil, shared.NewValidationError("choice_ids", "選択肢が重複しています")
+		}
+		positions[id] = i + 1
+	}
+
+	return positions, nil
+}
+
+// NextPosition は新しく追加する選択肢の表示順（末尾）を返す
+func NextPosition(choices []Choice) int {
+	max := 0
+	for _, choice := range choices {
+		if choice.Position > max {
+			max = choice.Position
+		}
+	}
+	return max + 1
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func choiceIDs(choices []Choice) []int64 {
+	ids := make([]int64, len(choices))
+	for i, choice := range choices {
+		ids[i] = choice.ID
+	}
+	return ids
+}
+
+func TestSortByPosition(t *testing.T) {
+	choices := []Choice{{ID: 1, Position: 3}, {ID: 2, Position: 1}, {ID: 3, Position: 2}}
+	SortByPosition(choices)
+	assert.Equal(t, []int64{2, 3, 1}, choiceIDs(choices))
+}
+
+func TestShuffleForViewer_StablePerViewer(t *testing.T) {
+	base := []Choice{{ID: 1, Position: 1}, {ID: 2, Position: 2}, {ID: 3, Position: 3}, {ID: 4, Position: 4}, {ID: 5, Position: 5}}
+
+	first := append([]Choice(nil), base...)
+	ShuffleForViewer(first, "user:a", 10)
+
+	// 取得順が違っても同じ閲覧者・同じ問題なら同じ並びになる
+	second := []Choice{base[4], base[2], base[0], base[3], base[1]}
+	ShuffleForViewer(second, "user:a", 10)
+	assert.Equal(t, choiceIDs(first), choiceIDs(second))
+
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4, 5}, choiceIDs(first))
+}
+
+func TestBuildPositions(t *testing.T) {
+	choices := []Choice{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	positions, err := BuildPositions(choices, []int64{3, 1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]int{3: 1, 1: 2, 2: 3}, positions)
+
+	_, err = BuildPositions(choices, []int64{1, 2})
+	assert.Error(t, err)
+	_, err = BuildPositions(choices, []int64{1, 2, 2})
+	assert.Error(t, err)
+	_, err = BuildPositions(choices, []int64{1, 2, 4})
+	assert.Error(t, err)
+
+	assert.Equal(t, 1, NextPosition(nil))
+	assert.Equal(t, 4, NextPosition([]Choice{{Position: 3}, {Position: 1}}))
+}
@@ -0,0 +1,23 @@
+package entities
+
+// choice_set.goは問題に紐づく選択肢一式の検証を定義
+
+import "Shittaka_back/internal/domain/shared"
+
+// MinChoicesToPublish は公開に必要な選択肢の最小数
+const MinChoicesToPublish = 2
+
+// ValidateChoiceSet は問題を公開できる選択肢一式かどうかを検証する
+// 選択肢が2つ以上あり、少なくとも1つが正解であること
+func ValidateChoiceSet(choices []Choice) error {
+	if len(choices) < MinChoicesToPublish {
+		return shared.NewValidationError("choices", "公開するには選択肢が2つ以上必要です")
+	}
+
+	for _, choice := range choices {
+		if choice.IsCorrect {
+			return nil
+		}
+	}
+	return shared.NewValidationError("choices", "公開するには正解の選択肢が必要です")
+}
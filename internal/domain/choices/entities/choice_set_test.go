@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChoiceSet(t *testing.T) {
+	correct := Choice{Text: "正解", IsCorrect: true}
+	wrong := Choice{Text: "不正解", IsCorrect: false}
+
+	assert.Error(t, ValidateChoiceSet(nil))
+	assert.Error(t, ValidateChoiceSet([]Choice{correct}))
+	assert.Error(t, ValidateChoiceSet([]Choice{wrong, wrong}))
+	assert.NoError(t, ValidateChoiceSet([]Choice{wrong, correct}))
+	assert.NoError(t, ValidateChoiceSet([]Choice{correct, correct, wrong}))
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	entities "Shittaka_back/internal/domain/choices/entities"
+	"Shittaka_back/internal/domain/shared"
 
 	"github.com/nedpals/supabase-go"
 )
@@ -12,11 +13,15 @@ import (
 // ChoiceRepository は選択肢のリポジトリを表すインターフェース
 // Service層から利用され、DB操作の抽象化を担当する
 type ChoiceRepository interface {
+	GetByID(ctx context.Context, id int64) (*entities.Choice, error) // IDで選択肢を取得
 	GetByQuestionID(ctx context.Context, questionID int64) ([]entities.Choice, error)                  // 問題IDに紐づく選択肢を取得
+	GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]entities.Choice, error) // 複数の問題に紐づく選択肢をまとめて取得
 	Create(ctx context.Context, choice entities.Choice) (*entities.Choice, error)                      // 新しい選択肢を作成
 	CreateWithAuth(ctx context.Context, choice entities.Choice, userToken string) (*entities.Choice, error) // 認証付きで新しい選択肢を作成
+	CreateBatch(ctx context.Context, choices []entities.Choice, userToken string) ([]entities.Choice, error) // 認証付きで複数の選択肢をまとめて作成
 	Update(ctx context.Context, choice entities.Choice) (*entities.Choice, error)                      // 既存の選択肢を更新
 	Delete(ctx context.Context, id int64) error                                                        // 選択肢を削除
+	UpdatePositions(ctx context.Context, questionID int64, positions map[int64]int, userToken string) error // 選択肢の表示順をまとめて更新
 }
 
 // choiceRepository は ChoiceRepository インターフェースの実装
@@ -42,6 +47,43 @@ func (r *choiceRepository) GetByQuestionID(ctx context.Context, questionID int64
 	return choices, nil
 }
 
+// GetByID は指定された ID の選択肢を DB から取得
+func (r *choiceRepository) GetByID(ctx context.Context, id int64) (*entities.Choice, error) {
+	var choices []entities.Choice
+	err := r.client.DB.From("choices").
+		Select("*").
+		Eq("id", strconv.FormatInt(id, 10)). // int64 → string
+		Execute(&choices)
+	if err != nil {
+		return nil, err
+	}
+	if len(choices) == 0 {
+		return nil, shared.NewDomainError("NOT_FOUND", "選択肢が見つかりません")
+	}
+	return &choices[0], nil
+}
+
+// GetByQuestionIDs は複数の questionID に紐づく選択肢をまとめて DB から取得
+func (r *choiceRepository) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]entities.Choice, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(questionIDs))
+	for i, id := range questionIDs {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+
+	var choices []entities.Choice
+	err := r.client.DB.From("choices").
+		Select("*").
+		In("question_id", ids).
+		Execute(&choices)
+	if err != nil {
+		return nil, err
+	}
+	return choices, nil
+}
+
 // Create は新しい選択肢を DB に追加
 func (r *choiceRepository) Create(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
 	var inserted []entities.Choice
@@ -60,6 +102,19 @@ func (r *choiceRepository) CreateWithAuth(ctx context.Context, choice entities.C
 	return r.Create(ctx, choice)
 }
 
+// CreateBatch は複数の選択肢をまとめて DB に追加
+func (r *choiceRepository) CreateBatch(ctx context.Context, choices []entities.Choice, userToken string) ([]entities.Choice, error) {
+	// 古いSupabase実装では認証対応が困難なため、サービスロールのクライアントで追加
+	var inserted []entities.Choice
+	err := r.client.DB.From("choices").
+		Insert(choices).
+		Execute(&inserted)
+	if err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
 // Update は既存の選択肢を更新
 func (r *choiceRepository) Update(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
 	var updated []entities.Choice
@@ -80,3 +135,18 @@ func (r *choiceRepository) Delete(ctx context.Context, id int64) error {
 		Eq("id", strconv.FormatInt(id, 10)). // ID を条件に削除
 		Execute(nil)
 }
+
+// UpdatePositions は問題に紐づく選択肢の表示順をまとめて更新
+func (r *choiceRepository) UpdatePositions(ctx context.Context, questionID int64, positions map[int64]int, userToken string) error {
+	for id, position := range positions {
+		err := r.client.DB.From("choices").
+			Update(map[string]interface{}{"position": position}).
+			Eq("id", strconv.FormatInt(id, 10)).
+			Eq("question_id", strconv.FormatInt(questionID, 10)).
+			Execute(nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
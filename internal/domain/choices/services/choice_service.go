@@ -5,17 +5,20 @@ import (
 
 	entities "Shittaka_back/internal/domain/choices/entities"
 	"Shittaka_back/internal/domain/choices/repositories"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
 )
 
 // ChoiceService はユースケース層のサービス
 // Repository を利用してアプリケーションの処理をまとめる
 type ChoiceService struct {
-	repo repositories.ChoiceRepository
+	repo         repositories.ChoiceRepository
+	questionRepo questionRepositories.QuestionRepository // 作成者の確認とシャッフル設定の取得に使用
 }
 
 // NewChoiceService は ChoiceService のコンストラクタ
-func NewChoiceService(repo repositories.ChoiceRepository) *ChoiceService {
-	return &ChoiceService{repo: repo}
+func NewChoiceService(repo repositories.ChoiceRepository, questionRepo questionRepositories.QuestionRepository) *ChoiceService {
+	return &ChoiceService{repo: repo, questionRepo: questionRepo}
 }
 
 // GetChoices は問題IDに紐づく選択肢を取得
@@ -23,22 +26,108 @@ func (s *ChoiceService) GetChoices(ctx context.Context, questionID int64) ([]ent
 	return s.repo.GetByQuestionID(ctx, questionID)
 }
 
+// GetChoicesForViewer は閲覧者向けに並べた選択肢を取得
+// 閲覧者が見られない問題（非公開・下書き・非表示）の場合は NOT_FOUND を返す
+// 問題がシャッフル設定の場合は閲覧者ごとに固定された順序でシャッフルする
+func (s *ChoiceService) GetChoicesForViewer(ctx context.Context, questionID int64, viewerID string, viewerKey string) ([]entities.Choice, error) {
+	question, err := s.questionRepo.GetByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !question.IsVisibleTo(viewerID) {
+		return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	}
+
+	choices, err := s.repo.GetByQuestionID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if question.ShuffleChoices {
+		entities.ShuffleForViewer(choices, viewerKey, questionID)
+	} else {
+		entities.SortByPosition(choices)
+	}
+	return choices, nil
+}
+
+// ReorderChoices は選択肢の表示順を指定された並びにまとめて変更する（問題の作成者のみ）
+func (s *ChoiceService) ReorderChoices(ctx context.Context, questionID int64, orderedIDs []int64, userID string, userToken string) error {
+	if err := s.requireAuthor(ctx, questionID, userID, "この問題の選択肢を並べ替える権限がありません"); err != nil {
+		return err
+	}
+
+	choices, err := s.repo.GetByQuestionID(ctx, questionID)
+	if err != nil {
+		return err
+	}
+
+	positions, err := entities.BuildPositions(choices, orderedIDs)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdatePositions(ctx, questionID, positions, userToken)
+}
+
 // CreateChoice は新しい選択肢を作成
 func (s *ChoiceService) CreateChoice(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
 	return s.repo.Create(ctx, choice)
 }
 
-// CreateChoiceWithAuth は認証付きで新しい選択肢を作成
-func (s *ChoiceService) CreateChoiceWithAuth(ctx context.Context, choice entities.Choice, userToken string) (*entities.Choice, error) {
+// CreateChoiceWithAuth は認証付きで新しい選択肢を作成（問題の作成者のみ）
+func (s *ChoiceService) CreateChoiceWithAuth(ctx context.Context, choice entities.Choice, userID string, userToken string) (*entities.Choice, error) {
+	if err := s.requireAuthor(ctx, choice.QuestionID, userID, "この問題に選択肢を追加する権限がありません"); err != nil {
+		return nil, err
+	}
+
+	// 表示順の指定がない場合は末尾に追加する
+	if choice.Position == 0 {
+		existing, err := s.repo.GetByQuestionID(ctx, choice.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		choice.Position = entities.NextPosition(existing)
+	}
 	return s.repo.CreateWithAuth(ctx, choice, userToken)
 }
 
-// UpdateChoice は既存の選択肢を更新
-func (s *ChoiceService) UpdateChoice(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
+// UpdateChoice は既存の選択肢を更新（問題の作成者のみ）
+// 選択肢を別の問題へ移せないよう、問題IDは既存の選択肢のものを使う
+func (s *ChoiceService) UpdateChoice(ctx context.Context, choice entities.Choice, userID string) (*entities.Choice, error) {
+	existing, err := s.repo.GetByID(ctx, choice.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireAuthor(ctx, existing.QuestionID, userID, "この問題の選択肢を編集する権限がありません"); err != nil {
+		return nil, err
+	}
+
+	choice.QuestionID = existing.QuestionID
 	return s.repo.Update(ctx, choice)
 }
 
-// DeleteChoice は選択肢を削除
-func (s *ChoiceService) DeleteChoice(ctx context.Context, id int64) error {
+// DeleteChoice は選択肢を削除（問題の作成者のみ）
+func (s *ChoiceService) DeleteChoice(ctx context.Context, id int64, userID string) error {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.requireAuthor(ctx, existing.QuestionID, userID, "この問題の選択肢を削除する権限がありません"); err != nil {
+		return err
+	}
+
 	return s.repo.Delete(ctx, id)
 }
+
+// requireAuthor は userID が問題の作成者でなければ FORBIDDEN を返す
+func (s *ChoiceService) requireAuthor(ctx context.Context, questionID int64, userID string, message string) error {
+	question, err := s.questionRepo.GetByID(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if question.UserID != userID {
+		return shared.NewDomainError("FORBIDDEN", message)
+	}
+	return nil
+}
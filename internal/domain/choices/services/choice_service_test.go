@@ -2,74 +2,165 @@ package services
 
 import (
 	"context"
-	"log"
-	"os"
 	"testing"
+	"time"
 
 	entities "Shittaka_back/internal/domain/choices/entities"
-	"Shittaka_back/internal/domain/choices/repositories"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/shared"
+	choiceMemory "Shittaka_back/internal/infrastructure/choice/memory"
+	questionMemory "Shittaka_back/internal/infrastructure/question/memory"
 
-	"github.com/joho/godotenv"
-	"github.com/nedpals/supabase-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestChoiceService_CRUD(t *testing.T) {
-	// .envを読み込む
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, relying on system environment variables")
-	}
+const authorID = "00000000-0000-0000-0000-000000000001"
 
-	url := os.Getenv("SUPABASE_URL")
-	serviceRoleKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
-	if url == "" || serviceRoleKey == "" {
-		t.Fatal("SUPABASE_URL or SUPABASE_SERVICE_ROLE_KEY is not set")
-	}
+// newTestService はメモリ上のリポジトリを使うサービスと、選択肢を付ける問題を作成
+func newTestService(t *testing.T) (*ChoiceService, *questionEntities.Question) {
+	t.Helper()
 
-	// Supabase クライアント作成
-	client := supabase.CreateClient(url, serviceRoleKey)
+	questionRepo := questionMemory.NewQuestionRepository(nil)
+	question, err := questionRepo.Create(context.Background(), questionEntities.NewQuestion(1, authorID, "タイトル", "本文", ""), "")
+	require.NoError(t, err)
 
-	// リポジトリとサービス作成
-	repo := repositories.NewChoiceRepository(client)
-	service := NewChoiceService(repo)
+	return NewChoiceService(choiceMemory.NewChoiceRepository(), questionRepo), question
+}
 
+func TestChoiceService_CRUD(t *testing.T) {
+	service, question := newTestService(t)
 	ctx := context.Background()
 
 	// ---------------------------
 	// 1. Create
 	// ---------------------------
 	newChoice := entities.Choice{
-		QuestionID: 1, // 実在する question_id を使用
+		QuestionID: question.ID,
 		Text:       "テスト選択肢",
 		IsCorrect:  false,
 	}
 
 	created, err := service.CreateChoice(ctx, newChoice)
-	assert.NoError(t, err)
-	assert.NotNil(t, created)
-	t.Logf("Created Choice: %+v", created)
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, "テスト選択肢", created.Text)
 
 	// ---------------------------
 	// 2. GetByQuestionID
 	// ---------------------------
-	choices, err := service.GetChoices(ctx, int64(newChoice.QuestionID))
-	assert.NoError(t, err)
-	assert.GreaterOrEqual(t, len(choices), 1)
-	t.Logf("Choices for QuestionID=%d: %+v", newChoice.QuestionID, choices)
+	choices, err := service.GetChoices(ctx, question.ID)
+	require.NoError(t, err)
+	assert.Len(t, choices, 1)
 
 	// ---------------------------
 	// 3. Update
 	// ---------------------------
 	created.Text = "更新済み選択肢"
-	updated, err := service.UpdateChoice(ctx, *created)
-	assert.NoError(t, err)
+	updated, err := service.UpdateChoice(ctx, *created, authorID)
+	require.NoError(t, err)
 	assert.Equal(t, "更新済み選択肢", updated.Text)
-	t.Logf("Updated Choice: %+v", updated)
 
 	// ---------------------------
 	// 4. Delete
 	// ---------------------------
-	err = service.DeleteChoice(ctx, int64(updated.ID))
-	assert.NoError(t, err)
-	t.Logf("Deleted Choice ID: %d", updated.ID)
+	require.NoError(t, service.DeleteChoice(ctx, updated.ID, authorID))
+	choices, err = service.GetChoices(ctx, question.ID)
+	require.NoError(t, err)
+	assert.Empty(t, choices)
+}
+
+func TestChoiceService_UpdateMissingChoice(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.UpdateChoice(context.Background(), entities.Choice{ID: 999, Text: "存在しない"}, authorID)
+	assert.Error(t, err)
+}
+
+func TestChoiceService_OnlyAuthorCanEditChoices(t *testing.T) {
+	service, question := newTestService(t)
+	ctx := context.Background()
+	const otherID = "00000000-0000-0000-0000-000000000002"
+
+	_, err := service.CreateChoiceWithAuth(ctx, entities.Choice{QuestionID: question.ID, Text: "A"}, otherID, "")
+	require.Error(t, err)
+	assert.Equal(t, "FORBIDDEN", err.(shared.DomainError).Code)
+
+	created, err := service.CreateChoiceWithAuth(ctx, entities.Choice{QuestionID: question.ID, Text: "A"}, authorID, "")
+	require.NoError(t, err)
+
+	created.Text = "書き換え"
+	_, err = service.UpdateChoice(ctx, *created, otherID)
+	require.Error(t, err)
+	assert.Equal(t, "FORBIDDEN", err.(shared.DomainError).Code)
+
+	err = service.DeleteChoice(ctx, created.ID, otherID)
+	require.Error(t, err)
+	assert.Equal(t, "FORBIDDEN", err.(shared.DomainError).Code)
+
+	choices, err := service.GetChoices(ctx, question.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, texts(choices))
+}
+
+func TestChoiceService_CreateAppendsAndReorders(t *testing.T) {
+	service, question := newTestService(t)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, text := range []string{"A", "B", "C"} {
+		created, err := service.CreateChoiceWithAuth(ctx, entities.Choice{QuestionID: question.ID, Text: text}, authorID, "")
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+
+	// 表示順の指定がない選択肢は末尾に追加される
+	choices, err := service.GetChoicesForViewer(ctx, question.ID, authorID, "viewer")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C"}, texts(choices))
+
+	// 作成者以外は並べ替えられない
+	err = service.ReorderChoices(ctx, question.ID, []int64{ids[2], ids[1], ids[0]}, "00000000-0000-0000-0000-000000000002", "")
+	assert.Error(t, err)
+
+	require.NoError(t, service.ReorderChoices(ctx, question.ID, []int64{ids[2], ids[0], ids[1]}, authorID, ""))
+	choices, err = service.GetChoicesForViewer(ctx, question.ID, authorID, "viewer")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C", "A", "B"}, texts(choices))
+}
+
+func TestChoiceService_GetChoicesForViewer_HidesUnpublishedQuestion(t *testing.T) {
+	ctx := context.Background()
+	questionRepo := questionMemory.NewQuestionRepository(nil)
+	question, err := questionRepo.Create(ctx, questionEntities.NewQuestion(1, authorID, "タイトル", "本文", ""), "")
+	require.NoError(t, err)
+	service := NewChoiceService(choiceMemory.NewChoiceRepository(), questionRepo)
+
+	_, err = service.CreateChoiceWithAuth(ctx, entities.Choice{QuestionID: question.ID, Text: "A"}, authorID, "")
+	require.NoError(t, err)
+
+	// 下書きの選択肢は作成者以外には見えない
+	_, err = service.GetChoicesForViewer(ctx, question.ID, "", "anonymous")
+	require.Error(t, err)
+	assert.Equal(t, "NOT_FOUND", err.(shared.DomainError).Code)
+
+	choices, err := service.GetChoicesForViewer(ctx, question.ID, authorID, authorID)
+	require.NoError(t, err)
+	assert.Len(t, choices, 1)
+
+	require.NoError(t, question.Publish(time.Now()))
+	require.NoError(t, questionRepo.UpdateStatus(ctx, question, ""))
+
+	choices, err = service.GetChoicesForViewer(ctx, question.ID, "", "anonymous")
+	require.NoError(t, err)
+	assert.Len(t, choices, 1)
+}
+
+// texts は選択肢の本文を並び順のまま返す
+func texts(choices []entities.Choice) []string {
+	result := make([]string, len(choices))
+	for i, c := range choices {
+		result[i] = c.Text
+	}
+	return result
 }
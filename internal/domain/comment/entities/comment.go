@@ -0,0 +1,92 @@
+package entities
+
+// comment.goは問題に対するコメントのドメインエンティティを定義
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// CommentEditWindow はコメント投稿後に編集できる期間
+const CommentEditWindow = 15 * time.Minute
+
+// MaxCommentLength はコメント本文の最大文字数
+const MaxCommentLength = 1000
+
+// Comment は問題に対するコメントのドメインエンティティ
+// ParentID が nil のものがスレッドの起点、値があるものは返信（1階層のみ）
+type Comment struct {
+	ID         int64      `json:"id"`
+	QuestionID int64      `json:"question_id"`
+	UserID     string     `json:"user_id"`
+	ParentID   *int64     `json:"parent_id"`
+	Body       string     `json:"body"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+// NewComment は新しいCommentエンティティを作成
+func NewComment(questionID int64, userID string, parentID *int64, body string) *Comment {
+	now := time.Now()
+	return &Comment{
+		QuestionID: questionID,
+		UserID:     userID,
+		ParentID:   parentID,
+		Body:       body,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Validate はCommentエンティティのバリデーションを行う
+func (c *Comment) Validate() error {
+	if c.QuestionID == 0 {
+		return shared.NewValidationError("question_id", "question_id is required")
+	}
+	if c.UserID == "" {
+		return shared.NewValidationError("user_id", "user_id is required")
+	}
+	if strings.TrimSpace(c.Body) == "" {
+		return shared.NewValidationError("body", "body is required")
+	}
+	if utf8.RuneCountInString(c.Body) > MaxCommentLength {
+		return shared.NewValidationError("body", "body is too long")
+	}
+	return nil
+}
+
+// IsReply は返信コメントかどうかを返す
+func (c *Comment) IsReply() bool {
+	return c.ParentID != nil
+}
+
+// IsDeleted は削除済みかどうかを返す
+func (c *Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// IsEdited は投稿後に編集されたかどうかを返す
+func (c *Comment) IsEdited() bool {
+	return c.UpdatedAt.After(c.CreatedAt.Add(time.Second))
+}
+
+// CanEdit は指定時刻に編集可能かどうかを返す
+func (c *Comment) CanEdit(now time.Time) bool {
+	return !c.IsDeleted() && now.Sub(c.CreatedAt) <= CommentEditWindow
+}
+
+// Edit は本文を更新する
+func (c *Comment) Edit(body string, now time.Time) {
+	c.Body = body
+	c.UpdatedAt = now
+}
+
+// SoftDelete はコメントを論理削除する
+func (c *Comment) SoftDelete(now time.Time) {
+	c.DeletedAt = &now
+	c.UpdatedAt = now
+}
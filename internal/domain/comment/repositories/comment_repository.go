@@ -0,0 +1,30 @@
+package repositories
+
+// comment_repository.goはコメントリポジトリのインターフェースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/comment/entities"
+)
+
+// CommentRepository はコメントリポジトリのインターフェース
+type CommentRepository interface {
+	// Create は新しいコメントを作成する（認証が必要）
+	Create(ctx context.Context, comment *entities.Comment, userToken string) (*entities.Comment, error)
+
+	// GetByID はIDでコメントを取得する
+	GetByID(ctx context.Context, id int64) (*entities.Comment, error)
+
+	// GetRootsByQuestionID は問題に紐づくスレッド起点のコメントを古い順に取得する
+	GetRootsByQuestionID(ctx context.Context, questionID int64, limit, offset int) ([]*entities.Comment, error)
+
+	// GetRepliesByParentIDs は指定したコメントへの返信を古い順に取得する
+	GetRepliesByParentIDs(ctx context.Context, parentIDs []int64) ([]*entities.Comment, error)
+
+	// Update はコメント本文を更新する（認証が必要）
+	Update(ctx context.Context, comment *entities.Comment, userToken string) error
+
+	// SoftDelete はコメントを論理削除する（認証が必要）
+	SoftDelete(ctx context.Context, comment *entities.Comment, userToken string) error
+}
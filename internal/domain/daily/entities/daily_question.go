@@ -0,0 +1,57 @@
+package entities
+
+// daily_question.goは「今日の一問」のドメインエンティティを定義
+
+import (
+	"time"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// DateLayout は日付キーの書式
+const DateLayout = "2006-01-02"
+
+// DailyQuestion は日付ごとに選ばれた「今日の一問」
+type DailyQuestion struct {
+	Date       string    `json:"date"`
+	QuestionID int64     `json:"question_id"`
+	IsOverride bool      `json:"is_override"`
+	SetBy      string    `json:"set_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewDailyQuestion は自動選出されたDailyQuestionを作成
+func NewDailyQuestion(date string, questionID int64) *DailyQuestion {
+	return &DailyQuestion{
+		Date:       date,
+		QuestionID: questionID,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// NewDailyQuestionOverride はキュレーターが指定したDailyQuestionを作成
+func NewDailyQuestionOverride(date string, questionID int64, curatorID string) *DailyQuestion {
+	return &DailyQuestion{
+		Date:       date,
+		QuestionID: questionID,
+		IsOverride: true,
+		SetBy:      curatorID,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Validate はDailyQuestionエンティティのバリデーションを行う
+func (d *DailyQuestion) Validate() error {
+	if _, err := time.Parse(DateLayout, d.Date); err != nil {
+		return shared.NewValidationError("date", "date must be YYYY-MM-DD")
+	}
+	if d.QuestionID == 0 {
+		return shared.NewValidationError("question_id", "question_id is required")
+	}
+	return nil
+}
+
+// DateKey は指定タイムゾーンでの日付キーを返す
+func DateKey(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(DateLayout)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/daily/entities"
+)
+
+// DailyQuestionRepository は「今日の一問」リポジトリのインターフェース
+type DailyQuestionRepository interface {
+	GetByDate(ctx context.Context, date string) (*entities.DailyQuestion, error)
+	GetSince(ctx context.Context, date string) ([]*entities.DailyQuestion, error)
+	Create(ctx context.Context, daily *entities.DailyQuestion) error
+	Upsert(ctx context.Context, daily *entities.DailyQuestion) error
+}
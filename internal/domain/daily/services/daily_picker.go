@@ -0,0 +1,34 @@
+package services
+
+// daily_picker.goは「今日の一問」を決定的に選ぶドメインサービスを定義
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+// PickDailyQuestion は日付をシードに候補から問題IDを1つ選ぶ
+// 同じ日付・同じ候補なら必ず同じ問題を返すため、全ユーザーに同じ問題が表示される
+// recent に含まれる問題（直近で選ばれた問題）は候補から外すが、全て外れる場合は全候補から選ぶ
+// 候補が空の場合は 0 を返す
+func PickDailyQuestion(date string, candidates []int64, recent map[int64]bool) int64 {
+	pool := make([]int64, 0, len(candidates))
+	for _, id := range candidates {
+		if !recent[id] {
+			pool = append(pool, id)
+		}
+	}
+	if len(pool) == 0 {
+		pool = append(pool, candidates...)
+	}
+	if len(pool) == 0 {
+		return 0
+	}
+
+	// 取得順に依存しないよう並べ替えてから選ぶ
+	sort.Slice(pool, func(i, j int) bool { return pool[i] < pool[j] })
+
+	h := fnv.New64a()
+	h.Write([]byte(date))
+	return pool[h.Sum64()%uint64(len(pool))]
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickDailyQuestion_IsDeterministic(t *testing.T) {
+	first := PickDailyQuestion("2024-05-01", []int64{3, 1, 2}, nil)
+	second := PickDailyQuestion("2024-05-01", []int64{2, 3, 1}, nil)
+
+	assert.NotZero(t, first)
+	assert.Equal(t, first, second)
+}
+
+func TestPickDailyQuestion_AvoidsRecent(t *testing.T) {
+	candidates := []int64{1, 2, 3}
+	recent := map[int64]bool{1: true, 2: true}
+
+	for _, date := range []string{"2024-05-01", "2024-05-02", "2024-05-03"} {
+		assert.Equal(t, int64(3), PickDailyQuestion(date, candidates, recent))
+	}
+
+	// 全て直近に出題済みなら全候補から選ぶ
+	all := map[int64]bool{1: true, 2: true, 3: true}
+	assert.Contains(t, candidates, PickDailyQuestion("2024-05-01", candidates, all))
+
+	assert.Zero(t, PickDailyQuestion("2024-05-01", nil, nil))
+}
@@ -0,0 +1,55 @@
+package entities
+
+// follow.goはユーザー・ジャンルのフォローのドメインエンティティを定義
+
+import (
+	"time"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// UserFollow はユーザーが他のユーザーをフォローしている関係
+type UserFollow struct {
+	FollowerID string    `json:"follower_id"` // フォローしているユーザー
+	FolloweeID string    `json:"followee_id"` // フォローされているユーザー
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewUserFollow は新しいUserFollowエンティティを作成
+func NewUserFollow(followerID, followeeID string) *UserFollow {
+	return &UserFollow{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Validate はUserFollowエンティティのバリデーションを行う
+func (f *UserFollow) Validate() error {
+	if f.FollowerID == "" {
+		return shared.NewValidationError("follower_id", "follower_id is required")
+	}
+	if f.FolloweeID == "" {
+		return shared.NewValidationError("followee_id", "followee_id is required")
+	}
+	if f.FollowerID == f.FolloweeID {
+		return shared.NewValidationError("followee_id", "自分自身はフォローできません")
+	}
+	return nil
+}
+
+// GenreFollow はユーザーがジャンルをフォローしている関係
+type GenreFollow struct {
+	UserID    string    `json:"user_id"`
+	GenreID   int64     `json:"genre_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewGenreFollow は新しいGenreFollowエンティティを作成
+func NewGenreFollow(userID string, genreID int64) *GenreFollow {
+	return &GenreFollow{
+		UserID:    userID,
+		GenreID:   genreID,
+		CreatedAt: time.Now(),
+	}
+}
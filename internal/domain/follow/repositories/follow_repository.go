@@ -0,0 +1,36 @@
+package repositories
+
+// follow_repository.goはフォローリポジトリのインターフェースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/follow/entities"
+)
+
+// FollowRepository はフォローリポジトリのインターフェース
+type FollowRepository interface {
+	// FollowUser はユーザーをフォローする（既にフォローしている場合は何もしない、認証が必要）
+	FollowUser(ctx context.Context, follow *entities.UserFollow, userToken string) error
+
+	// UnfollowUser はユーザーのフォローを外す（認証が必要）
+	UnfollowUser(ctx context.Context, followerID, followeeID string, userToken string) error
+
+	// GetFollowers はユーザーのフォロワーを新しい順に取得する
+	GetFollowers(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error)
+
+	// GetFollowing はユーザーがフォローしているユーザーを新しい順に取得する
+	GetFollowing(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error)
+
+	// GetFollowingIDs はユーザーがフォローしている全てのユーザーIDを取得する
+	GetFollowingIDs(ctx context.Context, userID string) ([]string, error)
+
+	// FollowGenre はジャンルをフォローする（既にフォローしている場合は何もしない、認証が必要）
+	FollowGenre(ctx context.Context, follow *entities.GenreFollow, userToken string) error
+
+	// UnfollowGenre はジャンルのフォローを外す（認証が必要）
+	UnfollowGenre(ctx context.Context, userID string, genreID int64, userToken string) error
+
+	// GetFollowedGenreIDs はユーザーがフォローしている全てのジャンルIDを取得する
+	GetFollowedGenreIDs(ctx context.Context, userID string) ([]int64, error)
+}
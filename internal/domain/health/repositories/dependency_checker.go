@@ -0,0 +1,15 @@
+package repositories
+
+// dependency_checker.goは外部の依存先（データベース・認証など）の疎通確認のインターフェースを定義
+
+import "context"
+
+// DependencyChecker は外部の依存先にリクエストを受け付けられる状態かを確認するインターフェース
+// 保存先の設定（DATABASE_DRIVER）に応じて確認する依存先を切り替える
+type DependencyChecker interface {
+	// Name は依存先の名前（レスポンスに表示する）
+	Name() string
+
+	// Check は依存先へ接続できることを確認する（接続できない場合はエラー）
+	Check(ctx context.Context) error
+}
@@ -0,0 +1,31 @@
+package entities
+
+// user_stats.goはユーザーの公開プロフィールに表示する集計値を定義
+
+// UserStats はユーザーが作成した問題と回答の集計
+type UserStats struct {
+	QuestionCount        int // 公開中の作成した問題の数
+	ReceivedAnswerCount  int // 作成した問題に他のユーザーが回答した数
+	ReceivedCorrectCount int // そのうち正解だった数
+	AnswerCount          int // ユーザー自身が回答した数
+	CorrectCount         int // そのうち正解だった数
+}
+
+// ReceivedCorrectRate は作成した問題に他のユーザーが正解した割合を返す（回答が無ければ nil）
+func (s *UserStats) ReceivedCorrectRate() *float64 {
+	return rate(s.ReceivedCorrectCount, s.ReceivedAnswerCount)
+}
+
+// Accuracy はユーザー自身の正答率を返す（回答が無ければ nil）
+func (s *UserStats) Accuracy() *float64 {
+	return rate(s.CorrectCount, s.AnswerCount)
+}
+
+// rate は分母が0の場合に nil を返す割合の計算
+func rate(numerator, denominator int) *float64 {
+	if denominator == 0 {
+		return nil
+	}
+	r := float64(numerator) / float64(denominator)
+	return &r
+}
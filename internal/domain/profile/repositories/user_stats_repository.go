@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"Shittaka_back/internal/domain/profile/entities"
+	"context"
+)
+
+// UserStatsRepository はユーザーの集計値を取得するリポジトリのインターフェース
+type UserStatsRepository interface {
+	GetStats(ctx context.Context, userID string) (*entities.UserStats, error)
+}
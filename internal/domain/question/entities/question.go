@@ -5,18 +5,56 @@ import (
 	"time"
 )
 
+// 問題の公開状態
+const (
+	StatusDraft     = "draft"     // 下書き（作成者のみ閲覧可能）
+	StatusPublished = "published" // 公開中
+	StatusArchived  = "archived"  // アーカイブ済み（一覧に表示しない）
+)
+
+// 問題の種類
+const (
+	TypeSingleChoice   = "single_choice"   // 単一選択
+	TypeMultipleSelect = "multiple_select" // 複数選択（正解の選択肢を全て選ぶ）
+	TypeTrueFalse      = "true_false"      // ○×
+	TypeFreeText       = "free_text"       // 自由記述（正解表記のいずれかに一致）
+	TypeNumeric        = "numeric"         // 数値（許容誤差以内なら正解）
+)
+
+// 問題文・解説の形式
+const (
+	BodyFormatPlain    = "plain"    // プレーンテキスト
+	BodyFormatMarkdown = "markdown" // Markdown（数式を含められる）
+)
+
 // Question は問題のドメインエンティティ
 type Question struct {
-	ID             int64     `json:"id"`
-	GenreID        int64     `json:"genre_id"`
-	UserID         string    `json:"user_id"`
-	Title          string    `json:"title"`
-	Body           string    `json:"body"`
-	Explanation    string    `json:"explanation"`
-	CreatedAt      time.Time `json:"created_at"`
-	Views          int       `json:"views"`
-	CorrectCount   int       `json:"correct_count"`
-	IncorrectCount int       `json:"incorrect_count"`
+	ID             int64      `json:"id"`
+	GenreID        int64      `json:"genre_id"`
+	UserID         string     `json:"user_id"`
+	Title          string     `json:"title"`
+	Body           string     `json:"body"`
+	Explanation    string     `json:"explanation"`
+	BodyFormat     string     `json:"body_format"` // 問題文・解説の形式
+	CreatedAt      time.Time  `json:"created_at"`
+	Views          int        `json:"views"`
+	CorrectCount   int        `json:"correct_count"`
+	IncorrectCount int        `json:"incorrect_count"`
+	IsHidden       bool       `json:"is_hidden"`
+	Revision       int        `json:"revision"`
+	Status         string     `json:"status"`
+	PublishedAt    *time.Time `json:"published_at"`
+	PublishAt      *time.Time `json:"publish_at"`
+
+	// 問題の種類と採点に使う正解の情報
+	Type             string   `json:"type"`
+	PartialCredit    bool     `json:"partial_credit"`
+	AcceptedAnswers  []string `json:"accepted_answers"`
+	NumericAnswer    *float64 `json:"numeric_answer"`
+	NumericTolerance float64  `json:"numeric_tolerance"`
+
+	// 回答者ごとに選択肢の並びをシャッフルするかどうか
+	ShuffleChoices bool `json:"shuffle_choices"`
 }
 
 // NewQuestion は新しいQuestionエンティティを作成
@@ -27,11 +65,38 @@ func NewQuestion(genreID int64, userID, title, body, explanation string) *Questi
 		Title:          title,
 		Body:           body,
 		Explanation:    explanation,
+		BodyFormat:     BodyFormatPlain,
 		CreatedAt:      time.Now(),
 		Views:          0,
 		CorrectCount:   0,
 		IncorrectCount: 0,
+		Revision:       1,
+		Status:         StatusDraft,
+		Type:           TypeSingleChoice,
+	}
+}
+
+// IsValidType は問題の種類が定義済みかどうかを返す
+func IsValidType(questionType string) bool {
+	switch questionType {
+	case TypeSingleChoice, TypeMultipleSelect, TypeTrueFalse, TypeFreeText, TypeNumeric:
+		return true
 	}
+	return false
+}
+
+// IsValidBodyFormat は問題文・解説の形式が定義済みかどうかを返す
+func IsValidBodyFormat(format string) bool {
+	return format == BodyFormatPlain || format == BodyFormatMarkdown
+}
+
+// UsesChoices は選択肢で回答する種類の問題かどうかを返す
+func (q *Question) UsesChoices() bool {
+	switch q.Type {
+	case TypeFreeText, TypeNumeric:
+		return false
+	}
+	return true
 }
 
 // Validate はQuestionエンティティのバリデーションを行う
@@ -45,6 +110,15 @@ func (q *Question) Validate() error {
 	if q.Title == "" {
 		return shared.NewValidationError("title", "title is required")
 	}
+	if !IsValidType(q.Type) {
+		return shared.NewValidationError("type", "invalid question type")
+	}
+	if !IsValidBodyFormat(q.BodyFormat) {
+		return shared.NewValidationError("body_format", "invalid body format")
+	}
+	if q.NumericTolerance < 0 {
+		return shared.NewValidationError("numeric_tolerance", "numeric_tolerance must not be negative")
+	}
 	return nil
 }
 
@@ -53,6 +127,71 @@ func (q *Question) IncrementViews() {
 	q.Views++
 }
 
+// IsPublished は公開中かどうかを返す
+func (q *Question) IsPublished() bool {
+	return q.Status == StatusPublished
+}
+
+// IsPubliclyVisible は誰でも閲覧・回答できるかどうか（公開中かつ通報で非公開になっていない）を返す
+func (q *Question) IsPubliclyVisible() bool {
+	return q.IsPublished() && !q.IsHidden
+}
+
+// IsVisibleTo は指定ユーザーが問題を閲覧できるかどうかを返す
+// 公開中以外の問題と通報で非公開になった問題は作成者のみ閲覧できる
+func (q *Question) IsVisibleTo(userID string) bool {
+	return q.IsPubliclyVisible() || (userID != "" && q.UserID == userID)
+}
+
+// Publish は問題を公開する（下書き・アーカイブ済み → 公開中）
+func (q *Question) Publish(now time.Time) error {
+	if q.IsPublished() {
+		return shared.NewDomainError("INVALID_TRANSITION", "既に公開されています")
+	}
+	q.Status = StatusPublished
+	q.PublishedAt = &now
+	q.PublishAt = nil
+	return nil
+}
+
+// SchedulePublish は下書きの問題を指定日時に公開するよう予約する
+// at が nil の場合は予約を取り消す
+func (q *Question) SchedulePublish(at *time.Time, now time.Time) error {
+	if q.Status != StatusDraft {
+		return shared.NewDomainError("INVALID_TRANSITION", "予約公開できるのは下書きのみです")
+	}
+	if at != nil && !at.After(now) {
+		return shared.NewValidationError("publish_at", "publish_at must be in the future")
+	}
+	q.PublishAt = at
+	return nil
+}
+
+// IsDueForPublish は予約公開の日時を過ぎた下書きかどうかを返す
+func (q *Question) IsDueForPublish(now time.Time) bool {
+	return q.Status == StatusDraft && q.PublishAt != nil && !q.PublishAt.After(now)
+}
+
+// Unpublish は問題を下書きに戻す（公開中・アーカイブ済み → 下書き）
+func (q *Question) Unpublish() error {
+	if q.Status == StatusDraft {
+		return shared.NewDomainError("INVALID_TRANSITION", "既に下書きです")
+	}
+	q.Status = StatusDraft
+	q.PublishedAt = nil
+	q.PublishAt = nil
+	return nil
+}
+
+// Archive は問題をアーカイブする
+func (q *Question) Archive() error {
+	if q.Status == StatusArchived {
+		return shared.NewDomainError("INVALID_TRANSITION", "既にアーカイブされています")
+	}
+	q.Status = StatusArchived
+	return nil
+}
+
 // IncrementCorrectCount は正解数をインクリメント
 func (q *Question) IncrementCorrectCount() {
 	q.CorrectCount++
@@ -61,4 +200,4 @@ func (q *Question) IncrementCorrectCount() {
 // IncrementIncorrectCount は不正解数をインクリメント
 func (q *Question) IncrementIncorrectCount() {
 	q.IncorrectCount++
-}
\ No newline at end of file
+}
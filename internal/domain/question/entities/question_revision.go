@@ -0,0 +1,48 @@
+package entities
+
+// question_revision.goは問題の編集履歴（リビジョン）のドメインエンティティを定義
+
+import "time"
+
+// QuestionRevision は問題のある時点の内容を保持する不変の履歴
+// 採点に使う問題の種類と正解の情報も記録し、リビジョンを戻すときに復元する
+type QuestionRevision struct {
+	ID               int64     `json:"id"`
+	QuestionID       int64     `json:"question_id"`
+	Revision         int       `json:"revision"`
+	UserID           string    `json:"user_id"`
+	Title            string    `json:"title"`
+	Body             string    `json:"body"`
+	Explanation      string    `json:"explanation"`
+	Type             string    `json:"type"` // 正解の情報を記録する前のリビジョンでは空
+	PartialCredit    bool      `json:"partial_credit"`
+	AcceptedAnswers  []string  `json:"accepted_answers"`
+	NumericAnswer    *float64  `json:"numeric_answer"`
+	NumericTolerance float64   `json:"numeric_tolerance"`
+	Diff             string    `json:"diff"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// NewQuestionRevision は問題の現在の内容からリビジョンを作成
+func NewQuestionRevision(question *Question, userID, diff string) *QuestionRevision {
+	return &QuestionRevision{
+		QuestionID:       question.ID,
+		Revision:         question.Revision,
+		UserID:           userID,
+		Title:            question.Title,
+		Body:             question.Body,
+		Explanation:      question.Explanation,
+		Type:             question.Type,
+		PartialCredit:    question.PartialCredit,
+		AcceptedAnswers:  question.AcceptedAnswers,
+		NumericAnswer:    question.NumericAnswer,
+		NumericTolerance: question.NumericTolerance,
+		Diff:             diff,
+		CreatedAt:        time.Now(),
+	}
+}
+
+// HasAnswerKey は問題の種類と正解の情報が記録されているかどうかを返す
+func (r *QuestionRevision) HasAnswerKey() bool {
+	return r.Type != ""
+}
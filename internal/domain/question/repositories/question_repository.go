@@ -2,15 +2,53 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"Shittaka_back/internal/domain/question/entities"
 )
 
+// QuestionPageQuery は問題をID順に少しずつ取得するための条件
+type QuestionPageQuery struct {
+	UserID        string  // 指定した場合は作成者で絞り込む
+	GenreID       int64   // 指定した場合はジャンルで絞り込む
+	TagSlug       string  // 指定した場合はタグで絞り込む
+	IDs           []int64 // 指定した場合はこれらのIDの問題に絞り込む（空のスライスは nil と同じく絞り込まない）
+	PublishedOnly bool    // 公開中かつ非表示でない問題のみに絞り込む
+	AfterID       int64   // このIDより大きい問題を取得する
+	NewestFirst   bool    // 新しい問題から順に取得する（AfterID の代わりに Offset で続きを取得する）
+	Offset        int     // 先頭から読み飛ばす件数
+	Limit         int
+}
+
+// FeedPosition はフィードの並び（公開日時とIDの降順）上の位置
+type FeedPosition struct {
+	PublishedAt time.Time
+	ID          int64
+}
+
+// QuestionFeedQuery はフォローしている作成者・ジャンルの公開中の問題を新しい順に取得するための条件
+type QuestionFeedQuery struct {
+	AuthorIDs []string      // これらのユーザーが作成した問題
+	GenreIDs  []int64       // または、これらのジャンルの問題
+	Before    *FeedPosition // 指定した場合はこの位置より後（古い側）の問題を取得する
+	Limit     int
+}
+
 // QuestionRepository は問題リポジトリのインターフェース
 type QuestionRepository interface {
 	Create(ctx context.Context, question *entities.Question, userToken string) (*entities.Question, error)
+	CreateBatch(ctx context.Context, questions []*entities.Question, userToken string) ([]*entities.Question, error)
 	GetByID(ctx context.Context, id int64) (*entities.Question, error)
 	GetByUserID(ctx context.Context, userID string, userToken string) ([]*entities.Question, error)
 	Update(ctx context.Context, question *entities.Question, userToken string) error
 	Delete(ctx context.Context, id int64, userToken string) error
 	GetAll(ctx context.Context) ([]*entities.Question, error)
-}
\ No newline at end of file
+	GetPage(ctx context.Context, query QuestionPageQuery, userToken string) ([]*entities.Question, error)
+	GetFeed(ctx context.Context, query QuestionFeedQuery) ([]*entities.Question, error)
+	UpdateStatus(ctx context.Context, question *entities.Question, userToken string) error
+	GetDueForPublish(ctx context.Context, now time.Time) ([]*entities.Question, error)
+	PublishScheduled(ctx context.Context, question *entities.Question) error
+	CancelScheduledPublish(ctx context.Context, id int64) error
+	SetHidden(ctx context.Context, id int64, hidden bool) error
+	IncrementViews(ctx context.Context, id int64, delta int) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/question/entities"
+)
+
+// QuestionRevisionRepository は問題リビジョンリポジトリのインターフェース
+// リビジョンは追記のみで、更新・削除は行わない
+type QuestionRevisionRepository interface {
+	Create(ctx context.Context, revision *entities.QuestionRevision, userToken string) (*entities.QuestionRevision, error)
+	CreateBatch(ctx context.Context, revisions []*entities.QuestionRevision, userToken string) ([]*entities.QuestionRevision, error)
+	GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.QuestionRevision, error)
+	GetByRevision(ctx context.Context, questionID int64, revision int) (*entities.QuestionRevision, error)
+}
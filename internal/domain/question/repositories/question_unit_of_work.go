@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	tagRepositories "Shittaka_back/internal/domain/tag/repositories"
+)
+
+// QuestionWriteRepositories は問題とそれに付随するデータ（リビジョン・選択肢・タグ）を書き込むリポジトリの組
+type QuestionWriteRepositories struct {
+	Questions QuestionRepository
+	Revisions QuestionRevisionRepository
+	Choices   choiceRepositories.ChoiceRepository
+	Tags      tagRepositories.TagRepository
+}
+
+// QuestionUnitOfWork は問題とそれに付随するデータの書き込みを1つのトランザクションで行うインターフェース
+// トランザクションに対応する保存先（PostgreSQLへの直接接続）のみが実装する
+type QuestionUnitOfWork interface {
+	// Do は fn に渡したリポジトリでの書き込みを1つのトランザクションで行う
+	// fn がエラーを返した場合は全ての書き込みを取り消す
+	Do(ctx context.Context, fn func(repos QuestionWriteRepositories) error) error
+}
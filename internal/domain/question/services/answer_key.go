@@ -0,0 +1,40 @@
+package services
+
+// answer_key.goは問題を公開できるだけの正解情報がそろっているかを検証するドメインサービスを定義
+
+import (
+	"strings"
+
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// ValidateAnswerKey は問題の種類ごとに採点に必要な正解情報がそろっているかを検証する
+// choices は選択肢で回答する種類の問題のときだけ参照する
+func ValidateAnswerKey(question *entities.Question, choices []choiceEntities.Choice) error {
+	switch question.Type {
+	case entities.TypeTrueFalse:
+		if len(choices) != 2 {
+			return shared.NewValidationError("choices", "○×問題の選択肢はちょうど2つにしてください")
+		}
+		if choices[0].IsCorrect == choices[1].IsCorrect {
+			return shared.NewValidationError("choices", "○×問題は正解の選択肢を1つだけにしてください")
+		}
+		return nil
+	case entities.TypeFreeText:
+		for _, accepted := range question.AcceptedAnswers {
+			if strings.TrimSpace(accepted) != "" {
+				return nil
+			}
+		}
+		return shared.NewValidationError("accepted_answers", "公開するには正解の表記が1つ以上必要です")
+	case entities.TypeNumeric:
+		if question.NumericAnswer == nil {
+			return shared.NewValidationError("numeric_answer", "公開するには正解の数値が必要です")
+		}
+		return nil
+	default:
+		return choiceEntities.ValidateChoiceSet(choices)
+	}
+}
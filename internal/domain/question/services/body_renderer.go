@@ -0,0 +1,280 @@
+package services
+
+// body_renderer.goは問題文・解説を表示用のHTMLに変換するドメインサービスを定義
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkHTML "github.com/yuin/goldmark/renderer/html"
+
+	"Shittaka_back/internal/domain/question/entities"
+)
+
+// 数式を退避する際のプレースホルダーの区切り（Unicodeの私用領域の文字）
+const (
+	placeholderOpen  = "\uE000"
+	placeholderClose = "\uE001"
+)
+
+var (
+	// markdownRenderer は生のHTMLを出力しない（goldmarkの既定）Markdownレンダラー
+	markdownRenderer = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(goldmarkHTML.WithHardWraps()),
+	)
+
+	// htmlSanitizer はscriptやjavascript:などの危険なリンクを取り除くポリシー
+	htmlSanitizer = newHTMLSanitizer()
+)
+
+// newHTMLSanitizer はユーザー投稿向けのHTMLサニタイズポリシーを作成
+func newHTMLSanitizer() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.RequireNoFollowOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	return policy
+}
+
+// RenderHTML は問題文・解説を本文の形式に従ってサニタイズ済みのHTMLに変換する
+// 数式（$...$, $$...$$, \(...\), \[...\]）はMarkdownとして解釈させずにそのまま残し、
+// クライアントのKaTeXやMathJaxで描画できるよう math クラスの要素で囲む
+func RenderHTML(text, format string) string {
+	if text == "" {
+		return ""
+	}
+
+	// 入力に含まれるプレースホルダーの区切り文字は取り除いておく
+	text = strings.NewReplacer(placeholderOpen, "", placeholderClose, "").Replace(text)
+
+	var rendered string
+	if format == entities.BodyFormatMarkdown {
+		protected, spans := extractMath(text, true)
+		var buf bytes.Buffer
+		if err := markdownRenderer.Convert([]byte(protected), &buf); err != nil {
+			// 変換できない場合はプレーンテキストとして表示する
+			return RenderHTML(text, entities.BodyFormatPlain)
+		}
+		rendered = restoreMath(htmlSanitizer.Sanitize(buf.String()), spans)
+	} else {
+		protected, spans := extractMath(text, false)
+		rendered = restoreMath(renderPlain(protected), spans)
+	}
+
+	return strings.TrimSpace(rendered)
+}
+
+// renderPlain はプレーンテキストをエスケープし、空行で段落、改行で <br> に変換する
+func renderPlain(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var b strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if paragraph == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// mathSpan は退避した数式
+type mathSpan struct {
+	tex     string
+	display bool
+}
+
+// extractMath は数式をプレースホルダーに置き換え、退避した数式を返す
+// skipCode が true の場合はMarkdownのコード（`...` とフェンス付きコードブロック）の中は数式として扱わない
+func extractMath(text string, skipCode bool) (string, []mathSpan) {
+	var b strings.Builder
+	var spans []mathSpan
+
+	addSpan := func(tex string, display bool) {
+		b.WriteString(placeholderOpen + strconv.Itoa(len(spans)) + placeholderClose)
+		spans = append(spans, mathSpan{tex: tex, display: display})
+	}
+
+	for i := 0; i < len(text); {
+		lineStart := i == 0 || text[i-1] == '\n'
+
+		// フェンス付きコードブロックは閉じるフェンスまでそのまま残す
+		if skipCode && lineStart {
+			if end, ok := fencedCodeEnd(text, i); ok {
+				b.WriteString(text[i:end])
+				i = end
+				continue
+			}
+		}
+
+		switch {
+		case skipCode && text[i] == '`':
+			// インラインコードは同じ長さのバッククォートまでそのまま残す
+			run := countRun(text, i, '`')
+			fence := strings.Repeat("`", run)
+			if end := findRun(text, i+run, fence); end >= 0 {
+				b.WriteString(text[i : end+run])
+				i = end + run
+			} else {
+				b.WriteString(fence)
+				i += run
+			}
+
+		case text[i] == '\\' && i+1 < len(text) && (text[i+1] == '(' || text[i+1] == '['):
+			closing := `\)`
+			if text[i+1] == '[' {
+				closing = `\]`
+			}
+			if end := strings.Index(text[i+2:], closing); end >= 0 {
+				addSpan(text[i+2:i+2+end], text[i+1] == '[')
+				i += 2 + end + 2
+			} else {
+				b.WriteString(text[i : i+2])
+				i += 2
+			}
+
+		case text[i] == '\\' && i+1 < len(text):
+			// エスケープされた文字（\$ など）は数式の区切りとして扱わない
+			b.WriteString(text[i : i+2])
+			i += 2
+
+		case strings.HasPrefix(text[i:], "$$"):
+			if end := strings.Index(text[i+2:], "$$"); end >= 0 && strings.TrimSpace(text[i+2:i+2+end]) != "" {
+				addSpan(strings.TrimSpace(text[i+2:i+2+end]), true)
+				i += 2 + end + 2
+			} else {
+				b.WriteString("$$")
+				i += 2
+			}
+
+		case text[i] == '$':
+			if end, ok := inlineMathEnd(text, i); ok {
+				addSpan(text[i+1:end], false)
+				i = end + 1
+			} else {
+				b.WriteByte('$')
+				i++
+			}
+
+		default:
+			b.WriteByte(text[i])
+			i++
+		}
+	}
+
+	return b.String(), spans
+}
+
+// inlineMathEnd は start の $ に対応する閉じの $ の位置を返す
+// 金額（$5 と $10 など）を数式と誤認しないよう、開きの直後と閉じの直前は空白不可、
+// 閉じの直後は数字不可とし、改行をまたぐものは数式としない
+func inlineMathEnd(text string, start int) (int, bool) {
+	if start+1 >= len(text) || isSpace(text[start+1]) {
+		return 0, false
+	}
+	for j := start + 1; j < len(text); j++ {
+		switch text[j] {
+		case '\n':
+			return 0, false
+		case '\\':
+			j++
+		case '$':
+			if j == start+1 || isSpace(text[j-1]) {
+				return 0, false
+			}
+			if j+1 < len(text) && text[j+1] >= '0' && text[j+1] <= '9' {
+				return 0, false
+			}
+			return j, true
+		}
+	}
+	return 0, false
+}
+
+// fencedCodeEnd は start の行がフェンス（``` または ~~~）で始まる場合、閉じるフェンスの行末の位置を返す
+func fencedCodeEnd(text string, start int) (int, bool) {
+	line := strings.TrimLeft(text[start:], " ")
+	var fence string
+	switch {
+	case strings.HasPrefix(line, "```"):
+		fence = "```"
+	case strings.HasPrefix(line, "~~~"):
+		fence = "~~~"
+	default:
+		return 0, false
+	}
+
+	openEnd := strings.IndexByte(text[start:], '\n')
+	if openEnd < 0 {
+		return len(text), true
+	}
+	pos := start + openEnd + 1
+	for pos < len(text) {
+		lineEnd := strings.IndexByte(text[pos:], '\n')
+		next := len(text)
+		if lineEnd >= 0 {
+			next = pos + lineEnd + 1
+		}
+		if strings.HasPrefix(strings.TrimLeft(text[pos:next], " "), fence) {
+			return next, true
+		}
+		pos = next
+	}
+	return len(text), true
+}
+
+// restoreMath はサニタイズ後のHTMLのプレースホルダーを数式の要素に戻す
+// 数式の中身はエスケープするため、サニタイズを経由しなくても安全
+func restoreMath(rendered string, spans []mathSpan) string {
+	for i, span := range spans {
+		placeholder := placeholderOpen + strconv.Itoa(i) + placeholderClose
+		var element string
+		if span.display {
+			element = fmt.Sprintf(`<span class="math math-display">\[%s\]</span>`, html.EscapeString(span.tex))
+		} else {
+			element = fmt.Sprintf(`<span class="math math-inline">\(%s\)</span>`, html.EscapeString(span.tex))
+		}
+		rendered = strings.Replace(rendered, placeholder, element, 1)
+	}
+	return rendered
+}
+
+// countRun は start から続く c の個数を返す
+func countRun(text string, start int, c byte) int {
+	n := 0
+	for start+n < len(text) && text[start+n] == c {
+		n++
+	}
+	return n
+}
+
+// findRun は from 以降で fence とちょうど同じ長さのバッククォートの並びの位置を返す
+func findRun(text string, from int, fence string) int {
+	for i := from; i < len(text); {
+		idx := strings.Index(text[i:], fence)
+		if idx < 0 {
+			return -1
+		}
+		pos := i + idx
+		if countRun(text, pos, '`') == len(fence) {
+			return pos
+		}
+		i = pos + countRun(text, pos, '`')
+	}
+	return -1
+}
+
+// isSpace は空白文字かどうかを返す
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"testing"
+
+	"Shittaka_back/internal/domain/question/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHTML_Markdown(t *testing.T) {
+	html := RenderHTML("**太字** と [リンク](https://example.com)", entities.BodyFormatMarkdown)
+	assert.Contains(t, html, "<strong>太字</strong>")
+	assert.Contains(t, html, `href="https://example.com"`)
+	assert.Contains(t, html, `rel="nofollow noopener"`)
+}
+
+func TestRenderHTML_StripsUnsafeContent(t *testing.T) {
+	html := RenderHTML("<script>alert(1)</script>\n\n[x](javascript:alert(1))\n\n<img src=x onerror=alert(1)>", entities.BodyFormatMarkdown)
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "javascript:")
+	assert.NotContains(t, html, "onerror")
+}
+
+func TestRenderHTML_MathSurvives(t *testing.T) {
+	html := RenderHTML("面積は $a_1 * b_1$ です\n\n$$\n\\frac{a}{b} < c\n$$", entities.BodyFormatMarkdown)
+	assert.Contains(t, html, `<span class="math math-inline">\(a_1 * b_1\)</span>`)
+	assert.Contains(t, html, `<span class="math math-display">\[\frac{a}{b} &lt; c\]</span>`)
+	assert.NotContains(t, html, "<em>")
+
+	html = RenderHTML(`\(x^2\) と \[y\]`, entities.BodyFormatMarkdown)
+	assert.Contains(t, html, `<span class="math math-inline">\(x^2\)</span>`)
+	assert.Contains(t, html, `<span class="math math-display">\[y\]</span>`)
+}
+
+func TestRenderHTML_NotMath(t *testing.T) {
+	// 金額やコード中の $ は数式として扱わない
+	html := RenderHTML("価格は $5 と $10 です", entities.BodyFormatMarkdown)
+	assert.NotContains(t, html, "math")
+
+	html = RenderHTML("`$x$` と\n\n```\n$y$\n```", entities.BodyFormatMarkdown)
+	assert.Contains(t, html, "<code>$x$</code>")
+	assert.Contains(t, html, "$y$")
+	assert.NotContains(t, html, "math")
+
+	html = RenderHTML(`\$x$`, entities.BodyFormatMarkdown)
+	assert.NotContains(t, html, "math")
+}
+
+func TestRenderHTML_Plain(t *testing.T) {
+	html := RenderHTML("<b>太字ではない</b>\n次の行\n\n$x$ の値", entities.BodyFormatPlain)
+	assert.Equal(t, "<p>&lt;b&gt;太字ではない&lt;/b&gt;<br>\n次の行</p>\n<p><span class=\"math math-inline\">\\(x\\)</span> の値</p>", html)
+
+	assert.Equal(t, "", RenderHTML("", entities.BodyFormatPlain))
+}
@@ -0,0 +1,141 @@
+package services
+
+// revision_diff.goは問題リビジョン間の差分を生成するドメインサービスを定義
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Shittaka_back/internal/domain/question/entities"
+)
+
+// BuildRevisionDiff は2つの版の問題の差分を行単位で生成する
+// 変更のあったフィールドごとに "--- フィールド名" の見出しを付け、
+// 削除行は "-"、追加行は "+"、変更のない行は " " を先頭に付ける
+// prev が nil の場合は全ての内容を追加として扱う
+func BuildRevisionDiff(prev, next *entities.Question) string {
+	if prev == nil {
+		prev = &entities.Question{}
+	}
+
+	fields := []struct {
+		name string
+		old  string
+		new  string
+	}{
+		{"title", prev.Title, next.Title},
+		{"body", prev.Body, next.Body},
+		{"explanation", prev.Explanation, next.Explanation},
+		{"answer_key", answerKeyText(prev), answerKeyText(next)},
+	}
+
+	var b strings.Builder
+	for _, f := range fields {
+		if f.old == f.new {
+			continue
+		}
+		b.WriteString("--- " + f.name + "\n")
+		for _, line := range diffLines(splitLines(f.old), splitLines(f.new)) {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// answerKeyHeader は正解の情報の差分の見出し（フィールドの最後に出力する）
+const answerKeyHeader = "--- answer_key\n"
+
+// RedactAnswerKey は差分から正解の情報の変更を取り除く（作成者以外に履歴を返す場合に使う）
+// 正解の情報は最後のフィールドのため、最後の見出しから末尾までを取り除く
+func RedactAnswerKey(diff string) string {
+	if strings.HasPrefix(diff, answerKeyHeader) {
+		return ""
+	}
+	if i := strings.LastIndex(diff, "\n"+answerKeyHeader); i >= 0 {
+		return diff[:i+1]
+	}
+	return diff
+}
+
+// HasContentChanges は2つの版で差分があるかどうかを返す
+// 採点結果が変わるため、正解の情報の変更も差分として扱う
+func HasContentChanges(prev, next *entities.Question) bool {
+	return prev.Title != next.Title || prev.Body != next.Body || prev.Explanation != next.Explanation ||
+		answerKeyText(prev) != answerKeyText(next)
+}
+
+// answerKeyText は問題の種類と正解の情報を差分表示用のテキストにする（未設定の項目は出力しない）
+func answerKeyText(q *entities.Question) string {
+	var lines []string
+	if q.Type != "" {
+		lines = append(lines, "type: "+q.Type)
+	}
+	if q.PartialCredit {
+		lines = append(lines, "partial_credit: true")
+	}
+	for _, accepted := range q.AcceptedAnswers {
+		lines = append(lines, "accepted: "+accepted)
+	}
+	if q.NumericAnswer != nil {
+		lines = append(lines, fmt.Sprintf("numeric: %s ± %s",
+			strconv.FormatFloat(*q.NumericAnswer, 'f', -1, 64),
+			strconv.FormatFloat(q.NumericTolerance, 'f', -1, 64)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitLines は文字列を行に分割する（空文字列は0行）
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines は最長共通部分列（LCS）を使って行単位の差分を求める
+func diffLines(a, b []string) []string {
+	// lcs[i][j] は a[i:] と b[j:] の最長共通部分列の長さ
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var result []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, "-"+a[i])
+			i++
+		default:
+			result = append(result, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		result = append(result, "+"+b[j])
+	}
+
+	return result
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+
+	"Shittaka_back/internal/domain/question/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRevisionDiff(t *testing.T) {
+	prev := &entities.Question{
+		Title:       "日本一高い山は？",
+		Body:        "次のうち\n正しいものを選べ",
+		Explanation: "富士山です",
+	}
+	next := &entities.Question{
+		Title:       "日本一高い山は？",
+		Body:        "次のうち\n最も高いものを選べ",
+		Explanation: "富士山です",
+	}
+
+	diff := BuildRevisionDiff(prev, next)
+	assert.Equal(t, "--- body\n 次のうち\n-正しいものを選べ\n+最も高いものを選べ\n", diff)
+	assert.True(t, HasContentChanges(prev, next))
+	assert.False(t, HasContentChanges(prev, prev))
+}
+
+func TestBuildRevisionDiff_Initial(t *testing.T) {
+	next := &entities.Question{Title: "問題", Explanation: "解説"}
+
+	diff := BuildRevisionDiff(nil, next)
+	assert.Equal(t, "--- title\n+問題\n--- explanation\n+解説\n", diff)
+}
+
+func TestRedactAnswerKey(t *testing.T) {
+	prev := &entities.Question{Title: "日本一高い山は？", Type: entities.TypeFreeText, AcceptedAnswers: []string{"富士山"}}
+	next := &entities.Question{Title: "日本一高い山は？（漢字で）", Type: entities.TypeFreeText, AcceptedAnswers: []string{"富士"}}
+
+	diff := BuildRevisionDiff(prev, next)
+	assert.Contains(t, diff, "accepted: 富士山")
+	assert.Equal(t, "--- title\n-日本一高い山は？\n+日本一高い山は？（漢字で）\n", RedactAnswerKey(diff))
+
+	// 正解の情報のみの変更は差分が空になる
+	assert.Empty(t, RedactAnswerKey(BuildRevisionDiff(prev, &entities.Question{Title: prev.Title, Type: entities.TypeFreeText})))
+	assert.Equal(t, "--- body\n+本文\n", RedactAnswerKey("--- body\n+本文\n"))
+}
@@ -0,0 +1,164 @@
+package services
+
+// similarity.goは問題文の類似度を文字n-gramのMinHashで推定し、重複した問題を見つけるドメインサービスを定義
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"unicode"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/shared"
+)
+
+const (
+	// shingleSize は類似度の計算に使う文字n-gramの長さ
+	shingleSize = 3
+	// minHashSize はMinHash署名のハッシュ関数の数（誤差はおよそ 1/√minHashSize）
+	minHashSize = 128
+)
+
+// MinHashSignature は文章の文字n-gram集合を要約したMinHash署名
+type MinHashSignature [minHashSize]uint64
+
+// SimilarQuestion は類似していると判定された既存の問題と類似度
+type SimilarQuestion struct {
+	Question   *entities.Question
+	Similarity float64
+}
+
+// DuplicateChecker は新しい問題と既存の問題の類似度から重複を判定する
+type DuplicateChecker struct {
+	// WarnThreshold 以上の類似度の問題は警告として返す
+	WarnThreshold float64
+	// RejectThreshold 以上の類似度の問題があれば DUPLICATE_QUESTION エラーにする
+	RejectThreshold float64
+}
+
+// NewDuplicateChecker は新しいDuplicateCheckerを作成
+func NewDuplicateChecker(warnThreshold, rejectThreshold float64) *DuplicateChecker {
+	return &DuplicateChecker{
+		WarnThreshold:   warnThreshold,
+		RejectThreshold: rejectThreshold,
+	}
+}
+
+// Check は候補の問題と既存の問題を比較し、類似度の高い順に警告対象の問題を返す
+// RejectThreshold 以上の問題があれば DUPLICATE_QUESTION のドメインエラーを返す
+func (c *DuplicateChecker) Check(candidate *entities.Question, existing []*entities.Question) ([]SimilarQuestion, error) {
+	similar := FindSimilarQuestions(candidate, existing, c.WarnThreshold)
+	if len(similar) > 0 && similar[0].Similarity >= c.RejectThreshold {
+		return similar, shared.NewDomainError("DUPLICATE_QUESTION",
+			fmt.Sprintf("同じジャンルにほぼ同じ問題があります（問題ID: %d、類似度: %.0f%%）", similar[0].Question.ID, similar[0].Similarity*100))
+	}
+	return similar, nil
+}
+
+// FindSimilarQuestions は候補の問題との類似度が threshold 以上の既存の問題を類似度の高い順に返す
+// 候補と同じIDの問題は比較しない
+func FindSimilarQuestions(candidate *entities.Question, existing []*entities.Question, threshold float64) []SimilarQuestion {
+	signature := QuestionSignature(candidate)
+
+	var similar []SimilarQuestion
+	for _, question := range existing {
+		if candidate.ID != 0 && question.ID == candidate.ID {
+			continue
+		}
+		score := EstimateSimilarity(signature, QuestionSignature(question))
+		if score >= threshold {
+			similar = append(similar, SimilarQuestion{Question: question, Similarity: score})
+		}
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Similarity > similar[j].Similarity
+	})
+	return similar
+}
+
+// QuestionSignature は問題のタイトルと本文からMinHash署名を作成する
+func QuestionSignature(question *entities.Question) MinHashSignature {
+	return NewMinHashSignature(Shingles(question.Title + "\n" + question.Body))
+}
+
+// Shingles は文章を正規化し、文字n-gramのハッシュ値の集合を返す
+// 表記ゆれ（全角・半角、カタカナ・ひらがな、大文字・小文字）と空白・記号の違いは無視する
+func Shingles(text string) map[uint64]struct{} {
+	var runes []rune
+	for _, r := range shared.NormalizeAnswerText(text) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			runes = append(runes, r)
+		}
+	}
+
+	shingles := make(map[uint64]struct{})
+	if len(runes) == 0 {
+		return shingles
+	}
+	if len(runes) < shingleSize {
+		shingles[hashShingle(runes)] = struct{}{}
+		return shingles
+	}
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		shingles[hashShingle(runes[i:i+shingleSize])] = struct{}{}
+	}
+	return shingles
+}
+
+// NewMinHashSignature はn-gram集合からMinHash署名を作成する
+// 空の集合の署名は全ての値が最大値になり、どの署名とも類似度0になる
+func NewMinHashSignature(shingles map[uint64]struct{}) MinHashSignature {
+	var signature MinHashSignature
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for shingle := range shingles {
+		for i := range signature {
+			if h := mix64(shingle ^ minHashSeeds[i]); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+	return signature
+}
+
+// EstimateSimilarity は2つの署名からn-gram集合のJaccard係数を推定する（0〜1）
+func EstimateSimilarity(a, b MinHashSignature) float64 {
+	matches := 0
+	for i := range a {
+		if a[i] == b[i] && a[i] != math.MaxUint64 {
+			matches++
+		}
+	}
+	return float64(matches) / float64(minHashSize)
+}
+
+// minHashSeeds は各ハッシュ関数を区別するための固定のシード
+var minHashSeeds = func() [minHashSize]uint64 {
+	var seeds [minHashSize]uint64
+	state := uint64(0x9E3779B97F4A7C15)
+	for i := range seeds {
+		state += 0x9E3779B97F4A7C15
+		seeds[i] = mix64(state)
+	}
+	return seeds
+}()
+
+// hashShingle はn-gramを64bitのハッシュ値に変換する
+func hashShingle(runes []rune) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(string(runes)))
+	return h.Sum64()
+}
+
+// mix64 は64bitの値をよく混ぜ合わせる（splitmix64 の最終段）
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateSimilarity(t *testing.T) {
+	similarity := func(a, b string) float64 {
+		return EstimateSimilarity(NewMinHashSignature(Shingles(a)), NewMinHashSignature(Shingles(b)))
+	}
+
+	assert.Equal(t, 1.0, similarity("日本で一番高い山はどこ？", "日本で一番高い山はどこ？"))
+	// 全角・半角、カタカナ・ひらがな、記号・空白の違いは無視する
+	assert.Equal(t, 1.0, similarity("ＡＢＣ はどの アルファベット？", "abcはどのあるふぁべっと"))
+	// 言い回しが少し違うだけなら類似度は高い
+	assert.Greater(t, similarity("日本で一番高い山はどこですか？", "日本で一番高い山はどこ？"), 0.7)
+	// 無関係な問題は類似度が低い
+	assert.Less(t, similarity("日本で一番高い山はどこ？", "水の化学式を答えよ"), 0.1)
+	// 空の文章はどれとも一致しない
+	assert.Equal(t, 0.0, similarity("", ""))
+}
+
+func TestDuplicateChecker_Check(t *testing.T) {
+	checker := NewDuplicateChecker(0.5, 0.9)
+	existing := []*entities.Question{
+		{ID: 1, Title: "水の化学式は？", Body: "次から選べ"},
+		{ID: 2, Title: "日本で一番高い山は？", Body: "次のうち正しいものを選べ"},
+		{ID: 3, Title: "日本で一番高い山はどこですか？", Body: "次のうち正しいものを選べ"},
+	}
+
+	t.Run("近い問題は警告として類似度の高い順に返す", func(t *testing.T) {
+		candidate := &entities.Question{Title: "日本で一番高い山はどこ？", Body: "次のうち正しいものを選べ"}
+		similar, err := checker.Check(candidate, existing)
+		require.NoError(t, err)
+		require.Len(t, similar, 2)
+		assert.GreaterOrEqual(t, similar[0].Similarity, similar[1].Similarity)
+		assert.Less(t, similar[0].Similarity, 0.9)
+	})
+
+	t.Run("ほぼ同じ問題はエラーにする", func(t *testing.T) {
+		candidate := &entities.Question{Title: "日本で一番高い山は?", Body: "次のうち、正しいものを選べ。"}
+		similar, err := checker.Check(candidate, existing)
+		require.Error(t, err)
+		assert.Equal(t, "DUPLICATE_QUESTION", err.(shared.DomainError).Code)
+		assert.Equal(t, int64(2), similar[0].Question.ID)
+	})
+
+	t.Run("自分自身とは比較しない", func(t *testing.T) {
+		similar, err := checker.Check(existing[1], existing[1:2])
+		require.NoError(t, err)
+		assert.Empty(t, similar)
+	})
+}
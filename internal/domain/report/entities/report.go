@@ -0,0 +1,114 @@
+package entities
+
+// report.goは問題に対する通報のドメインエンティティを定義
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// 通報理由
+const (
+	ReasonWrongAnswer = "wrong_answer" // 正解の選択肢が誤っている
+	ReasonOffensive   = "offensive"    // 不適切な内容
+	ReasonDuplicate   = "duplicate"    // 重複した問題
+	ReasonTypo        = "typo"         // 誤字・脱字
+)
+
+// 通報の状態
+const (
+	StatusOpen     = "open"     // 未対応
+	StatusAccepted = "accepted" // 通報内容を認めた
+	StatusRejected = "rejected" // 通報内容を却下した
+)
+
+// MaxReportCommentLength は通報コメントの最大文字数
+const MaxReportCommentLength = 500
+
+// Report は問題に対する通報のドメインエンティティ
+type Report struct {
+	ID         int64      `json:"id"`
+	QuestionID int64      `json:"question_id"`
+	UserID     string     `json:"user_id"`
+	Reason     string     `json:"reason"`
+	Comment    string     `json:"comment"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	ResolvedBy string     `json:"resolved_by"`
+}
+
+// NewReport は新しいReportエンティティを作成
+func NewReport(questionID int64, userID, reason, comment string) *Report {
+	return &Report{
+		QuestionID: questionID,
+		UserID:     userID,
+		Reason:     reason,
+		Comment:    comment,
+		Status:     StatusOpen,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// IsValidReason は通報理由が定義済みかどうかを返す
+func IsValidReason(reason string) bool {
+	switch reason {
+	case ReasonWrongAnswer, ReasonOffensive, ReasonDuplicate, ReasonTypo:
+		return true
+	}
+	return false
+}
+
+// IsValidStatus は状態が定義済みかどうかを返す
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusOpen, StatusAccepted, StatusRejected:
+		return true
+	}
+	return false
+}
+
+// Validate はReportエンティティのバリデーションを行う
+func (r *Report) Validate() error {
+	if r.QuestionID == 0 {
+		return shared.NewValidationError("question_id", "question_id is required")
+	}
+	if r.UserID == "" {
+		return shared.NewValidationError("user_id", "user_id is required")
+	}
+	if !IsValidReason(r.Reason) {
+		return shared.NewValidationError("reason", "invalid reason")
+	}
+	if utf8.RuneCountInString(r.Comment) > MaxReportCommentLength {
+		return shared.NewValidationError("comment", "comment is too long")
+	}
+	return nil
+}
+
+// IsOpen は未対応の通報かどうかを返す
+func (r *Report) IsOpen() bool {
+	return r.Status == StatusOpen
+}
+
+// Resolve は通報を対応済みにする（open → accepted / rejected のみ許可）
+func (r *Report) Resolve(status, moderatorID string, now time.Time) error {
+	if status != StatusAccepted && status != StatusRejected {
+		return shared.NewValidationError("status", "status must be accepted or rejected")
+	}
+	if !r.IsOpen() {
+		return shared.NewDomainError("INVALID_TRANSITION", "対応済みの通報は変更できません")
+	}
+
+	r.Status = status
+	r.ResolvedAt = &now
+	r.ResolvedBy = moderatorID
+	return nil
+}
+
+// HidesQuestion は受理された場合に問題を非公開にすべき理由かどうかを返す
+// 誤字の通報は作成者が修正すれば済むため非公開にはしない
+func (r *Report) HidesQuestion() bool {
+	return r.Status == StatusAccepted && r.Reason != ReasonTypo
+}
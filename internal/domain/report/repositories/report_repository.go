@@ -0,0 +1,30 @@
+package repositories
+
+// report_repository.goは通報リポジトリのインターフェースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/report/entities"
+)
+
+// ReportRepository は通報リポジトリのインターフェース
+type ReportRepository interface {
+	// Create は新しい通報を作成する（認証が必要）
+	Create(ctx context.Context, report *entities.Report, userToken string) (*entities.Report, error)
+
+	// GetByID はIDで通報を取得する
+	GetByID(ctx context.Context, id int64) (*entities.Report, error)
+
+	// ListByStatus は状態で絞り込んだ通報を古い順に取得する（モデレーター用）
+	ListByStatus(ctx context.Context, status string, limit, offset int) ([]*entities.Report, error)
+
+	// CountOpenByQuestionID は問題に対する未対応の通報件数を返す
+	CountOpenByQuestionID(ctx context.Context, questionID int64) (int, error)
+
+	// ExistsOpenByUserAndQuestion はユーザーが同じ問題に未対応の通報をしているかを返す
+	ExistsOpenByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error)
+
+	// UpdateStatus は通報の状態を更新する（モデレーター用）
+	UpdateStatus(ctx context.Context, report *entities.Report) error
+}
@@ -0,0 +1,55 @@
+package shared
+
+// normalize.goは自由記述の回答などを比較するための文字列正規化を定義
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeAnswerText は回答の表記ゆれを吸収するために文字列を正規化する
+// - 全角・半角の違い（英数字・記号・半角カナ）を NFKC でそろえる
+// - 英字は小文字にそろえる
+// - カタカナはひらがなにそろえる
+// - 前後の空白を除き、連続する空白を1つにまとめる
+func NormalizeAnswerText(s string) string {
+	s = norm.NFKC.String(s)
+	s = strings.ToLower(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		b.WriteRune(katakanaToHiragana(r))
+	}
+
+	return strings.Join(strings.FieldsFunc(b.String(), unicode.IsSpace), " ")
+}
+
+// katakanaToHiragana はカタカナ1文字をひらがなに変換する（対応するひらがながない文字はそのまま）
+func katakanaToHiragana(r rune) rune {
+	// ァ(U+30A1)〜ヶ(U+30F6) はひらがな ぁ(U+3041)〜ゖ(U+3096) と 0x60 ずれて対応する
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - 0x60
+	}
+	// 繰り返し記号 ヽヾ → ゝゞ
+	if r == 'ヽ' || r == 'ヾ' {
+		return r - 0x60
+	}
+	return r
+}
+
+// NormalizeLabel はジャンル名・タグ名などの表示用の名前を正規化する
+// - 全角・半角の違い（英数字・記号・半角カナ）を NFKC でそろえる
+// - 前後の空白を除き、連続する空白を1つにまとめる
+// 英字の大文字・小文字は入力されたまま残す
+func NormalizeLabel(s string) string {
+	return strings.Join(strings.FieldsFunc(norm.NFKC.String(s), unicode.IsSpace), " ")
+}
+
+// LabelKey はジャンル名・タグ名が同じものかどうかを比較するためのキーを返す
+// NormalizeLabel に加えて英字を小文字にそろえる
+func LabelKey(s string) string {
+	return strings.ToLower(NormalizeLabel(s))
+}
@@ -0,0 +1,35 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAnswerText(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"トウキョウ", "とうきょう"},
+		{"とうきょう", "とうきょう"},
+		{"ﾄｳｷｮｳ", "とうきょう"},
+		{"ｶﾞｯｺｳ", "がっこう"},
+		{"ＡＢＣ１２３", "abc123"},
+		{"  Hello　 World ", "hello world"},
+		{"東京タワー", "東京たわー"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, NormalizeAnswerText(c.input), c.input)
+	}
+}
+
+func TestNormalizeLabel(t *testing.T) {
+	assert.Equal(t, "2024年", NormalizeLabel("２０２４年"))
+	assert.Equal(t, "JavaScript 入門", NormalizeLabel("　ＪａｖａＳｃｒｉｐｔ  入門 "))
+	assert.Equal(t, "カタカナ", NormalizeLabel("ｶﾀｶﾅ"))
+
+	assert.Equal(t, "javascript 入門", LabelKey("ＪａｖａＳｃｒｉｐｔ　入門"))
+	assert.Equal(t, LabelKey("小学生向け"), LabelKey(" 小学生向け "))
+}
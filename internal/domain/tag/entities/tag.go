@@ -0,0 +1,67 @@
+package entities
+
+// tag.goはタグのドメインエンティティを定義
+
+import (
+	"unicode/utf8"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+const (
+	// MaxTagsPerQuestion は1つの問題に付けられるタグの最大数
+	MaxTagsPerQuestion = 10
+	// MaxTagLength はタグ名の最大文字数
+	MaxTagLength = 30
+)
+
+// Tag はジャンルをまたいで問題を分類する自由入力のタグ
+type Tag struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`           // 表示名（全角・半角をそろえたもの）
+	Slug          string `json:"slug"`           // 同じタグかどうかを判定するキー（英字は小文字）
+	QuestionCount int    `json:"question_count"` // タグが付いた問題の数（集計時のみ）
+}
+
+// NewTag は名前を正規化して新しいTagエンティティを作成
+func NewTag(name string) *Tag {
+	name = shared.NormalizeLabel(name)
+	return &Tag{
+		Name: name,
+		Slug: shared.LabelKey(name),
+	}
+}
+
+// NewTags はタグ名の一覧を正規化し、同じタグを1つにまとめて返す
+func NewTags(names []string) ([]*Tag, error) {
+	var tags []*Tag
+	seen := make(map[string]bool)
+	for _, name := range names {
+		tag := NewTag(name)
+		if tag.Name == "" {
+			return nil, shared.NewValidationError("tags", "タグ名は空にできません")
+		}
+		if utf8.RuneCountInString(tag.Name) > MaxTagLength {
+			return nil, shared.NewValidationError("tags", "タグ名は30文字以内で入力してください")
+		}
+		if seen[tag.Slug] {
+			continue
+		}
+		seen[tag.Slug] = true
+		tags = append(tags, tag)
+	}
+
+	if len(tags) > MaxTagsPerQuestion {
+		return nil, shared.NewValidationError("tags", "タグは1つの問題に10個まで付けられます")
+	}
+	return tags, nil
+}
+
+// TagNames はタグの表示名の一覧を返す
+func TagNames(tags []*Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTags(t *testing.T) {
+	tags, err := NewTags([]string{"２０２４年", "小学生向け", " 2024年 ", "JavaScript", "javascript"})
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+	assert.Equal(t, []string{"2024年", "小学生向け", "JavaScript"}, TagNames(tags))
+	assert.Equal(t, "javascript", tags[2].Slug)
+
+	_, err = NewTags([]string{"  "})
+	assert.Error(t, err)
+
+	_, err = NewTags([]string{strings.Repeat("あ", MaxTagLength+1)})
+	assert.Error(t, err)
+
+	many := make([]string, MaxTagsPerQuestion+1)
+	for i := range many {
+		many[i] = strings.Repeat("a", i+1)
+	}
+	_, err = NewTags(many)
+	assert.Error(t, err)
+}
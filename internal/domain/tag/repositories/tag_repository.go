@@ -0,0 +1,24 @@
+package repositories
+
+// tag_repository.goはタグリポジトリのインターフェースを定義
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/tag/entities"
+)
+
+// TagRepository はタグリポジトリのインターフェース
+type TagRepository interface {
+	// EnsureTags は存在しないタグを作成し、IDを埋めたタグを返す（認証が必要）
+	EnsureTags(ctx context.Context, tags []*entities.Tag, userToken string) ([]*entities.Tag, error)
+
+	// SetQuestionTags は問題に付いたタグを指定したタグで置き換える（認証が必要）
+	SetQuestionTags(ctx context.Context, questionID int64, tagIDs []int64, userToken string) error
+
+	// GetByQuestionIDs は問題ごとに付いたタグをまとめて取得する
+	GetByQuestionIDs(ctx context.Context, questionIDs []int64) (map[int64][]*entities.Tag, error)
+
+	// SearchBySlugPrefix はキーが前方一致するタグを問題数付きで取得する（空文字の場合は全てのタグ）
+	SearchBySlugPrefix(ctx context.Context, prefix string) ([]*entities.Tag, error)
+}
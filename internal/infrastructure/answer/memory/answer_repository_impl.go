@@ -0,0 +1,78 @@
+package memory
+
+// answer_repository_impl.goはメモリ上に保持するAnswerRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/answer/entities"
+	"Shittaka_back/internal/domain/answer/repositories"
+)
+
+// AnswerRepositoryImpl はメモリ上に保持するAnswerRepositoryの実装
+type AnswerRepositoryImpl struct {
+	mu      sync.RWMutex
+	nextID  int64
+	answers []*entities.Answer
+}
+
+// NewAnswerRepository は新しいAnswerRepositoryImplを作成
+func NewAnswerRepository() repositories.AnswerRepository {
+	return &AnswerRepositoryImpl{}
+}
+
+// Create は新しい回答を作成
+func (r *AnswerRepositoryImpl) Create(ctx context.Context, answer *entities.Answer, userToken string) (*entities.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	a := cloneAnswer(answer)
+	a.ID = r.nextID
+	if a.AnsweredAt.IsZero() {
+		a.AnsweredAt = time.Now()
+	}
+	r.answers = append(r.answers, a)
+	return cloneAnswer(a), nil
+}
+
+// GetByUserID はユーザーIDで回答一覧を取得
+func (r *AnswerRepositoryImpl) GetByUserID(ctx context.Context, userID string) ([]*entities.Answer, error) {
+	return r.filter(func(a *entities.Answer) bool { return a.UserID == userID }), nil
+}
+
+// GetByQuestionID は問題IDで回答一覧を取得
+func (r *AnswerRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.Answer, error) {
+	return r.filter(func(a *entities.Answer) bool { return a.QuestionID == questionID }), nil
+}
+
+// ExistsByUserAndQuestion はユーザーが問題に回答済みかどうかを判定
+func (r *AnswerRepositoryImpl) ExistsByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error) {
+	answers := r.filter(func(a *entities.Answer) bool { return a.UserID == userID && a.QuestionID == questionID })
+	return len(answers) > 0, nil
+}
+
+// filter は条件に合う回答を作成順に複製して返す
+func (r *AnswerRepositoryImpl) filter(match func(a *entities.Answer) bool) []*entities.Answer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var answers []*entities.Answer
+	for _, a := range r.answers {
+		if match(a) {
+			answers = append(answers, cloneAnswer(a))
+		}
+	}
+	return answers
+}
+
+// cloneAnswer は呼び出し元が書き換えても保持している回答に影響しないよう複製する
+func cloneAnswer(a *entities.Answer) *entities.Answer {
+	c := *a
+	c.ChoiceIDs = slices.Clone(a.ChoiceIDs)
+	return &c
+}
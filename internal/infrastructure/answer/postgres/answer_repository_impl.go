@@ -0,0 +1,98 @@
+package postgres
+
+// answer_repository_impl.goはPostgreSQLを直接使用したAnswerRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/answer/entities"
+	"Shittaka_back/internal/domain/answer/repositories"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// answerColumns は回答を取得する際の列（scanAnswer と同じ順序）
+const answerColumns = `id, user_id, question_id, COALESCE(choice_id, 0), choice_ids, COALESCE(text_answer, ''),
+	numeric_answer, is_correct, COALESCE(score, 0), answered_at, COALESCE(question_revision, 0)`
+
+// AnswerRepositoryImpl はPostgreSQLを使用したAnswerRepositoryの実装
+type AnswerRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewAnswerRepository は新しいAnswerRepositoryImplを作成
+func NewAnswerRepository(pool *pgxpool.Pool) repositories.AnswerRepository {
+	return &AnswerRepositoryImpl{pool: pool}
+}
+
+// Create は新しい回答を作成
+func (r *AnswerRepositoryImpl) Create(ctx context.Context, answer *entities.Answer, userToken string) (*entities.Answer, error) {
+	// 選択肢を選ばない回答は choice_id を NULL にする
+	var choiceID *int64
+	if answer.ChoiceID != 0 {
+		choiceID = &answer.ChoiceID
+	}
+
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO answers (user_id, question_id, choice_id, choice_ids, text_answer, numeric_answer,
+			is_correct, score, question_revision)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+answerColumns,
+		answer.UserID, answer.QuestionID, choiceID, answer.ChoiceIDs, answer.TextAnswer, answer.NumericAnswer,
+		answer.IsCorrect, answer.Score, answer.QuestionRevision,
+	)
+	created, err := scanAnswer(row)
+	if err != nil {
+		return nil, database.MapError(err)
+	}
+	return created, nil
+}
+
+// GetByUserID はユーザーIDで回答一覧を取得
+func (r *AnswerRepositoryImpl) GetByUserID(ctx context.Context, userID string) ([]*entities.Answer, error) {
+	return r.query(ctx, `SELECT `+answerColumns+` FROM answers WHERE user_id = $1`, userID)
+}
+
+// GetByQuestionID は問題IDで回答一覧を取得
+func (r *AnswerRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.Answer, error) {
+	return r.query(ctx, `SELECT `+answerColumns+` FROM answers WHERE question_id = $1`, questionID)
+}
+
+// ExistsByUserAndQuestion はユーザーが問題に回答済みかどうかを判定
+func (r *AnswerRepositoryImpl) ExistsByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM answers WHERE user_id = $1 AND question_id = $2)`, userID, questionID).Scan(&exists)
+	return exists, err
+}
+
+// query は回答を検索する共通処理
+func (r *AnswerRepositoryImpl) query(ctx context.Context, sql string, args ...any) ([]*entities.Answer, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []*entities.Answer
+	for rows.Next() {
+		answer, err := scanAnswer(rows)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, answer)
+	}
+	return answers, rows.Err()
+}
+
+// scanAnswer は answerColumns の順に読み込んだ行を Answer エンティティに変換
+func scanAnswer(row pgx.Row) (*entities.Answer, error) {
+	a := &entities.Answer{}
+	err := row.Scan(&a.ID, &a.UserID, &a.QuestionID, &a.ChoiceID, &a.ChoiceIDs, &a.TextAnswer,
+		&a.NumericAnswer, &a.IsCorrect, &a.Score, &a.AnsweredAt, &a.QuestionRevision)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -1,169 +1,112 @@
 package supabase
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
 	"strconv"
 	"time"
 
 	"Shittaka_back/internal/domain/answer/entities"
 	"Shittaka_back/internal/domain/answer/repositories"
+	"Shittaka_back/internal/infrastructure/postgrest"
 )
 
 // AnswerRepositoryImpl はSupabaseを使用したAnswerRepositoryの実装
-type AnswerRepositoryImpl struct{}
+// 回答は本人と問題の作成者にしか読めないため、読み取りはサービスロールキーで行い、誰に返すかはユースケースで判定する
+type AnswerRepositoryImpl struct {
+	client *postgrest.Client
+}
 
 // NewAnswerRepository は新しいAnswerRepositoryImplを作成
-func NewAnswerRepository() repositories.AnswerRepository {
-	return &AnswerRepositoryImpl{}
+func NewAnswerRepository(client *postgrest.Client) repositories.AnswerRepository {
+	return &AnswerRepositoryImpl{client: client}
 }
 
 // Create は新しい回答を作成（RLS適用のためユーザートークンを使用）
 func (r *AnswerRepositoryImpl) Create(ctx context.Context, answer *entities.Answer, userToken string) (*entities.Answer, error) {
 	answerData := map[string]interface{}{
-		"user_id":     answer.UserID,
-		"question_id": answer.QuestionID,
-		"choice_id":   answer.ChoiceID,
-	}
-
-	jsonData, err := json.Marshal(answerData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal answer data: %w", err)
-	}
-
-	url := os.Getenv("SUPABASE_URL") + "/rest/v1/answers"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
-	req.Header.Set("Prefer", "return=representation")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("create answer failed with status %d: %s", resp.StatusCode, string(body))
+		"user_id":           answer.UserID,
+		"question_id":       answer.QuestionID,
+		"choice_id":         nullableInt64(answer.ChoiceID),
+		"choice_ids":        answer.ChoiceIDs,
+		"text_answer":       answer.TextAnswer,
+		"numeric_answer":    answer.NumericAnswer,
+		"is_correct":        answer.IsCorrect,
+		"score":             answer.Score,
+		"question_revision": answer.QuestionRevision,
 	}
 
 	var answerList []map[string]interface{}
-	if err := json.Unmarshal(body, &answerList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := r.client.From("answers").WithToken(userToken).Insert(ctx, answerData, &answerList); err != nil {
+		return nil, err
 	}
 
 	if len(answerList) == 0 {
 		return nil, fmt.Errorf("no answer returned from create operation")
 	}
 
-	answerResp := answerList[0]
-	return mapToAnswer(answerResp), nil
+	return mapToAnswer(answerList[0]), nil
 }
 
 // GetByUserID はユーザーIDで回答一覧を取得
 func (r *AnswerRepositoryImpl) GetByUserID(ctx context.Context, userID string) ([]*entities.Answer, error) {
-	url := fmt.Sprintf("%s/rest/v1/answers?user_id=eq.%s", os.Getenv("SUPABASE_URL"), userID)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find answers by user failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var answerList []map[string]interface{}
-	if err := json.Unmarshal(body, &answerList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := r.client.From("answers").AsServiceRole().Eq("user_id", userID).Find(ctx, &answerList); err != nil {
+		return nil, err
 	}
 
-	answers := make([]*entities.Answer, len(answerList))
-	for i, answerData := range answerList {
-		answers[i] = mapToAnswer(answerData)
-	}
-
-	return answers, nil
+	return mapToAnswers(answerList), nil
 }
 
 // GetByQuestionID は問題IDで回答一覧を取得
 func (r *AnswerRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.Answer, error) {
-	url := fmt.Sprintf("%s/rest/v1/answers?question_id=eq.%d", os.Getenv("SUPABASE_URL"), questionID)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	var answerList []map[string]interface{}
+	if err := r.client.From("answers").AsServiceRole().Eq("question_id", questionID).Find(ctx, &answerList); err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
+	return mapToAnswers(answerList), nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// ExistsByUserAndQuestion はユーザーが問題に回答済みかどうかを判定
+func (r *AnswerRepositoryImpl) ExistsByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error) {
+	var answerList []map[string]interface{}
+	err := r.client.From("answers").AsServiceRole().
+		Select("id").
+		Eq("user_id", userID).
+		Eq("question_id", questionID).
+		Limit(1).
+		Find(ctx, &answerList)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return false, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find answers by question failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var answerList []map[string]interface{}
-	if err := json.Unmarshal(body, &answerList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+	return len(answerList) > 0, nil
+}
 
+// mapToAnswers は取得した行の一覧を Answer エンティティの一覧に変換
+func mapToAnswers(answerList []map[string]interface{}) []*entities.Answer {
 	answers := make([]*entities.Answer, len(answerList))
 	for i, answerData := range answerList {
 		answers[i] = mapToAnswer(answerData)
 	}
-
-	return answers, nil
+	return answers
 }
 
 // mapToAnswer は map[string]interface{} を Answer エンティティに変換
 func mapToAnswer(m map[string]interface{}) *entities.Answer {
 	return &entities.Answer{
-		ID:         getInt64(m, "id"),
-		UserID:     getString(m, "user_id"),
-		QuestionID: getInt64(m, "question_id"),
-		ChoiceID:   getInt64(m, "choice_id"),
-		AnsweredAt: getTime(m, "answered_at"),
+		ID:               getInt64(m, "id"),
+		UserID:           getString(m, "user_id"),
+		QuestionID:       getInt64(m, "question_id"),
+		ChoiceID:         getInt64(m, "choice_id"),
+		ChoiceIDs:        getInt64Slice(m, "choice_ids"),
+		TextAnswer:       getString(m, "text_answer"),
+		NumericAnswer:    getOptionalFloat64(m, "numeric_answer"),
+		IsCorrect:        getBool(m, "is_correct"),
+		Score:            getFloat64(m, "score"),
+		AnsweredAt:       getTime(m, "answered_at"),
+		QuestionRevision: int(getInt64(m, "question_revision")),
 	}
 }
 
@@ -198,6 +141,64 @@ func getInt64(m map[string]interface{}, key string) int64 {
 	return 0
 }
 
+// getInt64Slice は map から []int64 を安全に取得
+func getInt64Slice(m map[string]interface{}, key string) []int64 {
+	values, ok := m[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]int64, 0, len(values))
+	for _, v := range values {
+		if f, ok := v.(float64); ok {
+			result = append(result, int64(f))
+		}
+	}
+	return result
+}
+
+// getFloat64 は map から float64 を安全に取得
+func getFloat64(m map[string]interface{}, key string) float64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return v
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+// getOptionalFloat64 は map から NULL 許容の float64 を取得
+func getOptionalFloat64(m map[string]interface{}, key string) *float64 {
+	if val, ok := m[key]; !ok || val == nil {
+		return nil
+	}
+	f := getFloat64(m, key)
+	return &f
+}
+
+// getBool は map から bool を安全に取得
+func getBool(m map[string]interface{}, key string) bool {
+	if val, ok := m[key]; ok {
+		if b, ok := val.(bool); ok {
+			return b
+		}
+	}
+	return false
+}
+
+// nullableInt64 は 0 を NULL として書き込むための値に変換
+func nullableInt64(v int64) interface{} {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
+
 // getTime は map から time.Time を安全に取得
 func getTime(m map[string]interface{}, key string) time.Time {
 	if val, ok := m[key]; ok {
@@ -208,4 +209,4 @@ func getTime(m map[string]interface{}, key string) time.Time {
 		}
 	}
 	return time.Time{}
-}
\ No newline at end of file
+}
@@ -0,0 +1,67 @@
+package local
+
+// local_storage.goはローカルファイルシステムを使用したFileStorageの実装
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"Shittaka_back/internal/domain/attachment/repositories"
+)
+
+// Storage はローカルファイルシステムを使用したFileStorageの実装
+// 開発環境向けで、dir 以下に保存したファイルを baseURL 以下のURLで配信する前提
+type Storage struct {
+	dir     string
+	baseURL string
+}
+
+// NewStorage は新しいStorageを作成
+func NewStorage(dir, baseURL string) repositories.FileStorage {
+	return &Storage{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Put はファイルを dir/key に保存し、公開URLを返す
+func (s *Storage) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Delete は dir/key のファイルを削除
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// pathFor は key を保存先のパスに変換する（dir の外を指す key は拒否する）
+func (s *Storage) pathFor(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if cleaned == "." || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid storage key: %q", key)
+	}
+	return filepath.Join(s.dir, cleaned), nil
+}
@@ -0,0 +1,41 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_PutAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	storage := NewStorage(dir, "/uploads/")
+	ctx := context.Background()
+
+	url, err := storage.Put(ctx, "questions/1/abc.png", "image/png", []byte("data"))
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/questions/1/abc.png", url)
+
+	data, err := os.ReadFile(filepath.Join(dir, "questions", "1", "abc.png"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	require.NoError(t, storage.Delete(ctx, "questions/1/abc.png"))
+	_, err = os.Stat(filepath.Join(dir, "questions", "1", "abc.png"))
+	assert.True(t, os.IsNotExist(err))
+
+	// 存在しないファイルの削除はエラーにしない
+	assert.NoError(t, storage.Delete(ctx, "questions/1/abc.png"))
+}
+
+func TestStorage_RejectsKeysOutsideDir(t *testing.T) {
+	storage := NewStorage(t.TempDir(), "/uploads")
+
+	_, err := storage.Put(context.Background(), "../escape.png", "image/png", []byte("data"))
+	assert.Error(t, err)
+	_, err = storage.Put(context.Background(), "/etc/passwd", "image/png", []byte("data"))
+	assert.Error(t, err)
+}
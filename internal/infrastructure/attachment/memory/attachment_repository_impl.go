@@ -0,0 +1,86 @@
+package memory
+
+// attachment_repository_impl.goはメモリ上に保持するAttachmentRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+// 画像ファイル自体はストレージ（FileStorage）に保存する
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/attachment/entities"
+	"Shittaka_back/internal/domain/attachment/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// AttachmentRepositoryImpl はメモリ上に保持するAttachmentRepositoryの実装
+type AttachmentRepositoryImpl struct {
+	mu          sync.RWMutex
+	nextID      int64
+	attachments map[int64]*entities.Attachment
+}
+
+// NewAttachmentRepository は新しいAttachmentRepositoryImplを作成
+func NewAttachmentRepository() repositories.AttachmentRepository {
+	return &AttachmentRepositoryImpl{attachments: make(map[int64]*entities.Attachment)}
+}
+
+// Create は添付画像の情報を保存
+func (r *AttachmentRepositoryImpl) Create(ctx context.Context, attachment *entities.Attachment, userToken string) (*entities.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	a := *attachment
+	a.ID = r.nextID
+	a.CreatedAt = time.Now()
+	r.attachments[a.ID] = &a
+
+	created := a
+	return &created, nil
+}
+
+// GetByID はIDで添付画像を取得
+func (r *AttachmentRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.attachments[id]
+	if !ok {
+		return nil, shared.NewDomainError("NOT_FOUND", "添付画像が見つかりません")
+	}
+	found := *a
+	return &found, nil
+}
+
+// GetByQuestionIDs は問題（とその選択肢）に添付された画像をID順にまとめて取得
+func (r *AttachmentRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]*entities.Attachment, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var attachments []*entities.Attachment
+	for _, a := range r.attachments {
+		if slices.Contains(questionIDs, a.QuestionID) {
+			found := *a
+			attachments = append(attachments, &found)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].ID < attachments[j].ID })
+	return attachments, nil
+}
+
+// Delete は添付画像の情報を削除
+func (r *AttachmentRepositoryImpl) Delete(ctx context.Context, id int64, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attachments, id)
+	return nil
+}
@@ -0,0 +1,97 @@
+package postgres
+
+// attachment_repository_impl.goはPostgreSQLを直接使用したAttachmentRepositoryの実装
+// 画像ファイル自体はストレージ（STORAGE_DRIVER）に保存し、ここでは情報のみを扱う
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/attachment/entities"
+	"Shittaka_back/internal/domain/attachment/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// attachmentColumns は添付画像を取得する際の列（scanAttachment と同じ順序）
+const attachmentColumns = `id, question_id, choice_id, user_id, storage_key, url, content_type, size, width, height, created_at`
+
+// AttachmentRepositoryImpl はPostgreSQLを使用したAttachmentRepositoryの実装
+type AttachmentRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewAttachmentRepository は新しいAttachmentRepositoryImplを作成
+func NewAttachmentRepository(pool *pgxpool.Pool) repositories.AttachmentRepository {
+	return &AttachmentRepositoryImpl{pool: pool}
+}
+
+// Create は添付画像の情報を保存
+func (r *AttachmentRepositoryImpl) Create(ctx context.Context, attachment *entities.Attachment, userToken string) (*entities.Attachment, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO attachments (question_id, choice_id, user_id, storage_key, url, content_type, size, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+attachmentColumns,
+		attachment.QuestionID, attachment.ChoiceID, attachment.UserID, attachment.StorageKey, attachment.URL,
+		attachment.ContentType, attachment.Size, attachment.Width, attachment.Height,
+	)
+	created, err := scanAttachment(row)
+	if err != nil {
+		return nil, database.MapError(err)
+	}
+	return created, nil
+}
+
+// GetByID はIDで添付画像を取得
+func (r *AttachmentRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Attachment, error) {
+	attachment, err := scanAttachment(r.pool.QueryRow(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`, id))
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "添付画像が見つかりません")
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetByQuestionIDs は問題（とその選択肢）に添付された画像をまとめて取得
+func (r *AttachmentRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]*entities.Attachment, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE question_id = ANY($1) ORDER BY id ASC`, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*entities.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+// Delete は添付画像の情報を削除
+func (r *AttachmentRepositoryImpl) Delete(ctx context.Context, id int64, userToken string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM attachments WHERE id = $1`, id)
+	return database.MapError(err)
+}
+
+// scanAttachment は attachmentColumns の順に読み込んだ行を Attachment エンティティに変換
+func scanAttachment(row pgx.Row) (*entities.Attachment, error) {
+	a := &entities.Attachment{}
+	err := row.Scan(&a.ID, &a.QuestionID, &a.ChoiceID, &a.UserID, &a.StorageKey, &a.URL,
+		&a.ContentType, &a.Size, &a.Width, &a.Height, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,161 @@
+package supabase
+
+// attachment_repository_impl.goはSupabaseを使用したAttachmentRepositoryの実装
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"Shittaka_back/internal/domain/attachment/entities"
+	"Shittaka_back/internal/domain/attachment/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// AttachmentRepositoryImpl はSupabaseを使用したAttachmentRepositoryの実装
+type AttachmentRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewAttachmentRepository は新しいAttachmentRepositoryImplを作成
+func NewAttachmentRepository(client *postgrest.Client) repositories.AttachmentRepository {
+	return &AttachmentRepositoryImpl{client: client}
+}
+
+// Create は新しい添付画像を登録（RLS適用のためユーザートークンを使用）
+func (r *AttachmentRepositoryImpl) Create(ctx context.Context, attachment *entities.Attachment, userToken string) (*entities.Attachment, error) {
+	attachmentData := map[string]interface{}{
+		"question_id":  attachment.QuestionID,
+		"choice_id":    attachment.ChoiceID,
+		"user_id":      attachment.UserID,
+		"storage_key":  attachment.StorageKey,
+		"url":          attachment.URL,
+		"content_type": attachment.ContentType,
+		"size":         attachment.Size,
+		"width":        attachment.Width,
+		"height":       attachment.Height,
+	}
+
+	var attachmentList []map[string]interface{}
+	if err := r.client.From("attachments").WithToken(userToken).Insert(ctx, attachmentData, &attachmentList); err != nil {
+		return nil, err
+	}
+
+	if len(attachmentList) == 0 {
+		return nil, fmt.Errorf("no attachment returned from create operation")
+	}
+
+	return mapToAttachment(attachmentList[0]), nil
+}
+
+// GetByID はIDで添付画像を取得
+func (r *AttachmentRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Attachment, error) {
+	var attachmentData map[string]interface{}
+	if err := r.client.From("attachments").AsServiceRole().Eq("id", id).Single(ctx, &attachmentData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "添付画像が見つかりません")
+		}
+		return nil, err
+	}
+
+	return mapToAttachment(attachmentData), nil
+}
+
+// GetByQuestionIDs は問題（とその選択肢）に添付された画像をまとめて取得
+func (r *AttachmentRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]*entities.Attachment, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	var attachmentList []map[string]interface{}
+	err := r.client.From("attachments").AsServiceRole().
+		In("question_id", postgrest.Int64s(questionIDs)...).
+		Order("id", true).
+		Find(ctx, &attachmentList)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*entities.Attachment, len(attachmentList))
+	for i, attachmentData := range attachmentList {
+		attachments[i] = mapToAttachment(attachmentData)
+	}
+
+	return attachments, nil
+}
+
+// Delete は添付画像を削除（RLS適用のためユーザートークンを使用）
+func (r *AttachmentRepositoryImpl) Delete(ctx context.Context, id int64, userToken string) error {
+	return r.client.From("attachments").WithToken(userToken).Eq("id", id).Delete(ctx)
+}
+
+// mapToAttachment は map[string]interface{} を Attachment エンティティに変換
+func mapToAttachment(m map[string]interface{}) *entities.Attachment {
+	return &entities.Attachment{
+		ID:          getInt64(m, "id"),
+		QuestionID:  getInt64(m, "question_id"),
+		ChoiceID:    getOptionalInt64(m, "choice_id"),
+		UserID:      getString(m, "user_id"),
+		StorageKey:  getString(m, "storage_key"),
+		URL:         getString(m, "url"),
+		ContentType: getString(m, "content_type"),
+		Size:        getInt64(m, "size"),
+		Width:       int(getInt64(m, "width")),
+		Height:      int(getInt64(m, "height")),
+		CreatedAt:   getTime(m, "created_at"),
+	}
+}
+
+// ヘルパー関数
+
+// getString は map から文字列を安全に取得
+func getString(m map[string]interface{}, key string) string {
+	if val, ok := m[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// getInt64 は map から int64 を安全に取得
+func getInt64(m map[string]interface{}, key string) int64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// getOptionalInt64 は map から NULL 許容の int64 を取得
+func getOptionalInt64(m map[string]interface{}, key string) *int64 {
+	if val, ok := m[key]; !ok || val == nil {
+		return nil
+	}
+	v := getInt64(m, key)
+	return &v
+}
+
+// getTime は map から time.Time を安全に取得
+func getTime(m map[string]interface{}, key string) time.Time {
+	if val, ok := m[key]; ok {
+		if timeStr, ok := val.(string); ok {
+			if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
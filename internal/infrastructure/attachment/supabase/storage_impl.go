@@ -0,0 +1,96 @@
+package supabase
+
+// storage_impl.goはSupabase Storageを使用したFileStorageの実装
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"Shittaka_back/internal/domain/attachment/repositories"
+	"Shittaka_back/internal/infrastructure/config"
+)
+
+// StorageImpl はSupabase Storageを使用したFileStorageの実装
+// バケットは公開設定（public）で作成しておく必要がある
+type StorageImpl struct {
+	bucket     string
+	baseURL    string
+	serviceKey string
+	httpClient *http.Client
+}
+
+// NewStorage は新しいStorageImplを作成
+func NewStorage(cfg *config.Config, httpClient *http.Client) repositories.FileStorage {
+	return &StorageImpl{
+		bucket:     cfg.StorageBucket,
+		baseURL:    strings.TrimRight(cfg.SupabaseURL, "/"),
+		serviceKey: cfg.SupabaseServiceKey,
+		httpClient: httpClient,
+	}
+}
+
+// Put はファイルをバケットにアップロードし、公開URLを返す
+func (s *StorageImpl) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	apiURL := fmt.Sprintf("%s/storage/v1/object/%s/%s", s.baseURL, s.bucket, key)
+	status, body, err := s.doRequest(ctx, "POST", apiURL, contentType, data)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK && status != http.StatusCreated {
+		return "", fmt.Errorf("upload object failed with status %d: %s", status, string(body))
+	}
+
+	return fmt.Sprintf("%s/storage/v1/object/public/%s/%s", s.baseURL, s.bucket, key), nil
+}
+
+// Delete はバケットからファイルを削除
+func (s *StorageImpl) Delete(ctx context.Context, key string) error {
+	apiURL := fmt.Sprintf("%s/storage/v1/object/%s/%s", s.baseURL, s.bucket, key)
+	status, body, err := s.doRequest(ctx, "DELETE", apiURL, "", nil)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return fmt.Errorf("delete object failed with status %d: %s", status, string(body))
+	}
+
+	return nil
+}
+
+// doRequest はサービスロールキーでStorage APIへリクエストを送り、ステータスコードとボディを返す
+func (s *StorageImpl) doRequest(ctx context.Context, method, apiURL, contentType string, data []byte) (int, []byte, error) {
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("apikey", s.serviceKey)
+	req.Header.Set("Authorization", "Bearer "+s.serviceKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return resp.StatusCode, body, nil
+}
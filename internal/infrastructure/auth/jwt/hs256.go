@@ -0,0 +1,107 @@
+package jwt
+
+// hs256.goはHS256で署名したアクセストークン（JWT）の発行・検証を定義
+// Supabase Auth のJWTシークレットで署名されたトークンと、メモリ上の認証で発行するトークンの両方で使う
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// Claims はトークンに含める情報（Supabase Auth と同じクレーム名）
+type Claims struct {
+	Sub   string `json:"sub"`
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role,omitempty"`
+	Iat   int64  `json:"iat,omitempty"`
+	Exp   int64  `json:"exp"`
+}
+
+// header はトークンのヘッダー
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Sign はクレームを鍵で署名したトークンを返す
+func Sign(key []byte, claims Claims) string {
+	head, _ := json.Marshal(header{Alg: "HS256", Typ: "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := encodeSegment(head) + "." + encodeSegment(payload)
+	return signingInput + "." + encodeSegment(sign(key, signingInput))
+}
+
+// Verify はトークンの署名（HS256のみ）と有効期限を確認し、クレームを返す
+// 検証に失敗した場合は理由によらず UNAUTHORIZED を返す
+func Verify(key []byte, token string, now time.Time) (*Claims, error) {
+	unauthorized := shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, unauthorized
+	}
+
+	// 署名方式を差し替えたトークンを受け付けないよう、ヘッダーの alg も確認する
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil || head.Alg != "HS256" {
+		return nil, unauthorized
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(key, parts[0]+"."+parts[1])) {
+		return nil, unauthorized
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil || claims.Sub == "" || claims.Exp <= now.Unix() {
+		return nil, unauthorized
+	}
+
+	return &claims, nil
+}
+
+// Verifier は同じ鍵で署名されたトークンを検証する
+type Verifier struct {
+	key []byte
+}
+
+// NewVerifier は新しいVerifierを作成
+func NewVerifier(key []byte) *Verifier {
+	return &Verifier{key: key}
+}
+
+// Verify はトークンの署名と有効期限を確認し、ユーザーIDを返す
+func (v *Verifier) Verify(token string, now time.Time) (string, error) {
+	claims, err := Verify(v.key, token, now)
+	if err != nil {
+		return "", err
+	}
+	return claims.Sub, nil
+}
+
+// sign は署名対象の文字列のHMAC-SHA256を返す
+func sign(key []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+// encodeSegment はJWTの各部分をBase64URL（パディングなし）で符号化
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeSegment はBase64URLで符号化されたJWTの部分をJSONとして読み込む
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
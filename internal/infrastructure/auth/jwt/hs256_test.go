@@ -0,0 +1,43 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify_AcceptsTokenSignedWithKey(t *testing.T) {
+	now := time.Unix(1714521600, 0)
+	token := Sign([]byte("secret"), Claims{Sub: "user-1", Exp: now.Add(time.Hour).Unix()})
+
+	userID, err := NewVerifier([]byte("secret")).Verify(token, now)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+}
+
+func TestVerify_RejectsForgedOrExpiredTokens(t *testing.T) {
+	now := time.Unix(1714521600, 0)
+	key := []byte("secret")
+	valid := Sign(key, Claims{Sub: "user-1", Exp: now.Add(time.Hour).Unix()})
+	parts := strings.Split(valid, ".")
+
+	// ペイロードだけ差し替えたトークン（署名を検証しないと別のユーザーになりすませる）
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":9999999999}`))
+
+	tokens := map[string]string{
+		"other key":      Sign([]byte("other"), Claims{Sub: "user-1", Exp: now.Add(time.Hour).Unix()}),
+		"expired":        Sign(key, Claims{Sub: "user-1", Exp: now.Unix()}),
+		"missing sub":    Sign(key, Claims{Exp: now.Add(time.Hour).Unix()}),
+		"forged payload": parts[0] + "." + forgedPayload + "." + parts[2],
+		"alg none":       base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + ".",
+		"malformed":      "not-a-jwt",
+	}
+	for name, token := range tokens {
+		_, err := Verify(key, token, now)
+		assert.Error(t, err, name)
+	}
+}
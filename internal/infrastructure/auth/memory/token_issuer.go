@@ -0,0 +1,50 @@
+package memory
+
+// token_issuer.goはメモリ上の認証で使うアクセストークンの発行を定義
+// Supabase Auth と同じJWT形式で発行し、ハンドラーは署名を検証してから sub を使う
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"Shittaka_back/internal/domain/auth/entities"
+	"Shittaka_back/internal/infrastructure/auth/jwt"
+)
+
+// TokenIssuer はHS256で署名したJWTを発行・検証する
+// 署名鍵はプロセスごとに生成するため、再起動すると以前のトークンは無効になる
+type TokenIssuer struct {
+	key []byte
+	ttl time.Duration
+}
+
+// NewTokenIssuer は新しいTokenIssuerを作成
+func NewTokenIssuer(ttl time.Duration) *TokenIssuer {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate token key: %v", err))
+	}
+	return &TokenIssuer{key: key, ttl: ttl}
+}
+
+// Issue はユーザーのアクセストークンと有効期限（Unix時刻）を返す
+func (i *TokenIssuer) Issue(user *entities.User, now time.Time) (string, int64) {
+	claims := jwt.Claims{
+		Sub:   user.ID,
+		Email: user.Email,
+		Role:  "authenticated",
+		Iat:   now.Unix(),
+		Exp:   now.Add(i.ttl).Unix(),
+	}
+	return jwt.Sign(i.key, claims), claims.Exp
+}
+
+// Verify はトークンの署名と有効期限を確認し、ユーザーIDを返す
+func (i *TokenIssuer) Verify(token string, now time.Time) (string, error) {
+	claims, err := jwt.Verify(i.key, token, now)
+	if err != nil {
+		return "", err
+	}
+	return claims.Sub, nil
+}
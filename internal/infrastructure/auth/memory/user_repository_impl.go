@@ -0,0 +1,153 @@
+package memory
+
+// user_repository_impl.goはメモリ上に保持するUserRepositoryの実装
+// Supabase Auth に接続せずにユーザー登録・ログインを行う（開発・テスト用）
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"Shittaka_back/internal/domain/auth/entities"
+	"Shittaka_back/internal/domain/auth/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// storedUser は保持しているユーザーとパスワードのハッシュ
+type storedUser struct {
+	user         entities.User
+	passwordHash [32]byte
+}
+
+// UserRepositoryImpl はメモリ上に保持するUserRepositoryの実装
+type UserRepositoryImpl struct {
+	mu     sync.RWMutex
+	users  map[string]*storedUser // ユーザーIDごと
+	tokens *TokenIssuer
+}
+
+// NewUserRepository は新しいUserRepositoryImplを作成
+func NewUserRepository(tokens *TokenIssuer) *UserRepositoryImpl {
+	return &UserRepositoryImpl{
+		users:  make(map[string]*storedUser),
+		tokens: tokens,
+	}
+}
+
+// Create は新しいユーザーを作成（同じメールアドレスのユーザーは作成できない）
+func (r *UserRepositoryImpl) Create(ctx context.Context, email, password string, metadata map[string]interface{}) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findByEmail(email) != nil {
+		return nil, shared.NewDomainError("USER_EXISTS", "user with this email already exists")
+	}
+
+	username := ""
+	if u, ok := metadata["username"].(string); ok {
+		username = u
+	}
+
+	user := entities.NewUser(uuid.NewString(), email, username)
+	r.users[user.ID] = &storedUser{user: *user, passwordHash: sha256.Sum256([]byte(password))}
+
+	created := *user
+	return &created, nil
+}
+
+// Authenticate はメールアドレスとパスワードを確認し、トークンを発行する
+func (r *UserRepositoryImpl) Authenticate(ctx context.Context, email, password string) (*repositories.AuthResult, error) {
+	r.mu.RLock()
+	var found *storedUser
+	if stored := r.findByEmail(email); stored != nil {
+		copied := *stored
+		found = &copied
+	}
+	r.mu.RUnlock()
+
+	hash := sha256.Sum256([]byte(password))
+	if found == nil || subtle.ConstantTimeCompare(hash[:], found.passwordHash[:]) != 1 {
+		return nil, shared.NewDomainError("AUTH_FAILED", "invalid credentials")
+	}
+
+	user := found.user
+	accessToken, expiresAt := r.tokens.Issue(&user, time.Now())
+	return &repositories.AuthResult{
+		User:         &user,
+		AccessToken:  accessToken,
+		RefreshToken: uuid.NewString(),
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// FindByID はIDでユーザーを検索
+func (r *UserRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.users[id]
+	if !ok {
+		return nil, shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+	user := stored.user
+	return &user, nil
+}
+
+// FindByEmail はEmailでユーザーを検索
+func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.findByEmail(email)
+	if stored == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+	user := stored.user
+	return &user, nil
+}
+
+// Update はユーザー情報（ユーザー名・アバター）を更新
+func (r *UserRepositoryImpl) Update(ctx context.Context, user *entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[user.ID]
+	if !ok {
+		return shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+	stored.user.Username = user.Username
+	stored.user.AvatarURL = user.AvatarURL
+	stored.user.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delete はユーザーを削除
+func (r *UserRepositoryImpl) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, id)
+	return nil
+}
+
+// Logout はトークンが発行したものか確認する
+// 発行済みのトークンは有効期限まで使えるため、クライアント側で破棄する
+func (r *UserRepositoryImpl) Logout(ctx context.Context, token string) error {
+	_, err := r.tokens.Verify(token, time.Now())
+	return err
+}
+
+// findByEmail はメールアドレスでユーザーを探す（大文字・小文字は区別しない、呼び出し元でロックを取得する）
+func (r *UserRepositoryImpl) findByEmail(email string) *storedUser {
+	for _, stored := range r.users {
+		if strings.EqualFold(stored.user.Email, email) {
+			return stored
+		}
+	}
+	return nil
+}
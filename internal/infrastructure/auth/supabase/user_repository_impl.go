@@ -9,34 +9,42 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"net/url"
 	"strings"
 	"time"
 
 	"Shittaka_back/internal/domain/auth/entities"
 	"Shittaka_back/internal/domain/auth/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/config"
 
 	"github.com/supabase-community/gotrue-go"
 )
 
 // UserRepositoryImpl はSupabaseを使用したUserRepositoryの実装
 type UserRepositoryImpl struct {
-	client gotrue.Client
+	client     gotrue.Client
+	baseURL    string
+	serviceKey string
+	httpClient *http.Client
 }
 
 // NewUserRepository は新しいUserRepositoryImplを作成
-func NewUserRepository() *UserRepositoryImpl {
-	baseURL := os.Getenv("SUPABASE_URL")
-	baseURL = strings.TrimSuffix(baseURL, "/")
+// 接続先とキーは設定から受け取り、HTTPクライアントは他のリポジトリと共有する
+func NewUserRepository(cfg *config.Config, httpClient *http.Client) *UserRepositoryImpl {
+	baseURL := strings.TrimSuffix(cfg.SupabaseURL, "/")
 	authURL := baseURL + "/auth/v1"
 
 	client := gotrue.New(
 		authURL,
-		os.Getenv("SUPABASE_SERVICE_ROLE_KEY"),
-	)
+		cfg.SupabaseServiceKey,
+	).WithClient(*httpClient)
 
 	return &UserRepositoryImpl{
-		client: client,
+		client:     client,
+		baseURL:    baseURL,
+		serviceKey: cfg.SupabaseServiceKey,
+		httpClient: httpClient,
 	}
 }
 
@@ -53,18 +61,17 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, email, password string,
 		return nil, fmt.Errorf("failed to marshal signup data: %w", err)
 	}
 
-	authURL := os.Getenv("SUPABASE_URL") + "/auth/v1/signup"
+	authURL := r.baseURL + "/auth/v1/signup"
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", authURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("apikey", os.Getenv("SUPABASE_SERVICE_ROLE_KEY"))
-	httpReq.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_SERVICE_ROLE_KEY"))
+	httpReq.Header.Set("apikey", r.serviceKey)
+	httpReq.Header.Set("Authorization", "Bearer "+r.serviceKey)
 
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
+	resp, err := r.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -112,17 +119,16 @@ func (r *UserRepositoryImpl) Authenticate(ctx context.Context, email, password s
 		return nil, fmt.Errorf("failed to marshal login data: %w", err)
 	}
 
-	authURL := os.Getenv("SUPABASE_URL") + "/auth/v1/token?grant_type=password"
+	authURL := r.baseURL + "/auth/v1/token?grant_type=password"
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", authURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("apikey", os.Getenv("SUPABASE_SERVICE_ROLE_KEY"))
+	httpReq.Header.Set("apikey", r.serviceKey)
 
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
+	resp, err := r.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -164,8 +170,49 @@ func (r *UserRepositoryImpl) Authenticate(ctx context.Context, email, password s
 // FindByID はIDでユーザーを検索
 func (r *UserRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.User, error) {
 	// Supabase Admin APIを使用してユーザーを取得
-	// 実装は省略（必要に応じて追加）
-	return nil, fmt.Errorf("not implemented")
+	authURL := r.baseURL + "/auth/v1/admin/users/" + url.PathEscape(id)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", authURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("apikey", r.serviceKey)
+	httpReq.Header.Set("Authorization", "Bearer "+r.serviceKey)
+
+	resp, err := r.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, shared.NewDomainError("NOT_FOUND", "ユーザーが見つかりません")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("find user failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var supabaseResp map[string]interface{}
+	if err := json.Unmarshal(body, &supabaseResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	metadata := getMap(supabaseResp, "user_metadata")
+	user := &entities.User{
+		ID:        getString(supabaseResp, "id"),
+		Email:     getString(supabaseResp, "email"),
+		Username:  getString(metadata, "username"),
+		AvatarURL: getString(metadata, "avatar_url"),
+		CreatedAt: getTime(supabaseResp, "created_at"),
+		UpdatedAt: getTime(supabaseResp, "updated_at"),
+	}
+
+	return user, nil
 }
 
 // FindByEmail はEmailでユーザーを検索
@@ -203,6 +250,18 @@ func getString(m map[string]interface{}, key string) string {
 	return ""
 }
 
+// getTime は map から time.Time を安全に取得
+func getTime(m map[string]interface{}, key string) time.Time {
+	if val, ok := m[key]; ok {
+		if timeStr, ok := val.(string); ok {
+			if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
 // getMap は map から map を安全に取得
 func getMap(m map[string]interface{}, key string) map[string]interface{} {
 	if val, ok := m[key]; ok {
@@ -0,0 +1,91 @@
+package memory
+
+// bookmark_repository_impl.goはメモリ上に保持するBookmarkRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/bookmark/entities"
+	"Shittaka_back/internal/domain/bookmark/repositories"
+)
+
+// BookmarkRepositoryImpl はメモリ上に保持するBookmarkRepositoryの実装
+type BookmarkRepositoryImpl struct {
+	mu        sync.RWMutex
+	bookmarks []entities.Bookmark // ブックマークした順
+}
+
+// NewBookmarkRepository は新しいBookmarkRepositoryImplを作成
+func NewBookmarkRepository() repositories.BookmarkRepository {
+	return &BookmarkRepositoryImpl{}
+}
+
+// Add は問題をブックマーク（既にブックマークしている場合は何もしない）
+func (r *BookmarkRepositoryImpl) Add(ctx context.Context, bookmark *entities.Bookmark, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, b := range r.bookmarks {
+		if b.UserID == bookmark.UserID && b.QuestionID == bookmark.QuestionID {
+			return nil
+		}
+	}
+	r.bookmarks = append(r.bookmarks, entities.Bookmark{
+		UserID:     bookmark.UserID,
+		QuestionID: bookmark.QuestionID,
+		CreatedAt:  time.Now(),
+	})
+	return nil
+}
+
+// Remove はブックマークを外す
+func (r *BookmarkRepositoryImpl) Remove(ctx context.Context, userID string, questionID int64, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bookmarks = slices.DeleteFunc(r.bookmarks, func(b entities.Bookmark) bool {
+		return b.UserID == userID && b.QuestionID == questionID
+	})
+	return nil
+}
+
+// GetByUserID はユーザーのブックマークを新しい順に取得
+func (r *BookmarkRepositoryImpl) GetByUserID(ctx context.Context, userID string, limit, offset int, userToken string) ([]*entities.Bookmark, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var bookmarks []*entities.Bookmark
+	for i := len(r.bookmarks) - 1; i >= 0; i-- {
+		if b := r.bookmarks[i]; b.UserID == userID {
+			bookmarks = append(bookmarks, &b)
+		}
+	}
+
+	if offset >= len(bookmarks) {
+		return nil, nil
+	}
+	bookmarks = bookmarks[offset:]
+	if limit < len(bookmarks) {
+		bookmarks = bookmarks[:limit]
+	}
+	return bookmarks, nil
+}
+
+// GetQuestionIDs はユーザーがブックマークしている全ての問題IDをID順に取得
+func (r *BookmarkRepositoryImpl) GetQuestionIDs(ctx context.Context, userID string, userToken string) ([]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []int64
+	for _, b := range r.bookmarks {
+		if b.UserID == userID {
+			ids = append(ids, b.QuestionID)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
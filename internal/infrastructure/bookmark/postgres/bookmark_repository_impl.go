@@ -0,0 +1,81 @@
+package postgres
+
+// bookmark_repository_impl.goはPostgreSQLを直接使用したBookmarkRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/bookmark/entities"
+	"Shittaka_back/internal/domain/bookmark/repositories"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// BookmarkRepositoryImpl はPostgreSQLを使用したBookmarkRepositoryの実装
+type BookmarkRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewBookmarkRepository は新しいBookmarkRepositoryImplを作成
+func NewBookmarkRepository(pool *pgxpool.Pool) repositories.BookmarkRepository {
+	return &BookmarkRepositoryImpl{pool: pool}
+}
+
+// Add は問題をブックマーク（既にブックマークしている場合は何もしない）
+func (r *BookmarkRepositoryImpl) Add(ctx context.Context, bookmark *entities.Bookmark, userToken string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO bookmarks (user_id, question_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, question_id) DO NOTHING`,
+		bookmark.UserID, bookmark.QuestionID)
+	return database.MapError(err)
+}
+
+// Remove はブックマークを外す
+func (r *BookmarkRepositoryImpl) Remove(ctx context.Context, userID string, questionID int64, userToken string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM bookmarks WHERE user_id = $1 AND question_id = $2`, userID, questionID)
+	return database.MapError(err)
+}
+
+// GetByUserID はユーザーのブックマークを新しい順に取得
+func (r *BookmarkRepositoryImpl) GetByUserID(ctx context.Context, userID string, limit, offset int, userToken string) ([]*entities.Bookmark, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT user_id, question_id, created_at FROM bookmarks
+		WHERE user_id = $1
+		ORDER BY created_at DESC, question_id DESC
+		LIMIT $2 OFFSET $3`,
+		userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookmarks []*entities.Bookmark
+	for rows.Next() {
+		bookmark := &entities.Bookmark{}
+		if err := rows.Scan(&bookmark.UserID, &bookmark.QuestionID, &bookmark.CreatedAt); err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return bookmarks, rows.Err()
+}
+
+// GetQuestionIDs はユーザーがブックマークしている全ての問題IDをID順に取得
+func (r *BookmarkRepositoryImpl) GetQuestionIDs(ctx context.Context, userID string, userToken string) ([]int64, error) {
+	rows, err := r.pool.Query(ctx, `SELECT question_id FROM bookmarks WHERE user_id = $1 ORDER BY question_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,136 @@
+package supabase
+
+// bookmark_repository_impl.goはSupabaseを使用したBookmarkRepositoryの実装
+// ブックマークは本人しか読めないため、取得も含めて全てユーザートークンでRLSを適用する
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"Shittaka_back/internal/domain/bookmark/entities"
+	"Shittaka_back/internal/domain/bookmark/repositories"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// BookmarkRepositoryImpl はSupabaseを使用したBookmarkRepositoryの実装
+type BookmarkRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewBookmarkRepository は新しいBookmarkRepositoryImplを作成
+func NewBookmarkRepository(client *postgrest.Client) repositories.BookmarkRepository {
+	return &BookmarkRepositoryImpl{client: client}
+}
+
+// Add は問題をブックマークする（既にブックマークしている場合は何もしない）
+func (r *BookmarkRepositoryImpl) Add(ctx context.Context, bookmark *entities.Bookmark, userToken string) error {
+	bookmarkData := map[string]interface{}{
+		"user_id":     bookmark.UserID,
+		"question_id": bookmark.QuestionID,
+	}
+	return r.client.From("bookmarks").WithToken(userToken).IgnoreDuplicates("user_id", "question_id").Insert(ctx, bookmarkData, nil)
+}
+
+// Remove はブックマークを外す
+func (r *BookmarkRepositoryImpl) Remove(ctx context.Context, userID string, questionID int64, userToken string) error {
+	return r.client.From("bookmarks").WithToken(userToken).
+		Eq("user_id", userID).
+		Eq("question_id", questionID).
+		Delete(ctx)
+}
+
+// GetByUserID はユーザーのブックマークを新しい順に取得
+func (r *BookmarkRepositoryImpl) GetByUserID(ctx context.Context, userID string, limit, offset int, userToken string) ([]*entities.Bookmark, error) {
+	var bookmarkList []map[string]interface{}
+	err := r.client.From("bookmarks").WithToken(userToken).
+		Eq("user_id", userID).
+		Order("created_at", false).
+		Order("question_id", false).
+		Limit(limit).
+		Offset(offset).
+		Find(ctx, &bookmarkList)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks := make([]*entities.Bookmark, len(bookmarkList))
+	for i, bookmarkData := range bookmarkList {
+		bookmarks[i] = &entities.Bookmark{
+			UserID:     getString(bookmarkData, "user_id"),
+			QuestionID: getInt64(bookmarkData, "question_id"),
+			CreatedAt:  getTime(bookmarkData, "created_at"),
+		}
+	}
+	return bookmarks, nil
+}
+
+// questionIDPageSize は問題IDを1回で取得する件数（PostgRESTの既定の最大行数以下）
+const questionIDPageSize = 1000
+
+// GetQuestionIDs はユーザーがブックマークしている全ての問題IDをID順に取得
+// PostgRESTは1回の応答の行数に上限があるため、ページごとに取得する
+func (r *BookmarkRepositoryImpl) GetQuestionIDs(ctx context.Context, userID string, userToken string) ([]int64, error) {
+	var ids []int64
+	for {
+		var bookmarkList []map[string]interface{}
+		err := r.client.From("bookmarks").WithToken(userToken).
+			Select("question_id").
+			Eq("user_id", userID).
+			Order("question_id", true).
+			Limit(questionIDPageSize).
+			Offset(len(ids)).
+			Find(ctx, &bookmarkList)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bookmarkData := range bookmarkList {
+			ids = append(ids, getInt64(bookmarkData, "question_id"))
+		}
+		if len(bookmarkList) < questionIDPageSize {
+			return ids, nil
+		}
+	}
+}
+
+// getString は map から文字列を安全に取得
+func getString(m map[string]interface{}, key string) string {
+	if val, ok := m[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// getInt64 は map から int64 を安全に取得
+func getInt64(m map[string]interface{}, key string) int64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// getTime は map から time.Time を安全に取得
+func getTime(m map[string]interface{}, key string) time.Time {
+	if val, ok := m[key]; ok {
+		if timeStr, ok := val.(string); ok {
+			if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
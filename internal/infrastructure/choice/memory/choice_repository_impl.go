@@ -0,0 +1,150 @@
+package memory
+
+// choice_repository_impl.goはメモリ上に保持するChoiceRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	entities "Shittaka_back/internal/domain/choices/entities"
+	"Shittaka_back/internal/domain/choices/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// ChoiceRepositoryImpl はメモリ上に保持するChoiceRepositoryの実装
+type ChoiceRepositoryImpl struct {
+	mu      sync.RWMutex
+	nextID  int64
+	choices map[int64]entities.Choice
+}
+
+// NewChoiceRepository は新しいChoiceRepositoryImplを作成
+func NewChoiceRepository() repositories.ChoiceRepository {
+	return &ChoiceRepositoryImpl{choices: make(map[int64]entities.Choice)}
+}
+
+// GetByID はIDで選択肢を取得
+func (r *ChoiceRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Choice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	choice, ok := r.choices[id]
+	if !ok {
+		return nil, shared.NewDomainError("NOT_FOUND", "選択肢が見つかりません")
+	}
+	return &choice, nil
+}
+
+// GetByQuestionID は問題IDに紐づく選択肢を表示順に取得
+func (r *ChoiceRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]entities.Choice, error) {
+	return r.GetByQuestionIDs(ctx, []int64{questionID})
+}
+
+// GetByQuestionIDs は複数の問題に紐づく選択肢を問題ごとの表示順にまとめて取得
+func (r *ChoiceRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]entities.Choice, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var choices []entities.Choice
+	for _, choice := range r.choices {
+		if slices.Contains(questionIDs, choice.QuestionID) {
+			choices = append(choices, choice)
+		}
+	}
+	sort.Slice(choices, func(i, j int) bool {
+		a, b := choices[i], choices[j]
+		if a.QuestionID != b.QuestionID {
+			return a.QuestionID < b.QuestionID
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID < b.ID
+	})
+	return choices, nil
+}
+
+// Create は新しい選択肢を作成
+func (r *ChoiceRepositoryImpl) Create(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
+	created, err := r.CreateBatch(ctx, []entities.Choice{choice}, "")
+	if err != nil {
+		return nil, err
+	}
+	return &created[0], nil
+}
+
+// CreateWithAuth は新しい選択肢を作成（権限は ChoiceService で確認する）
+func (r *ChoiceRepositoryImpl) CreateWithAuth(ctx context.Context, choice entities.Choice, userToken string) (*entities.Choice, error) {
+	return r.Create(ctx, choice)
+}
+
+// CreateBatch は複数の選択肢をまとめて作成し、渡した順に返す
+func (r *ChoiceRepositoryImpl) CreateBatch(ctx context.Context, choices []entities.Choice, userToken string) ([]entities.Choice, error) {
+	if len(choices) == 0 {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := make([]entities.Choice, len(choices))
+	for i, choice := range choices {
+		r.nextID++
+		choice.ID = r.nextID
+		r.choices[choice.ID] = choice
+		created[i] = choice
+	}
+	return created, nil
+}
+
+// Update は選択肢を更新（表示順は指定された場合のみ更新する）
+func (r *ChoiceRepositoryImpl) Update(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.choices[choice.ID]
+	if !ok {
+		return nil, shared.NewDomainError("NOT_FOUND", "選択肢が見つかりません")
+	}
+
+	existing.Text = choice.Text
+	existing.IsCorrect = choice.IsCorrect
+	if choice.Position > 0 {
+		existing.Position = choice.Position
+	}
+	r.choices[choice.ID] = existing
+	return &existing, nil
+}
+
+// Delete は選択肢を削除
+func (r *ChoiceRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.choices, id)
+	return nil
+}
+
+// UpdatePositions は問題に紐づく選択肢の表示順をまとめて更新
+// 他の問題の選択肢は書き換えない
+func (r *ChoiceRepositoryImpl) UpdatePositions(ctx context.Context, questionID int64, positions map[int64]int, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, position := range positions {
+		choice, ok := r.choices[id]
+		if !ok || choice.QuestionID != questionID {
+			continue
+		}
+		choice.Position = position
+		r.choices[id] = choice
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package postgres
+
+// choice_repository_impl.goはPostgreSQLを直接使用したChoiceRepositoryの実装
+// RLSは使わないため userToken は参照しない（権限は ChoiceService で確認する）
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"Shittaka_back/internal/domain/choices/entities"
+	"Shittaka_back/internal/domain/choices/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// choiceColumns は選択肢を取得する際の列（scanChoice と同じ順序）
+const choiceColumns = `id, question_id, text, is_correct, position`
+
+// ChoiceRepositoryImpl はPostgreSQLを使用したChoiceRepositoryの実装
+type ChoiceRepositoryImpl struct {
+	db database.DB
+}
+
+// NewChoiceRepository は新しいChoiceRepositoryImplを作成
+// db には接続プールのほか、ユニットオブワークのトランザクションも渡せる
+func NewChoiceRepository(db database.DB) repositories.ChoiceRepository {
+	return &ChoiceRepositoryImpl{db: db}
+}
+
+// GetByID はIDで選択肢を取得
+func (r *ChoiceRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Choice, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+choiceColumns+` FROM choices WHERE id = $1`, id)
+	choice, err := scanChoice(row)
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "選択肢が見つかりません")
+		}
+		return nil, err
+	}
+	return &choice, nil
+}
+
+// GetByQuestionID は問題IDで選択肢一覧を取得
+func (r *ChoiceRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]entities.Choice, error) {
+	return r.query(ctx, `SELECT `+choiceColumns+` FROM choices WHERE question_id = $1 ORDER BY position ASC, id ASC`, questionID)
+}
+
+// GetByQuestionIDs は複数の問題に紐づく選択肢を問題ID・表示順の順にまとめて取得
+func (r *ChoiceRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]entities.Choice, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+	return r.query(ctx, `SELECT `+choiceColumns+` FROM choices WHERE question_id = ANY($1) ORDER BY question_id ASC, position ASC, id ASC`, questionIDs)
+}
+
+// Create は新しい選択肢を作成
+func (r *ChoiceRepositoryImpl) Create(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
+	created, err := insertChoice(ctx, r.db, choice)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// CreateWithAuth は新しい選択肢を作成
+func (r *ChoiceRepositoryImpl) CreateWithAuth(ctx context.Context, choice entities.Choice, userToken string) (*entities.Choice, error) {
+	return r.Create(ctx, choice)
+}
+
+// CreateBatch は複数の選択肢を1つのトランザクションでまとめて作成
+func (r *ChoiceRepositoryImpl) CreateBatch(ctx context.Context, choices []entities.Choice, userToken string) ([]entities.Choice, error) {
+	if len(choices) == 0 {
+		return nil, nil
+	}
+
+	created := make([]entities.Choice, len(choices))
+	err := database.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		for i, choice := range choices {
+			c, err := insertChoice(ctx, tx, choice)
+			if err != nil {
+				return err
+			}
+			created[i] = c
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Update は選択肢を更新（表示順は指定された場合のみ更新する）
+func (r *ChoiceRepositoryImpl) Update(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
+	row := r.db.QueryRow(ctx, `
+		UPDATE choices SET text = $1, is_correct = $2, position = CASE WHEN $3 > 0 THEN $3 ELSE position END
+		WHERE id = $4
+		RETURNING `+choiceColumns,
+		choice.Text, choice.IsCorrect, choice.Position, choice.ID,
+	)
+	updated, err := scanChoice(row)
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "選択肢が見つかりません")
+		}
+		return nil, database.MapError(err)
+	}
+	return &updated, nil
+}
+
+// Delete は選択肢を削除
+func (r *ChoiceRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM choices WHERE id = $1`, id)
+	return database.MapError(err)
+}
+
+// UpdatePositions は問題に紐づく選択肢の表示順を1つのトランザクションでまとめて更新
+// 他の問題の選択肢を書き換えないよう question_id も条件に含める
+func (r *ChoiceRepositoryImpl) UpdatePositions(ctx context.Context, questionID int64, positions map[int64]int, userToken string) error {
+	return database.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		for id, position := range positions {
+			if _, err := tx.Exec(ctx, `UPDATE choices SET position = $1 WHERE id = $2 AND question_id = $3`, position, id, questionID); err != nil {
+				return database.MapError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// query は選択肢を検索する共通処理
+func (r *ChoiceRepositoryImpl) query(ctx context.Context, sql string, args ...any) ([]entities.Choice, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var choices []entities.Choice
+	for rows.Next() {
+		choice, err := scanChoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		choices = append(choices, choice)
+	}
+	return choices, rows.Err()
+}
+
+// insertChoice は選択肢を1件作成する（プール・トランザクションのどちらでも使う）
+func insertChoice(ctx context.Context, q database.Querier, choice entities.Choice) (entities.Choice, error) {
+	row := q.QueryRow(ctx, `
+		INSERT INTO choices (question_id, text, is_correct, position)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+choiceColumns,
+		choice.QuestionID, choice.Text, choice.IsCorrect, choice.Position,
+	)
+	created, err := scanChoice(row)
+	if err != nil {
+		return entities.Choice{}, database.MapError(err)
+	}
+	return created, nil
+}
+
+// scanChoice は choiceColumns の順に読み込んだ行を Choice エンティティに変換
+func scanChoice(row pgx.Row) (entities.Choice, error) {
+	var c entities.Choice
+	err := row.Scan(&c.ID, &c.QuestionID, &c.Text, &c.IsCorrect, &c.Position)
+	return c, err
+}
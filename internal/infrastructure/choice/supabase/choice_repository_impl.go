@@ -1,264 +1,213 @@
 package supabase
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
 	"strconv"
 
 	"Shittaka_back/internal/domain/choices/entities"
 	"Shittaka_back/internal/domain/choices/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
 )
 
 // ChoiceRepositoryImpl はSupabaseを使用したChoiceRepositoryの実装
-type ChoiceRepositoryImpl struct{}
+// 正解の列（is_correct）は公開キーやユーザーのトークンでは読めないため、読み取りはサービスロールキーで行う
+type ChoiceRepositoryImpl struct {
+	client *postgrest.Client
+}
 
 // NewChoiceRepository は新しいChoiceRepositoryImplを作成
-func NewChoiceRepository() repositories.ChoiceRepository {
-	return &ChoiceRepositoryImpl{}
+func NewChoiceRepository(client *postgrest.Client) repositories.ChoiceRepository {
+	return &ChoiceRepositoryImpl{client: client}
 }
 
-// GetByQuestionID は問題IDで選択肢一覧を取得
-func (r *ChoiceRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]entities.Choice, error) {
-	url := fmt.Sprintf("%s/rest/v1/choices?question_id=eq.%d", os.Getenv("SUPABASE_URL"), questionID)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// GetByID はIDで選択肢を取得
+func (r *ChoiceRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Choice, error) {
+	var choiceData map[string]interface{}
+	if err := r.client.From("choices").AsServiceRole().Eq("id", id).Single(ctx, &choiceData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "選択肢が見つかりません")
+		}
+		return nil, err
 	}
 
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
+	choice := mapToChoice(choiceData)
+	return &choice, nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// GetByQuestionID は問題IDで選択肢一覧を取得
+func (r *ChoiceRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]entities.Choice, error) {
+	var choiceList []map[string]interface{}
+	err := r.client.From("choices").AsServiceRole().
+		Eq("question_id", questionID).
+		Order("position", true).
+		Order("id", true).
+		Find(ctx, &choiceList)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+	return mapToChoices(choiceList), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find choices failed with status %d: %s", resp.StatusCode, string(body))
+// GetByQuestionIDs は複数の問題に紐づく選択肢を問題ID・表示順の順にまとめて取得
+func (r *ChoiceRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) ([]entities.Choice, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
 	}
 
 	var choiceList []map[string]interface{}
-	if err := json.Unmarshal(body, &choiceList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	choices := make([]entities.Choice, len(choiceList))
-	for i, choiceData := range choiceList {
-		choices[i] = mapToChoice(choiceData)
+	err := r.client.From("choices").AsServiceRole().
+		In("question_id", postgrest.Int64s(questionIDs)...).
+		Order("question_id", true).
+		Order("position", true).
+		Order("id", true).
+		Find(ctx, &choiceList)
+	if err != nil {
+		return nil, err
 	}
 
-	return choices, nil
+	return mapToChoices(choiceList), nil
 }
 
 // Create は新しい選択肢を作成
 func (r *ChoiceRepositoryImpl) Create(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
-	choiceData := map[string]interface{}{
-		"question_id": choice.QuestionID,
-		"text":        choice.Text,
-		"is_correct":  choice.IsCorrect,
-	}
-
-	jsonData, err := json.Marshal(choiceData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal choice data: %w", err)
-	}
-
-	url := os.Getenv("SUPABASE_URL") + "/rest/v1/choices"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Prefer", "return=representation")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("create choice failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var choiceList []map[string]interface{}
-	if err := json.Unmarshal(body, &choiceList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := r.client.From("choices").Insert(ctx, choiceToMap(choice), &choiceList); err != nil {
+		return nil, err
 	}
 
 	if len(choiceList) == 0 {
 		return nil, fmt.Errorf("no choice returned from create operation")
 	}
 
-	choiceResp := choiceList[0]
-	result := mapToChoice(choiceResp)
+	result := mapToChoice(choiceList[0])
 	return &result, nil
 }
 
 // CreateWithAuth は認証トークンを使って新しい選択肢を作成
 func (r *ChoiceRepositoryImpl) CreateWithAuth(ctx context.Context, choice entities.Choice, userToken string) (*entities.Choice, error) {
-	choiceData := map[string]interface{}{
-		"question_id": choice.QuestionID,
-		"text":        choice.Text,
-		"is_correct":  choice.IsCorrect,
-	}
-
-	jsonData, err := json.Marshal(choiceData)
+	created, err := r.insertWithToken(ctx, []map[string]interface{}{choiceToMap(choice)}, userToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal choice data: %w", err)
+		return nil, err
 	}
 
-	url := os.Getenv("SUPABASE_URL") + "/rest/v1/choices"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if len(created) == 0 {
+		return nil, fmt.Errorf("no choice returned from create operation")
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
-	req.Header.Set("Prefer", "return=representation")
-	
-	// デバッグ用ログ
-	if len(userToken) > 20 {
-		fmt.Printf("CreateWithAuth - UserToken: %s...\n", userToken[:20])
-	} else {
-		fmt.Printf("CreateWithAuth - UserToken: %s\n", userToken)
-	}
-	fmt.Printf("CreateWithAuth - URL: %s\n", url)
+	return &created[0], nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// CreateBatch は認証トークンを使って複数の選択肢を1回のリクエストでまとめて作成
+func (r *ChoiceRepositoryImpl) CreateBatch(ctx context.Context, choices []entities.Choice, userToken string) ([]entities.Choice, error) {
+	if len(choices) == 0 {
+		return nil, nil
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	choiceDataList := make([]map[string]interface{}, len(choices))
+	for i, choice := range choices {
+		choiceDataList[i] = choiceToMap(choice)
 	}
 
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("create choice failed with status %d: %s", resp.StatusCode, string(body))
+	return r.insertWithToken(ctx, choiceDataList, userToken)
+}
+
+// insertWithToken はユーザーのトークンで選択肢を作成し（RLSで問題の作成者かを確認する）、作成した選択肢を渡した順に返す
+// ユーザーのトークンでは正解の列（is_correct）を読めないため作成時は ID だけを返させ、サービスロールキーで読み直す
+func (r *ChoiceRepositoryImpl) insertWithToken(ctx context.Context, choiceDataList []map[string]interface{}, userToken string) ([]entities.Choice, error) {
+	var inserted []struct {
+		ID int64 `json:"id"`
+	}
+	if err := r.client.From("choices").WithToken(userToken).Select("id").Insert(ctx, choiceDataList, &inserted); err != nil {
+		return nil, err
+	}
+	if len(inserted) == 0 {
+		return nil, nil
 	}
 
-	var choiceList []map[string]interface{}
-	if err := json.Unmarshal(body, &choiceList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	ids := make([]int64, len(inserted))
+	for i, row := range inserted {
+		ids[i] = row.ID
 	}
 
-	if len(choiceList) == 0 {
-		return nil, fmt.Errorf("no choice returned from create operation")
+	// 複数行の INSERT では ID が渡した順に採番される
+	var choiceList []map[string]interface{}
+	err := r.client.From("choices").AsServiceRole().
+		In("id", postgrest.Int64s(ids)...).
+		Order("id", true).
+		Find(ctx, &choiceList)
+	if err != nil {
+		return nil, err
 	}
 
-	choiceResp := choiceList[0]
-	result := mapToChoice(choiceResp)
-	return &result, nil
+	return mapToChoices(choiceList), nil
 }
 
-// Update は選択肢を更新
+// Update は選択肢を更新（問題の作成者かどうかは ChoiceService で確認するため、サービスロールキーを使用）
 func (r *ChoiceRepositoryImpl) Update(ctx context.Context, choice entities.Choice) (*entities.Choice, error) {
 	choiceData := map[string]interface{}{
 		"text":       choice.Text,
 		"is_correct": choice.IsCorrect,
 	}
-
-	jsonData, err := json.Marshal(choiceData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal choice data: %w", err)
-	}
-
-	url := fmt.Sprintf("%s/rest/v1/choices?id=eq.%d", os.Getenv("SUPABASE_URL"), choice.ID)
-	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Prefer", "return=representation")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("update choice failed with status %d: %s", resp.StatusCode, string(body))
+	// 表示順は指定された場合のみ更新する
+	if choice.Position > 0 {
+		choiceData["position"] = choice.Position
 	}
 
 	var choiceList []map[string]interface{}
-	if err := json.Unmarshal(body, &choiceList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := r.client.From("choices").AsServiceRole().Eq("id", choice.ID).Update(ctx, choiceData, &choiceList); err != nil {
+		return nil, err
 	}
 
 	if len(choiceList) == 0 {
 		return nil, fmt.Errorf("no choice returned from update operation")
 	}
 
-	choiceResp := choiceList[0]
-	result := mapToChoice(choiceResp)
+	result := mapToChoice(choiceList[0])
 	return &result, nil
 }
 
-// Delete は選択肢を削除
+// Delete は選択肢を削除（問題の作成者かどうかは ChoiceService で確認するため、サービスロールキーを使用）
 func (r *ChoiceRepositoryImpl) Delete(ctx context.Context, id int64) error {
-	url := fmt.Sprintf("%s/rest/v1/choices?id=eq.%d", os.Getenv("SUPABASE_URL"), id)
-	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
+	return r.client.From("choices").AsServiceRole().Eq("id", id).Delete(ctx)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+// UpdatePositions は問題に紐づく選択肢の表示順をまとめて更新
+// 他の問題の選択肢を書き換えないよう question_id も条件に含める
+func (r *ChoiceRepositoryImpl) UpdatePositions(ctx context.Context, questionID int64, positions map[int64]int, userToken string) error {
+	for id, position := range positions {
+		err := r.client.From("choices").
+			Eq("id", id).
+			Eq("question_id", questionID).
+			WithToken(userToken).
+			Update(ctx, map[string]interface{}{"position": position}, nil)
+		if err != nil {
+			return err
+		}
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("delete choice failed with status %d: %s", resp.StatusCode, string(body))
+// choiceToMap は Choice エンティティを作成用の map に変換
+func choiceToMap(choice entities.Choice) map[string]interface{} {
+	return map[string]interface{}{
+		"question_id": choice.QuestionID,
+		"text":        choice.Text,
+		"is_correct":  choice.IsCorrect,
+		"position":    choice.Position,
 	}
+}
 
-	return nil
+// mapToChoices は取得した行の一覧を Choice エンティティの一覧に変換
+func mapToChoices(choiceList []map[string]interface{}) []entities.Choice {
+	choices := make([]entities.Choice, len(choiceList))
+	for i, choiceData := range choiceList {
+		choices[i] = mapToChoice(choiceData)
+	}
+	return choices
 }
 
 // mapToChoice は map[string]interface{} を Choice エンティティに変換
@@ -268,6 +217,7 @@ func mapToChoice(m map[string]interface{}) entities.Choice {
 		QuestionID: getInt64(m, "question_id"),
 		Text:       getString(m, "text"),
 		IsCorrect:  getBool(m, "is_correct"),
+		Position:   int(getInt64(m, "position")),
 	}
 }
 
@@ -310,4 +260,4 @@ func getBool(m map[string]interface{}, key string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
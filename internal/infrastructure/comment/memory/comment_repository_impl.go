@@ -0,0 +1,118 @@
+package memory
+
+// comment_repository_impl.goはメモリ上に保持するCommentRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/comment/entities"
+	"Shittaka_back/internal/domain/comment/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// CommentRepositoryImpl はメモリ上に保持するCommentRepositoryの実装
+type CommentRepositoryImpl struct {
+	mu       sync.RWMutex
+	comments []*entities.Comment // 作成順（ID順）
+}
+
+// NewCommentRepository は新しいCommentRepositoryImplを作成
+func NewCommentRepository() repositories.CommentRepository {
+	return &CommentRepositoryImpl{}
+}
+
+// Create は新しいコメントを作成
+func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *entities.Comment, userToken string) (*entities.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	c := *comment
+	c.ID = int64(len(r.comments) + 1)
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	c.DeletedAt = nil
+	r.comments = append(r.comments, &c)
+
+	created := c
+	return &created, nil
+}
+
+// GetByID はIDでコメントを取得
+func (r *CommentRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Comment, error) {
+	comments := r.filter(func(c *entities.Comment) bool { return c.ID == id })
+	if len(comments) == 0 {
+		return nil, shared.NewDomainError("NOT_FOUND", "コメントが見つかりません")
+	}
+	return comments[0], nil
+}
+
+// GetRootsByQuestionID は問題に紐づくスレッド起点のコメントを古い順に取得
+func (r *CommentRepositoryImpl) GetRootsByQuestionID(ctx context.Context, questionID int64, limit, offset int) ([]*entities.Comment, error) {
+	comments := r.filter(func(c *entities.Comment) bool { return c.QuestionID == questionID && c.ParentID == nil })
+	if offset >= len(comments) {
+		return nil, nil
+	}
+	comments = comments[offset:]
+	if limit < len(comments) {
+		comments = comments[:limit]
+	}
+	return comments, nil
+}
+
+// GetRepliesByParentIDs は指定したコメントへの返信を古い順に取得
+func (r *CommentRepositoryImpl) GetRepliesByParentIDs(ctx context.Context, parentIDs []int64) ([]*entities.Comment, error) {
+	return r.filter(func(c *entities.Comment) bool {
+		return c.ParentID != nil && slices.Contains(parentIDs, *c.ParentID)
+	}), nil
+}
+
+// Update はコメント本文を更新
+func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *entities.Comment, userToken string) error {
+	r.modify(comment.ID, func(c *entities.Comment) {
+		c.Body = comment.Body
+		c.UpdatedAt = comment.UpdatedAt
+	})
+	return nil
+}
+
+// SoftDelete はコメントを削除済みにする（返信のスレッドを保つため残す）
+func (r *CommentRepositoryImpl) SoftDelete(ctx context.Context, comment *entities.Comment, userToken string) error {
+	r.modify(comment.ID, func(c *entities.Comment) {
+		c.DeletedAt = comment.DeletedAt
+		c.UpdatedAt = comment.UpdatedAt
+	})
+	return nil
+}
+
+// filter は条件に合うコメントを作成順に複製して返す
+func (r *CommentRepositoryImpl) filter(match func(c *entities.Comment) bool) []*entities.Comment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var comments []*entities.Comment
+	for _, c := range r.comments {
+		if match(c) {
+			copied := *c
+			comments = append(comments, &copied)
+		}
+	}
+	return comments
+}
+
+// modify は保持しているコメントを書き換える（存在しない場合は何もしない）
+func (r *CommentRepositoryImpl) modify(id int64, update func(c *entities.Comment)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.comments {
+		if c.ID == id {
+			update(c)
+			return
+		}
+	}
+}
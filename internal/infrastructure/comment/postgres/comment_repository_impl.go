@@ -0,0 +1,113 @@
+package postgres
+
+// comment_repository_impl.goはPostgreSQLを直接使用したCommentRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/comment/entities"
+	"Shittaka_back/internal/domain/comment/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// commentColumns はコメントを取得する際の列（scanComment と同じ順序）
+const commentColumns = `id, question_id, user_id, parent_id, body, created_at, updated_at, deleted_at`
+
+// CommentRepositoryImpl はPostgreSQLを使用したCommentRepositoryの実装
+type CommentRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommentRepository は新しいCommentRepositoryImplを作成
+func NewCommentRepository(pool *pgxpool.Pool) repositories.CommentRepository {
+	return &CommentRepositoryImpl{pool: pool}
+}
+
+// Create は新しいコメントを作成
+func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *entities.Comment, userToken string) (*entities.Comment, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO comments (question_id, user_id, parent_id, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+commentColumns,
+		comment.QuestionID, comment.UserID, comment.ParentID, comment.Body,
+	)
+	created, err := scanComment(row)
+	if err != nil {
+		return nil, database.MapError(err)
+	}
+	return created, nil
+}
+
+// GetByID はIDでコメントを取得
+func (r *CommentRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Comment, error) {
+	comment, err := scanComment(r.pool.QueryRow(ctx, `SELECT `+commentColumns+` FROM comments WHERE id = $1`, id))
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "コメントが見つかりません")
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetRootsByQuestionID は問題に紐づくスレッド起点のコメントを取得
+func (r *CommentRepositoryImpl) GetRootsByQuestionID(ctx context.Context, questionID int64, limit, offset int) ([]*entities.Comment, error) {
+	return r.query(ctx, `
+		SELECT `+commentColumns+` FROM comments
+		WHERE question_id = $1 AND parent_id IS NULL
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2 OFFSET $3`,
+		questionID, limit, offset)
+}
+
+// GetRepliesByParentIDs は指定したコメントへの返信を取得
+func (r *CommentRepositoryImpl) GetRepliesByParentIDs(ctx context.Context, parentIDs []int64) ([]*entities.Comment, error) {
+	return r.query(ctx, `SELECT `+commentColumns+` FROM comments WHERE parent_id = ANY($1) ORDER BY created_at ASC, id ASC`, parentIDs)
+}
+
+// Update はコメント本文を更新
+func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *entities.Comment, userToken string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE comments SET body = $1, updated_at = $2 WHERE id = $3`,
+		comment.Body, comment.UpdatedAt, comment.ID)
+	return database.MapError(err)
+}
+
+// SoftDelete はコメントを削除済みにする（返信のスレッドを保つため行は残す）
+func (r *CommentRepositoryImpl) SoftDelete(ctx context.Context, comment *entities.Comment, userToken string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE comments SET deleted_at = $1, updated_at = $2 WHERE id = $3`,
+		comment.DeletedAt, comment.UpdatedAt, comment.ID)
+	return database.MapError(err)
+}
+
+// query はコメントを検索する共通処理
+func (r *CommentRepositoryImpl) query(ctx context.Context, sql string, args ...any) ([]*entities.Comment, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*entities.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// scanComment は commentColumns の順に読み込んだ行を Comment エンティティに変換
+func scanComment(row pgx.Row) (*entities.Comment, error) {
+	c := &entities.Comment{}
+	err := row.Scan(&c.ID, &c.QuestionID, &c.UserID, &c.ParentID, &c.Body, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,188 @@
+package supabase
+
+// comment_repository_impl.goはSupabaseを使用したCommentRepositoryの実装
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"Shittaka_back/internal/domain/comment/entities"
+	"Shittaka_back/internal/domain/comment/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// CommentRepositoryImpl はSupabaseを使用したCommentRepositoryの実装
+type CommentRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewCommentRepository は新しいCommentRepositoryImplを作成
+func NewCommentRepository(client *postgrest.Client) repositories.CommentRepository {
+	return &CommentRepositoryImpl{client: client}
+}
+
+// Create は新しいコメントを作成（RLS適用のためユーザートークンを使用）
+func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *entities.Comment, userToken string) (*entities.Comment, error) {
+	commentData := map[string]interface{}{
+		"question_id": comment.QuestionID,
+		"user_id":     comment.UserID,
+		"parent_id":   comment.ParentID,
+		"body":        comment.Body,
+	}
+
+	var commentList []map[string]interface{}
+	if err := r.client.From("comments").WithToken(userToken).Insert(ctx, commentData, &commentList); err != nil {
+		return nil, err
+	}
+
+	if len(commentList) == 0 {
+		return nil, fmt.Errorf("no comment returned from create operation")
+	}
+
+	return mapToComment(commentList[0]), nil
+}
+
+// GetByID はIDでコメントを取得
+func (r *CommentRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Comment, error) {
+	var commentData map[string]interface{}
+	if err := r.client.From("comments").Eq("id", id).Single(ctx, &commentData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "コメントが見つかりません")
+		}
+		return nil, err
+	}
+
+	return mapToComment(commentData), nil
+}
+
+// GetRootsByQuestionID は問題に紐づくスレッド起点のコメントを取得
+func (r *CommentRepositoryImpl) GetRootsByQuestionID(ctx context.Context, questionID int64, limit, offset int) ([]*entities.Comment, error) {
+	return r.findComments(ctx, r.client.From("comments").
+		Eq("question_id", questionID).
+		Filter("parent_id", "is", "null").
+		Order("created_at", true).
+		Order("id", true).
+		Limit(limit).
+		Offset(offset))
+}
+
+// GetRepliesByParentIDs は指定したコメントへの返信を取得
+func (r *CommentRepositoryImpl) GetRepliesByParentIDs(ctx context.Context, parentIDs []int64) ([]*entities.Comment, error) {
+	return r.findComments(ctx, r.client.From("comments").
+		In("parent_id", postgrest.Int64s(parentIDs)...).
+		Order("created_at", true).
+		Order("id", true))
+}
+
+// Update はコメント本文を更新（RLS適用のためユーザートークンを使用）
+func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *entities.Comment, userToken string) error {
+	commentData := map[string]interface{}{
+		"body":       comment.Body,
+		"updated_at": comment.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+
+	return r.client.From("comments").WithToken(userToken).Eq("id", comment.ID).Update(ctx, commentData, nil)
+}
+
+// SoftDelete はコメントを論理削除（RLS適用のためユーザートークンを使用）
+func (r *CommentRepositoryImpl) SoftDelete(ctx context.Context, comment *entities.Comment, userToken string) error {
+	commentData := map[string]interface{}{
+		"deleted_at": comment.DeletedAt.UTC().Format(time.RFC3339Nano),
+		"updated_at": comment.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+
+	return r.client.From("comments").WithToken(userToken).Eq("id", comment.ID).Update(ctx, commentData, nil)
+}
+
+// findComments はクエリに合うコメントを取得してCommentエンティティのスライスに変換
+func (r *CommentRepositoryImpl) findComments(ctx context.Context, query *postgrest.Query) ([]*entities.Comment, error) {
+	var commentList []map[string]interface{}
+	if err := query.Find(ctx, &commentList); err != nil {
+		return nil, err
+	}
+
+	comments := make([]*entities.Comment, len(commentList))
+	for i, commentData := range commentList {
+		comments[i] = mapToComment(commentData)
+	}
+
+	return comments, nil
+}
+
+// mapToComment は map[string]interface{} を Comment エンティティに変換
+func mapToComment(m map[string]interface{}) *entities.Comment {
+	return &entities.Comment{
+		ID:         getInt64(m, "id"),
+		QuestionID: getInt64(m, "question_id"),
+		UserID:     getString(m, "user_id"),
+		ParentID:   getOptionalInt64(m, "parent_id"),
+		Body:       getString(m, "body"),
+		CreatedAt:  getTime(m, "created_at"),
+		UpdatedAt:  getTime(m, "updated_at"),
+		DeletedAt:  getOptionalTime(m, "deleted_at"),
+	}
+}
+
+// ヘルパー関数
+
+// getString は map から文字列を安全に取得
+func getString(m map[string]interface{}, key string) string {
+	if val, ok := m[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// getInt64 は map から int64 を安全に取得
+func getInt64(m map[string]interface{}, key string) int64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// getOptionalInt64 は map から NULL 許容の int64 を取得
+func getOptionalInt64(m map[string]interface{}, key string) *int64 {
+	if val, ok := m[key]; !ok || val == nil {
+		return nil
+	}
+	v := getInt64(m, key)
+	return &v
+}
+
+// getTime は map から time.Time を安全に取得
+func getTime(m map[string]interface{}, key string) time.Time {
+	if val, ok := m[key]; ok {
+		if timeStr, ok := val.(string); ok {
+			if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// getOptionalTime は map から NULL 許容の time.Time を取得
+func getOptionalTime(m map[string]interface{}, key string) *time.Time {
+	if val, ok := m[key]; !ok || val == nil {
+		return nil
+	}
+	t := getTime(m, key)
+	return &t
+}
@@ -3,8 +3,15 @@ package config
 // config.goはアプリケーションの設定を保持
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // 実行環境にタイムゾーン情報がなくても DAILY_TIMEZONE を読み込めるようにする
 
 	"github.com/joho/godotenv"
 )
@@ -12,36 +19,384 @@ import (
 // Config はアプリケーションの設定を保持
 type Config struct {
 	SupabaseURL        string
+	SupabaseAnonKey    string
 	SupabaseServiceKey string
 	Port               string
+
+	// SupabaseJWTSecret はSupabase Authがアクセストークンの署名に使うJWTシークレット（トークンの検証に使う）
+	SupabaseJWTSecret string
+
+	// ServerReadHeaderTimeout / ServerReadTimeout / ServerWriteTimeout / ServerIdleTimeout はHTTPサーバーのタイムアウト
+	ServerReadHeaderTimeout time.Duration
+	ServerReadTimeout       time.Duration
+	ServerWriteTimeout      time.Duration
+	ServerIdleTimeout       time.Duration
+	// ShutdownTimeout は終了シグナルを受けてから処理中のリクエストの完了を待つ時間
+	ShutdownTimeout time.Duration
+
+	// LogFormat はログの形式（json / text）、LogLevel は出力するログのレベル（debug / info / warn / error）
+	LogFormat string
+	LogLevel  string
+
+	// ReadinessTimeout は /readyz で依存先ごとに応答を待つ時間
+	ReadinessTimeout time.Duration
+	// ReadinessCacheTTL は /readyz の確認結果を使い回す期間
+	ReadinessCacheTTL time.Duration
+
+	// TrustedProxies は X-Forwarded-For を信頼するリバースプロキシのアドレス範囲（空の場合は接続元のアドレスを使う）
+	TrustedProxies []netip.Prefix
+
+	// AppEnv は実行環境（memory の場合は外部サービスに接続せずメモリ上にデータを保持する）
+	AppEnv string
+
+	// DatabaseDriver はデータの保存先（supabase / postgres / memory）
+	DatabaseDriver string
+	// DatabaseURL はPostgreSQLに直接接続する場合の接続文字列
+	DatabaseURL string
+	// SeedOnStart はサーバーの起動時にサンプルデータを投入するかどうか（APP_ENV=memory での動作確認用）
+	SeedOnStart bool
+
+	// ModeratorUserIDs は通報キューを操作できるユーザーID一覧
+	ModeratorUserIDs []string
+	// ReportHideThreshold は問題を自動で非公開にする未対応通報の件数
+	ReportHideThreshold int
+
+	// ViewDedupWindow は同じ閲覧者の再閲覧を数えない期間
+	ViewDedupWindow time.Duration
+	// ViewFlushInterval は閲覧数をまとめて書き込む間隔
+	ViewFlushInterval time.Duration
+
+	// PublishSchedulerInterval は予約公開の問題を確認する間隔
+	PublishSchedulerInterval time.Duration
+
+	// CuratorUserIDs は「今日の一問」を指定できるユーザーID一覧
+	CuratorUserIDs []string
+	// DailyNoRepeatDays は「今日の一問」で同じ問題を再出題しない日数
+	DailyNoRepeatDays int
+	// DailyLocation は「今日の一問」の日付を切り替えるタイムゾーン
+	DailyLocation *time.Location
+
+	// StorageDriver は添付画像の保存先（local / supabase）
+	StorageDriver string
+	// StorageLocalDir はローカル保存時の保存先ディレクトリ
+	StorageLocalDir string
+	// StoragePublicBaseURL はローカル保存時に画像を配信するURLの接頭辞
+	StoragePublicBaseURL string
+	// StorageBucket はSupabase Storageのバケット名
+	StorageBucket string
+	// AttachmentMaxBytes は添付画像のファイルサイズの上限
+	AttachmentMaxBytes int64
+	// AttachmentMaxWidth / AttachmentMaxHeight は添付画像の縦横のピクセル数の上限
+	AttachmentMaxWidth  int
+	AttachmentMaxHeight int
+
+	// DuplicateWarnThreshold は作成時に似た問題として警告する類似度（0〜1）
+	DuplicateWarnThreshold float64
+	// DuplicateRejectThreshold は作成時に重複として拒否する類似度（0〜1）
+	DuplicateRejectThreshold float64
 }
 
-// LoadConfig は設定を読み込む
+// LoadConfig は設定を読み込み、不正な設定があれば終了する
 func LoadConfig() *Config {
+	cfg, err := Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return cfg
+}
+
+// Load は .env と環境変数から設定を読み込んで検証する
+// 不正な値は全てまとめてエラーとして返す
+func Load() (*Config, error) {
 	// 環境変数を読み込み
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using system env")
 	}
 
-	// 必要な環境変数をチェック
-	supabaseURL := os.Getenv("SUPABASE_URL")
-	if supabaseURL == "" {
-		log.Fatal("SUPABASE_URL is required")
+	env := &envReader{}
+
+	// APP_ENV=memory の場合は Supabase を使わないため接続情報は不要
+	appEnv := os.Getenv("APP_ENV")
+	inMemory := appEnv == "memory"
+
+	databaseDriver := getEnv("DATABASE_DRIVER", "supabase")
+	if databaseDriver != "supabase" && databaseDriver != "postgres" {
+		env.fail("DATABASE_DRIVER must be supabase or postgres: %q", databaseDriver)
+	}
+	if inMemory {
+		databaseDriver = "memory"
+	}
+
+	dailyTimezone := getEnv("DAILY_TIMEZONE", "Asia/Tokyo")
+	dailyLocation, err := time.LoadLocation(dailyTimezone)
+	if err != nil {
+		env.fail("DAILY_TIMEZONE is invalid: %q", dailyTimezone)
+	}
+
+	cfg := &Config{
+		SupabaseURL:        os.Getenv("SUPABASE_URL"),
+		SupabaseAnonKey:    os.Getenv("SUPABASE_ANON_KEY"),
+		SupabaseServiceKey: os.Getenv("SUPABASE_SERVICE_ROLE_KEY"),
+		Port:               getEnv("PORT", "8088"),
+
+		SupabaseJWTSecret: os.Getenv("SUPABASE_JWT_SECRET"),
+
+		ServerReadHeaderTimeout: env.duration("SERVER_READ_HEADER_TIMEOUT", 10*time.Second),
+		ServerReadTimeout:       env.duration("SERVER_READ_TIMEOUT", 30*time.Second),
+		ServerWriteTimeout:      env.duration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		ServerIdleTimeout:       env.duration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+		ShutdownTimeout:         env.duration("SHUTDOWN_TIMEOUT", 20*time.Second),
+
+		LogFormat: getEnv("LOG_FORMAT", "json"),
+		LogLevel:  getEnv("LOG_LEVEL", "info"),
+
+		ReadinessTimeout:  env.duration("READINESS_TIMEOUT", 2*time.Second),
+		ReadinessCacheTTL: env.duration("READINESS_CACHE_TTL", 5*time.Second),
+		TrustedProxies:    env.prefixes("TRUSTED_PROXIES"),
+
+		AppEnv:              appEnv,
+		DatabaseDriver:      databaseDriver,
+		DatabaseURL:         os.Getenv("DATABASE_URL"),
+		SeedOnStart:         env.bool("SEED_ON_START", false),
+		ModeratorUserIDs:    splitList(os.Getenv("MODERATOR_USER_IDS")),
+		ReportHideThreshold: env.nonNegativeInt("REPORT_HIDE_THRESHOLD", 3),
+		ViewDedupWindow:     env.duration("VIEW_DEDUP_WINDOW", 30*time.Minute),
+		ViewFlushInterval:   env.duration("VIEW_FLUSH_INTERVAL", 10*time.Second),
+
+		PublishSchedulerInterval: env.duration("PUBLISH_SCHEDULER_INTERVAL", time.Minute),
+
+		CuratorUserIDs:    splitList(os.Getenv("CURATOR_USER_IDS")),
+		DailyNoRepeatDays: env.nonNegativeInt("DAILY_NO_REPEAT_DAYS", 30),
+		DailyLocation:     dailyLocation,
+
+		StorageDriver:        getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:      getEnv("STORAGE_LOCAL_DIR", "./static/uploads"),
+		StoragePublicBaseURL: getEnv("STORAGE_PUBLIC_BASE_URL", "/uploads"),
+		StorageBucket:        getEnv("SUPABASE_STORAGE_BUCKET", "attachments"),
+		AttachmentMaxBytes:   int64(env.positiveInt("ATTACHMENT_MAX_BYTES", 5<<20)),
+		AttachmentMaxWidth:   env.positiveInt("ATTACHMENT_MAX_WIDTH", 4096),
+		AttachmentMaxHeight:  env.positiveInt("ATTACHMENT_MAX_HEIGHT", 4096),
+
+		DuplicateWarnThreshold:   env.ratio("DUPLICATE_WARN_THRESHOLD", 0.6),
+		DuplicateRejectThreshold: env.ratio("DUPLICATE_REJECT_THRESHOLD", 0.9),
+	}
+
+	if env.err != nil {
+		return nil, env.err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// MigrateConfig はマイグレーション（server migrate）の設定
+// マイグレーションはデータベースにだけ接続するため、Supabase などの設定は求めない
+type MigrateConfig struct {
+	// DatabaseURL はマイグレーションを実行するPostgreSQLの接続文字列
+	DatabaseURL string
+}
+
+// LoadMigrate は .env と環境変数からマイグレーションの設定を読み込んで検証する
+func LoadMigrate() (*MigrateConfig, error) {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system env")
+	}
+
+	cfg := &MigrateConfig{DatabaseURL: os.Getenv("DATABASE_URL")}
+	if cfg.DatabaseURL == "" {
+		return nil, errors.New("DATABASE_URL is required to run migrations")
+	}
+	return cfg, nil
+}
+
+// Validate は設定の組み合わせが正しいかを検証する
+// テストなどで Config を直接組み立てた場合もコンテナの作成時に検証する
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch c.DatabaseDriver {
+	case "supabase", "postgres", "memory":
+	default:
+		fail("DATABASE_DRIVER must be supabase or postgres: %q", c.DatabaseDriver)
 	}
+	if c.DatabaseDriver == "memory" && c.AppEnv != "memory" {
+		fail("DATABASE_DRIVER=memory requires APP_ENV=memory")
+	}
+
+	// memory 以外では認証に Supabase Auth を使う
+	if c.DatabaseDriver != "memory" {
+		if c.SupabaseURL == "" {
+			fail("SUPABASE_URL is required")
+		}
+		if c.SupabaseServiceKey == "" {
+			fail("SUPABASE_SERVICE_ROLE_KEY is required")
+		}
+		// postgres では行レベルセキュリティを通らないため、ユーザーの特定はトークンの署名の検証だけに頼る
+		if c.SupabaseJWTSecret == "" {
+			fail("SUPABASE_JWT_SECRET is required to verify access tokens")
+		}
+	}
+	// supabase ではユーザーのトークンでの読み書き（行レベルセキュリティを通す）に anon key を使う
+	if c.DatabaseDriver == "supabase" && c.SupabaseAnonKey == "" {
+		fail("SUPABASE_ANON_KEY is required when DATABASE_DRIVER is supabase")
+	}
+	if c.DatabaseDriver == "postgres" && c.DatabaseURL == "" {
+		fail("DATABASE_URL is required when DATABASE_DRIVER is postgres")
+	}
+
+	if c.StorageDriver != "local" && c.StorageDriver != "supabase" {
+		fail("STORAGE_DRIVER must be local or supabase: %q", c.StorageDriver)
+	}
+	if c.DatabaseDriver == "memory" && c.StorageDriver == "supabase" {
+		fail("STORAGE_DRIVER must be local when APP_ENV is memory")
+	}
+
+	if c.LogFormat != "json" && c.LogFormat != "text" {
+		fail("LOG_FORMAT must be json or text: %q", c.LogFormat)
+	}
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		fail("LOG_LEVEL must be debug, info, warn or error: %q", c.LogLevel)
+	}
+
+	// 定期処理の間隔が 0 以下だとバックグラウンド処理を起動できない
+	if c.ViewFlushInterval <= 0 {
+		fail("VIEW_FLUSH_INTERVAL must be positive")
+	}
+	if c.PublishSchedulerInterval <= 0 {
+		fail("PUBLISH_SCHEDULER_INTERVAL must be positive")
+	}
+
+	if c.DuplicateWarnThreshold > c.DuplicateRejectThreshold {
+		fail("DUPLICATE_WARN_THRESHOLD must not exceed DUPLICATE_REJECT_THRESHOLD")
+	}
+
+	return errors.Join(errs...)
+}
+
+// getEnv は環境変数を読み込む（未設定の場合は既定値）
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// envReader は環境変数を型に合わせて読み込み、不正な値をまとめて記録する
+type envReader struct {
+	err error
+}
+
+// fail は不正な値をエラーとして記録する
+func (r *envReader) fail(format string, args ...interface{}) {
+	r.err = errors.Join(r.err, fmt.Errorf(format, args...))
+}
 
-	supabaseServiceKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
-	if supabaseServiceKey == "" {
-		log.Fatal("SUPABASE_SERVICE_ROLE_KEY is required")
+// positiveInt は環境変数を正の整数として読み込む（未設定の場合は既定値）
+func (r *envReader) positiveInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
 	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8088"
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		r.fail("%s must be a positive integer: %q", key, value)
+		return defaultValue
 	}
+	return n
+}
+
+// nonNegativeInt は環境変数を0以上の整数として読み込む（未設定の場合は既定値）
+func (r *envReader) nonNegativeInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		r.fail("%s must be a non-negative integer: %q", key, value)
+		return defaultValue
+	}
+	return n
+}
+
+// bool は環境変数を真偽値として読み込む（未設定の場合は既定値）
+func (r *envReader) bool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		r.fail("%s must be true or false: %q", key, value)
+		return defaultValue
+	}
+	return b
+}
+
+// ratio は環境変数を0〜1の割合として読み込む（未設定の場合は既定値）
+func (r *envReader) ratio(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 || f > 1 {
+		r.fail("%s must be a number between 0 and 1: %q", key, value)
+		return defaultValue
+	}
+	return f
+}
+
+// duration は環境変数を time.Duration として読み込む（未設定の場合は既定値）
+func (r *envReader) duration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		r.fail("%s must be a positive duration (e.g. 30s, 10m): %q", key, value)
+		return defaultValue
+	}
+	return d
+}
+
+// prefixes はカンマ区切りのCIDR（単一のIPアドレスも可）を読み込む
+func (r *envReader) prefixes(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, item := range splitList(os.Getenv(key)) {
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(item)
+			if addrErr != nil {
+				r.fail("%s must be a comma-separated list of IP addresses or CIDRs: %q", key, item)
+				continue
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
 
-	return &Config{
-		SupabaseURL:        supabaseURL,
-		SupabaseServiceKey: supabaseServiceKey,
-		Port:               port,
+// splitList はカンマ区切りの文字列を空要素を除いたスライスに変換
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
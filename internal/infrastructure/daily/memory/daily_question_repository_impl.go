@@ -0,0 +1,88 @@
+package memory
+
+// daily_question_repository_impl.goはメモリ上に保持するDailyQuestionRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/daily/entities"
+	"Shittaka_back/internal/domain/daily/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// DailyQuestionRepositoryImpl はメモリ上に保持するDailyQuestionRepositoryの実装
+type DailyQuestionRepositoryImpl struct {
+	mu      sync.RWMutex
+	dailies map[string]*entities.DailyQuestion // 日付（YYYY-MM-DD）ごと
+}
+
+// NewDailyQuestionRepository は新しいDailyQuestionRepositoryImplを作成
+func NewDailyQuestionRepository() repositories.DailyQuestionRepository {
+	return &DailyQuestionRepositoryImpl{dailies: make(map[string]*entities.DailyQuestion)}
+}
+
+// GetByDate は日付で「今日の一問」を取得
+func (r *DailyQuestionRepositoryImpl) GetByDate(ctx context.Context, date string) (*entities.DailyQuestion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	daily, ok := r.dailies[date]
+	if !ok {
+		return nil, shared.NewDomainError("NOT_FOUND", "今日の一問が見つかりません")
+	}
+	found := *daily
+	return &found, nil
+}
+
+// GetSince は指定日以降の「今日の一問」を新しい順に取得
+// 日付は YYYY-MM-DD 形式のため文字列の比較で前後を判定できる
+func (r *DailyQuestionRepositoryImpl) GetSince(ctx context.Context, date string) ([]*entities.DailyQuestion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var dailies []*entities.DailyQuestion
+	for d, daily := range r.dailies {
+		if d >= date {
+			found := *daily
+			dailies = append(dailies, &found)
+		}
+	}
+	sort.Slice(dailies, func(i, j int) bool { return dailies[i].Date > dailies[j].Date })
+	return dailies, nil
+}
+
+// Create は「今日の一問」を保存（既に同じ日付があれば何もしない）
+func (r *DailyQuestionRepositoryImpl) Create(ctx context.Context, daily *entities.DailyQuestion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dailies[daily.Date]; ok {
+		return nil
+	}
+	r.save(daily)
+	return nil
+}
+
+// Upsert は「今日の一問」を保存（既に同じ日付があれば上書きする）
+func (r *DailyQuestionRepositoryImpl) Upsert(ctx context.Context, daily *entities.DailyQuestion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.save(daily)
+	return nil
+}
+
+// save は「今日の一問」を保存する（呼び出し元でロックを取得する）
+func (r *DailyQuestionRepositoryImpl) save(daily *entities.DailyQuestion) {
+	d := *daily
+	if existing, ok := r.dailies[d.Date]; ok {
+		d.CreatedAt = existing.CreatedAt
+	} else {
+		d.CreatedAt = time.Now()
+	}
+	r.dailies[d.Date] = &d
+}
@@ -0,0 +1,89 @@
+package postgres
+
+// daily_question_repository_impl.goはPostgreSQLを直接使用したDailyQuestionRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/daily/entities"
+	"Shittaka_back/internal/domain/daily/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// dailyColumns は「今日の一問」を取得する際の列（scanDailyQuestion と同じ順序）
+const dailyColumns = `to_char(date, 'YYYY-MM-DD'), question_id, is_override, COALESCE(set_by::text, ''), created_at`
+
+// DailyQuestionRepositoryImpl はPostgreSQLを使用したDailyQuestionRepositoryの実装
+type DailyQuestionRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewDailyQuestionRepository は新しいDailyQuestionRepositoryImplを作成
+func NewDailyQuestionRepository(pool *pgxpool.Pool) repositories.DailyQuestionRepository {
+	return &DailyQuestionRepositoryImpl{pool: pool}
+}
+
+// GetByDate は日付で「今日の一問」を取得
+func (r *DailyQuestionRepositoryImpl) GetByDate(ctx context.Context, date string) (*entities.DailyQuestion, error) {
+	daily, err := scanDailyQuestion(r.pool.QueryRow(ctx, `SELECT `+dailyColumns+` FROM daily_questions WHERE date = $1::date`, date))
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "今日の一問が見つかりません")
+		}
+		return nil, err
+	}
+	return daily, nil
+}
+
+// GetSince は指定日以降の「今日の一問」を取得
+func (r *DailyQuestionRepositoryImpl) GetSince(ctx context.Context, date string) ([]*entities.DailyQuestion, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+dailyColumns+` FROM daily_questions WHERE date >= $1::date ORDER BY date DESC`, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dailies []*entities.DailyQuestion
+	for rows.Next() {
+		daily, err := scanDailyQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		dailies = append(dailies, daily)
+	}
+	return dailies, rows.Err()
+}
+
+// Create は「今日の一問」を保存（既に同じ日付があれば何もしない）
+func (r *DailyQuestionRepositoryImpl) Create(ctx context.Context, daily *entities.DailyQuestion) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO daily_questions (date, question_id, is_override, set_by)
+		VALUES ($1::date, $2, $3, NULLIF($4, '')::uuid)
+		ON CONFLICT (date) DO NOTHING`,
+		daily.Date, daily.QuestionID, daily.IsOverride, daily.SetBy)
+	return database.MapError(err)
+}
+
+// Upsert は「今日の一問」を保存（既に同じ日付があれば上書きする）
+func (r *DailyQuestionRepositoryImpl) Upsert(ctx context.Context, daily *entities.DailyQuestion) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO daily_questions (date, question_id, is_override, set_by)
+		VALUES ($1::date, $2, $3, NULLIF($4, '')::uuid)
+		ON CONFLICT (date) DO UPDATE
+		SET question_id = EXCLUDED.question_id, is_override = EXCLUDED.is_override, set_by = EXCLUDED.set_by`,
+		daily.Date, daily.QuestionID, daily.IsOverride, daily.SetBy)
+	return database.MapError(err)
+}
+
+// scanDailyQuestion は dailyColumns の順に読み込んだ行を DailyQuestion エンティティに変換
+func scanDailyQuestion(row pgx.Row) (*entities.DailyQuestion, error) {
+	d := &entities.DailyQuestion{}
+	if err := row.Scan(&d.Date, &d.QuestionID, &d.IsOverride, &d.SetBy, &d.CreatedAt); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,141 @@
+package supabase
+
+// daily_question_repository_impl.goはSupabaseを使用したDailyQuestionRepositoryの実装
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"Shittaka_back/internal/domain/daily/entities"
+	"Shittaka_back/internal/domain/daily/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// DailyQuestionRepositoryImpl はSupabaseを使用したDailyQuestionRepositoryの実装
+// 「今日の一問」はサーバーが決めて保存するため、読み書きともサービスロールキーで実行する
+type DailyQuestionRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewDailyQuestionRepository は新しいDailyQuestionRepositoryImplを作成
+func NewDailyQuestionRepository(client *postgrest.Client) repositories.DailyQuestionRepository {
+	return &DailyQuestionRepositoryImpl{client: client}
+}
+
+// GetByDate は日付で「今日の一問」を取得
+func (r *DailyQuestionRepositoryImpl) GetByDate(ctx context.Context, date string) (*entities.DailyQuestion, error) {
+	var dailyData map[string]interface{}
+	if err := r.client.From("daily_questions").AsServiceRole().Eq("date", date).Single(ctx, &dailyData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "今日の一問が見つかりません")
+		}
+		return nil, err
+	}
+
+	return mapToDailyQuestion(dailyData), nil
+}
+
+// GetSince は指定日以降の「今日の一問」を取得
+func (r *DailyQuestionRepositoryImpl) GetSince(ctx context.Context, date string) ([]*entities.DailyQuestion, error) {
+	var dailyList []map[string]interface{}
+	err := r.client.From("daily_questions").AsServiceRole().
+		Gte("date", date).
+		Order("date", false).
+		Find(ctx, &dailyList)
+	if err != nil {
+		return nil, err
+	}
+
+	dailies := make([]*entities.DailyQuestion, len(dailyList))
+	for i, dailyData := range dailyList {
+		dailies[i] = mapToDailyQuestion(dailyData)
+	}
+
+	return dailies, nil
+}
+
+// Create は「今日の一問」を保存（既に同じ日付があれば何もしない）
+func (r *DailyQuestionRepositoryImpl) Create(ctx context.Context, daily *entities.DailyQuestion) error {
+	return r.client.From("daily_questions").AsServiceRole().IgnoreDuplicates("date").Insert(ctx, dailyQuestionData(daily), nil)
+}
+
+// Upsert は「今日の一問」を保存（既に同じ日付があれば上書きする）
+func (r *DailyQuestionRepositoryImpl) Upsert(ctx context.Context, daily *entities.DailyQuestion) error {
+	return r.client.From("daily_questions").AsServiceRole().OnConflict("date").Insert(ctx, dailyQuestionData(daily), nil)
+}
+
+// dailyQuestionData は「今日の一問」を書き込み用の map に変換
+func dailyQuestionData(daily *entities.DailyQuestion) map[string]interface{} {
+	return map[string]interface{}{
+		"date":        daily.Date,
+		"question_id": daily.QuestionID,
+		"is_override": daily.IsOverride,
+		"set_by":      daily.SetBy,
+	}
+}
+
+// mapToDailyQuestion は map[string]interface{} を DailyQuestion エンティティに変換
+func mapToDailyQuestion(m map[string]interface{}) *entities.DailyQuestion {
+	return &entities.DailyQuestion{
+		Date:       getString(m, "date"),
+		QuestionID: getInt64(m, "question_id"),
+		IsOverride: getBool(m, "is_override"),
+		SetBy:      getString(m, "set_by"),
+		CreatedAt:  getTime(m, "created_at"),
+	}
+}
+
+// ヘルパー関数
+
+// getString は map から文字列を安全に取得
+func getString(m map[string]interface{}, key string) string {
+	if val, ok := m[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// getInt64 は map から int64 を安全に取得
+func getInt64(m map[string]interface{}, key string) int64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// getBool は map から bool を安全に取得
+func getBool(m map[string]interface{}, key string) bool {
+	if val, ok := m[key]; ok {
+		if b, ok := val.(bool); ok {
+			return b
+		}
+	}
+	return false
+}
+
+// getTime は map から time.Time を安全に取得
+func getTime(m map[string]interface{}, key string) time.Time {
+	if val, ok := m[key]; ok {
+		if timeStr, ok := val.(string); ok {
+			if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
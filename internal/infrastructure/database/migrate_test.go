@@ -0,0 +1,53 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_BundledAreOrderedAndReversible(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	// バージョンは1から欠番なく並び、全てに up と down がある
+	for i, m := range migrations {
+		assert.Equal(t, int64(i+1), m.Version)
+		assert.NotEmpty(t, m.Up, m.Name)
+		assert.NotEmpty(t, m.Down, m.Name)
+	}
+}
+
+func TestLoadMigrations_SortsByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0010_second.up.sql":   {Data: []byte("up 10")},
+		"m/0010_second.down.sql": {Data: []byte("down 10")},
+		"m/0002_first.up.sql":    {Data: []byte("up 2")},
+		"m/0002_first.down.sql":  {Data: []byte("down 2")},
+		"m/README.md":            {Data: []byte("ignored")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "m")
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 2, Name: "first", Up: "up 2", Down: "down 2"},
+		{Version: 10, Name: "second", Up: "up 10", Down: "down 10"},
+	}, migrations)
+}
+
+func TestLoadMigrations_RejectsInvalidFiles(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down":     {"m/0001_a.up.sql": {Data: []byte("up")}},
+		"conflicting name": {"m/0001_a.up.sql": {Data: []byte("up")}, "m/0001_b.down.sql": {Data: []byte("down")}},
+		"no direction":     {"m/0001_a.sql": {Data: []byte("up")}},
+		"no version":       {"m/create.up.sql": {Data: []byte("up")}},
+	}
+
+	for name, fsys := range cases {
+		_, err := LoadMigrations(fsys, "m")
+		assert.Error(t, err, name)
+	}
+}
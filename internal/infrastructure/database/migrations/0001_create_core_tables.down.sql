@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS answers;
+DROP TABLE IF EXISTS choices;
+DROP TABLE IF EXISTS questions;
+DROP TABLE IF EXISTS genres;
@@ -0,0 +1,45 @@
+-- 問題・選択肢・回答など、問題を出題・回答するためのテーブル（コメントなどの機能を追加する前のスキーマ）
+-- ユーザーは Supabase Auth（auth.users）で管理するため、user_id は uuid で保持する
+--
+-- 既存の Supabase プロジェクトには既にこれらのテーブルがあるため、無い場合だけ作成する
+-- 以降の機能で追加した列は 0002 で追加する
+
+CREATE TABLE IF NOT EXISTS genres (
+    id   bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name text NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS questions (
+    id              bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    genre_id        bigint NOT NULL REFERENCES genres (id) ON DELETE RESTRICT,
+    user_id         uuid NOT NULL,
+    title           text NOT NULL,
+    body            text NOT NULL DEFAULT '',
+    explanation     text,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    views           integer NOT NULL DEFAULT 0,
+    correct_count   integer NOT NULL DEFAULT 0,
+    incorrect_count integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS questions_genre_id_idx ON questions (genre_id);
+CREATE INDEX IF NOT EXISTS questions_user_id_idx ON questions (user_id);
+
+CREATE TABLE IF NOT EXISTS choices (
+    id          bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    text        text NOT NULL,
+    is_correct  boolean NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS answers (
+    id          bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id     uuid NOT NULL,
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    choice_id   bigint REFERENCES choices (id) ON DELETE SET NULL,
+    answered_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS answers_user_id_idx ON answers (user_id, question_id);
+CREATE INDEX IF NOT EXISTS answers_question_id_idx ON answers (question_id);
+CREATE INDEX IF NOT EXISTS answers_choice_id_idx ON answers (choice_id);
@@ -0,0 +1,28 @@
+ALTER TABLE answers
+    DROP COLUMN IF EXISTS question_revision,
+    DROP COLUMN IF EXISTS score,
+    DROP COLUMN IF EXISTS is_correct,
+    DROP COLUMN IF EXISTS numeric_answer,
+    DROP COLUMN IF EXISTS text_answer,
+    DROP COLUMN IF EXISTS choice_ids;
+
+DROP INDEX IF EXISTS choices_question_id_idx;
+ALTER TABLE choices DROP COLUMN IF EXISTS position;
+
+DROP TABLE IF EXISTS question_revisions;
+
+DROP INDEX IF EXISTS questions_publish_at_idx;
+DROP INDEX IF EXISTS questions_published_idx;
+ALTER TABLE questions
+    DROP COLUMN IF EXISTS shuffle_choices,
+    DROP COLUMN IF EXISTS numeric_tolerance,
+    DROP COLUMN IF EXISTS numeric_answer,
+    DROP COLUMN IF EXISTS accepted_answers,
+    DROP COLUMN IF EXISTS partial_credit,
+    DROP COLUMN IF EXISTS type,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS revision,
+    DROP COLUMN IF EXISTS is_hidden,
+    DROP COLUMN IF EXISTS body_format;
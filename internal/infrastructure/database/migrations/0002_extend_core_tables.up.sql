@@ -0,0 +1,60 @@
+-- 非表示・公開状態と予約公開・問題の種類と正解・本文の書式・改訂履歴・選択肢の表示順・回答の採点結果のための列とテーブル
+-- 既存の Supabase プロジェクトのテーブルにも適用できるよう、列とテーブルは追加するだけにする
+
+ALTER TABLE questions
+    ADD COLUMN IF NOT EXISTS body_format       text NOT NULL DEFAULT 'plain' CHECK (body_format IN ('plain', 'markdown')),
+    ADD COLUMN IF NOT EXISTS is_hidden         boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS revision          integer NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS status            text NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'archived')),
+    ADD COLUMN IF NOT EXISTS published_at      timestamptz,
+    ADD COLUMN IF NOT EXISTS publish_at        timestamptz,
+    ADD COLUMN IF NOT EXISTS type              text NOT NULL DEFAULT 'single_choice'
+                                               CHECK (type IN ('single_choice', 'multiple_select', 'true_false', 'free_text', 'numeric')),
+    ADD COLUMN IF NOT EXISTS partial_credit    boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS accepted_answers  text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS numeric_answer    double precision,
+    ADD COLUMN IF NOT EXISTS numeric_tolerance double precision NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS shuffle_choices   boolean NOT NULL DEFAULT false;
+
+-- 列を追加する前の問題は公開中として扱い、フィードの並び順・カーソルに使う公開日時を作成日時で埋める
+UPDATE questions SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+
+-- フィード・一覧（公開中の問題を新しい順に取得）
+CREATE INDEX IF NOT EXISTS questions_published_idx ON questions (published_at DESC, id DESC)
+    WHERE status = 'published' AND NOT is_hidden;
+-- 予約公開の対象（公開日時を過ぎた下書き）
+CREATE INDEX IF NOT EXISTS questions_publish_at_idx ON questions (publish_at) WHERE status = 'draft' AND publish_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS question_revisions (
+    id          bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    revision    integer NOT NULL,
+    user_id     uuid NOT NULL,
+    title       text NOT NULL,
+    body        text NOT NULL DEFAULT '',
+    explanation text,
+    diff        text,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (question_id, revision)
+);
+
+ALTER TABLE choices
+    ADD COLUMN IF NOT EXISTS position integer NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS choices_question_id_idx ON choices (question_id, position, id);
+
+ALTER TABLE answers
+    ADD COLUMN IF NOT EXISTS choice_ids        bigint[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS text_answer       text,
+    ADD COLUMN IF NOT EXISTS numeric_answer    double precision,
+    ADD COLUMN IF NOT EXISTS is_correct        boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS score             double precision NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS question_revision integer;
+
+-- 列を追加する前の回答（選択肢を1つ選ぶ形式のみ）は、選んだ選択肢から採点結果を埋める
+UPDATE answers a
+SET choice_ids = ARRAY[a.choice_id],
+    is_correct = c.is_correct,
+    score      = CASE WHEN c.is_correct THEN 1 ELSE 0 END
+FROM choices c
+WHERE c.id = a.choice_id AND a.choice_ids = '{}';
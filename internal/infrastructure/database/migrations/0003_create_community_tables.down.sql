@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS genre_follows;
+DROP TABLE IF EXISTS user_follows;
+DROP TABLE IF EXISTS question_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS daily_questions;
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS comments;
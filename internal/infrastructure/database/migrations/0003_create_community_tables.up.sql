@@ -0,0 +1,95 @@
+-- コメント・通報・「今日の一問」・添付画像・タグ・フォローのテーブル
+
+CREATE TABLE comments (
+    id          bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    user_id     uuid NOT NULL,
+    parent_id   bigint REFERENCES comments (id) ON DELETE CASCADE,
+    body        text NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now(),
+    deleted_at  timestamptz
+);
+
+CREATE INDEX comments_question_id_idx ON comments (question_id, created_at, id) WHERE parent_id IS NULL;
+CREATE INDEX comments_parent_id_idx ON comments (parent_id, created_at, id);
+
+CREATE TABLE reports (
+    id          bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    user_id     uuid NOT NULL,
+    reason      text NOT NULL CHECK (reason IN ('wrong_answer', 'offensive', 'duplicate', 'typo')),
+    comment     text,
+    status      text NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'accepted', 'rejected')),
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    resolved_at timestamptz,
+    resolved_by uuid
+);
+
+CREATE INDEX reports_status_idx ON reports (status, created_at, id);
+CREATE INDEX reports_question_id_idx ON reports (question_id, status);
+-- 同じユーザーが同じ問題に未対応の通報を重ねて作成できないようにする
+CREATE UNIQUE INDEX reports_open_user_question_idx ON reports (user_id, question_id) WHERE status = 'open';
+
+CREATE TABLE daily_questions (
+    date        date PRIMARY KEY,
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    is_override boolean NOT NULL DEFAULT false,
+    set_by      uuid,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX daily_questions_question_id_idx ON daily_questions (question_id);
+
+CREATE TABLE attachments (
+    id           bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    question_id  bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    choice_id    bigint REFERENCES choices (id) ON DELETE CASCADE,
+    user_id      uuid NOT NULL,
+    storage_key  text NOT NULL UNIQUE,
+    url          text NOT NULL,
+    content_type text NOT NULL,
+    size         bigint NOT NULL,
+    width        integer NOT NULL,
+    height       integer NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX attachments_question_id_idx ON attachments (question_id, id);
+CREATE INDEX attachments_choice_id_idx ON attachments (choice_id);
+
+CREATE TABLE tags (
+    id   bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name text NOT NULL,
+    slug text NOT NULL UNIQUE
+);
+
+-- 前方一致の検索（slug LIKE 'prefix%'）で索引を使えるようにする
+CREATE INDEX tags_slug_prefix_idx ON tags (slug text_pattern_ops);
+
+CREATE TABLE question_tags (
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    tag_id      bigint NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (question_id, tag_id)
+);
+
+CREATE INDEX question_tags_tag_id_idx ON question_tags (tag_id);
+
+CREATE TABLE user_follows (
+    follower_id uuid NOT NULL,
+    followee_id uuid NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX user_follows_followee_id_idx ON user_follows (followee_id, created_at DESC);
+
+CREATE TABLE genre_follows (
+    user_id    uuid NOT NULL,
+    genre_id   bigint NOT NULL REFERENCES genres (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, genre_id)
+);
+
+CREATE INDEX genre_follows_genre_id_idx ON genre_follows (genre_id);
@@ -0,0 +1,9 @@
+-- 閲覧数を原子的に加算する関数（Supabase実装が RPC で呼び出す）
+CREATE FUNCTION increment_question_views(question_id bigint, delta integer)
+RETURNS void
+LANGUAGE sql
+AS $$
+    UPDATE questions
+    SET views = views + increment_question_views.delta
+    WHERE id = increment_question_views.question_id;
+$$;
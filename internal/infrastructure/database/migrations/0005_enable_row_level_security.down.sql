@@ -0,0 +1,49 @@
+-- ロールと auth スキーマは Supabase が管理するものと共有しているため削除しない
+
+DROP POLICY IF EXISTS genres_select ON genres;
+DROP POLICY IF EXISTS genres_insert ON genres;
+DROP POLICY IF EXISTS questions_select ON questions;
+DROP POLICY IF EXISTS questions_insert ON questions;
+DROP POLICY IF EXISTS questions_update ON questions;
+DROP POLICY IF EXISTS questions_delete ON questions;
+DROP POLICY IF EXISTS question_revisions_select ON question_revisions;
+DROP POLICY IF EXISTS question_revisions_insert ON question_revisions;
+DROP POLICY IF EXISTS choices_select ON choices;
+DROP POLICY IF EXISTS choices_write ON choices;
+DROP POLICY IF EXISTS question_tags_select ON question_tags;
+DROP POLICY IF EXISTS question_tags_write ON question_tags;
+DROP POLICY IF EXISTS tags_select ON tags;
+DROP POLICY IF EXISTS tags_insert ON tags;
+DROP POLICY IF EXISTS answers_select ON answers;
+DROP POLICY IF EXISTS answers_insert ON answers;
+DROP POLICY IF EXISTS comments_select ON comments;
+DROP POLICY IF EXISTS comments_insert ON comments;
+DROP POLICY IF EXISTS comments_update ON comments;
+DROP POLICY IF EXISTS reports_insert ON reports;
+DROP POLICY IF EXISTS daily_questions_select ON daily_questions;
+DROP POLICY IF EXISTS attachments_insert ON attachments;
+DROP POLICY IF EXISTS attachments_delete ON attachments;
+DROP POLICY IF EXISTS user_follows_select ON user_follows;
+DROP POLICY IF EXISTS user_follows_insert ON user_follows;
+DROP POLICY IF EXISTS user_follows_delete ON user_follows;
+DROP POLICY IF EXISTS genre_follows_select ON genre_follows;
+DROP POLICY IF EXISTS genre_follows_insert ON genre_follows;
+DROP POLICY IF EXISTS genre_follows_delete ON genre_follows;
+
+ALTER TABLE genres DISABLE ROW LEVEL SECURITY;
+ALTER TABLE questions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE question_revisions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE choices DISABLE ROW LEVEL SECURITY;
+ALTER TABLE answers DISABLE ROW LEVEL SECURITY;
+ALTER TABLE comments DISABLE ROW LEVEL SECURITY;
+ALTER TABLE reports DISABLE ROW LEVEL SECURITY;
+ALTER TABLE daily_questions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE attachments DISABLE ROW LEVEL SECURITY;
+ALTER TABLE tags DISABLE ROW LEVEL SECURITY;
+ALTER TABLE question_tags DISABLE ROW LEVEL SECURITY;
+ALTER TABLE user_follows DISABLE ROW LEVEL SECURITY;
+ALTER TABLE genre_follows DISABLE ROW LEVEL SECURITY;
+
+GRANT EXECUTE ON FUNCTION increment_question_views(bigint, integer) TO PUBLIC;
+REVOKE ALL ON genres, questions, question_revisions, choices, answers, comments, reports, daily_questions,
+    attachments, tags, question_tags, user_follows, genre_follows FROM anon, authenticated, service_role;
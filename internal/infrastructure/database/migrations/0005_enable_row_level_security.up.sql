@@ -0,0 +1,138 @@
+-- 行レベルセキュリティ（Supabase の PostgREST 経由のアクセスに適用される）
+-- 公開キー（anon）とユーザーのトークン（authenticated）では、公開中で非表示でない問題と本人の問題だけを読める
+-- 正解の列（問題の accepted_answers・numeric_answer・numeric_tolerance、選択肢の is_correct）は列の権限で読めなくする
+-- 回答と改訂履歴は本人と問題の作成者だけが読める
+-- 書き込みはユーザーのトークン（authenticated）で行い、本人の行だけを操作できる
+-- アプリケーションの読み取り・管理者の操作・集計はサービスロールキー（service_role、RLS を適用しない）で行い、
+-- 誰に何を返すかはアプリケーション側で判定する
+--
+-- Supabase 以外の PostgreSQL でも適用できるように、Supabase が用意するロールと auth.uid() が無ければ作成する
+-- （テーブルの所有者は RLS の対象外のため、DATABASE_DRIVER=postgres での直接接続には影響しない）
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'anon') THEN
+        CREATE ROLE anon NOLOGIN;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'authenticated') THEN
+        CREATE ROLE authenticated NOLOGIN;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'service_role') THEN
+        CREATE ROLE service_role NOLOGIN BYPASSRLS;
+    END IF;
+END
+$$;
+
+CREATE SCHEMA IF NOT EXISTS auth;
+
+DO $$
+BEGIN
+    IF to_regprocedure('auth.uid()') IS NULL THEN
+        -- PostgREST がリクエストごとに設定するJWTの sub をユーザーIDとして返す
+        CREATE FUNCTION auth.uid() RETURNS uuid
+        LANGUAGE sql STABLE
+        AS $f$
+            SELECT nullif(coalesce(
+                current_setting('request.jwt.claim.sub', true),
+                current_setting('request.jwt.claims', true)::jsonb ->> 'sub'
+            ), '')::uuid
+        $f$;
+    END IF;
+END
+$$;
+
+GRANT USAGE ON SCHEMA auth TO anon, authenticated, service_role;
+GRANT USAGE ON SCHEMA public TO anon, authenticated, service_role;
+GRANT SELECT ON genres, comments, reports, daily_questions, attachments, tags, question_tags, user_follows, genre_follows TO anon;
+GRANT SELECT, INSERT, UPDATE, DELETE ON genres, questions, question_revisions, choices, answers, comments, reports, daily_questions,
+    attachments, tags, question_tags, user_follows, genre_follows TO authenticated, service_role;
+
+-- 正解の列を除いた列だけを読めるようにする（列を追加した場合は、読ませる列だけを改めて GRANT する）
+REVOKE SELECT ON questions, question_revisions, choices FROM authenticated;
+GRANT SELECT (id, genre_id, user_id, title, body, explanation, body_format, created_at, views, correct_count, incorrect_count,
+    is_hidden, revision, status, published_at, publish_at, type, partial_credit, shuffle_choices) ON questions TO anon, authenticated;
+GRANT SELECT (id, question_id, text, position) ON choices TO anon, authenticated;
+GRANT SELECT (id, question_id, revision, user_id, title, body, explanation, diff, created_at) ON question_revisions TO authenticated;
+GRANT EXECUTE ON FUNCTION increment_question_views(bigint, integer) TO service_role;
+REVOKE EXECUTE ON FUNCTION increment_question_views(bigint, integer) FROM PUBLIC;
+
+ALTER TABLE genres ENABLE ROW LEVEL SECURITY;
+ALTER TABLE questions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE question_revisions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE choices ENABLE ROW LEVEL SECURITY;
+ALTER TABLE answers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE reports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE daily_questions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE attachments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE question_tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_follows ENABLE ROW LEVEL SECURITY;
+ALTER TABLE genre_follows ENABLE ROW LEVEL SECURITY;
+
+-- ジャンル: 誰でも閲覧でき、ログインユーザーが作成できる
+CREATE POLICY genres_select ON genres FOR SELECT USING (true);
+CREATE POLICY genres_insert ON genres FOR INSERT TO authenticated WITH CHECK (true);
+
+-- 問題: 公開中で非表示でない問題と本人の問題を読め、作成者本人だけが作成・更新・削除できる
+CREATE POLICY questions_select ON questions FOR SELECT
+    USING ((status = 'published' AND NOT is_hidden) OR user_id = auth.uid());
+CREATE POLICY questions_insert ON questions FOR INSERT TO authenticated WITH CHECK (user_id = auth.uid());
+CREATE POLICY questions_update ON questions FOR UPDATE TO authenticated
+    USING (user_id = auth.uid()) WITH CHECK (user_id = auth.uid());
+CREATE POLICY questions_delete ON questions FOR DELETE TO authenticated USING (user_id = auth.uid());
+
+-- 改訂履歴: 改訂した本人と問題の作成者だけが読める
+CREATE POLICY question_revisions_select ON question_revisions FOR SELECT TO authenticated
+    USING (user_id = auth.uid()
+        OR EXISTS (SELECT 1 FROM questions q WHERE q.id = question_revisions.question_id AND q.user_id = auth.uid()));
+CREATE POLICY question_revisions_insert ON question_revisions FOR INSERT TO authenticated
+    WITH CHECK (user_id = auth.uid());
+
+-- 選択肢・問題のタグ: 問題の作成者だけが操作できる（選択肢は読める問題のものだけを読める）
+CREATE POLICY choices_select ON choices FOR SELECT
+    USING (EXISTS (SELECT 1 FROM questions q WHERE q.id = choices.question_id
+        AND ((q.status = 'published' AND NOT q.is_hidden) OR q.user_id = auth.uid())));
+CREATE POLICY choices_write ON choices FOR ALL TO authenticated
+    USING (EXISTS (SELECT 1 FROM questions q WHERE q.id = choices.question_id AND q.user_id = auth.uid()))
+    WITH CHECK (EXISTS (SELECT 1 FROM questions q WHERE q.id = choices.question_id AND q.user_id = auth.uid()));
+
+CREATE POLICY question_tags_select ON question_tags FOR SELECT USING (true);
+CREATE POLICY question_tags_write ON question_tags FOR ALL TO authenticated
+    USING (EXISTS (SELECT 1 FROM questions q WHERE q.id = question_tags.question_id AND q.user_id = auth.uid()))
+    WITH CHECK (EXISTS (SELECT 1 FROM questions q WHERE q.id = question_tags.question_id AND q.user_id = auth.uid()));
+
+-- タグ: 誰でも閲覧でき、ログインユーザーが作成できる
+CREATE POLICY tags_select ON tags FOR SELECT USING (true);
+CREATE POLICY tags_insert ON tags FOR INSERT TO authenticated WITH CHECK (true);
+
+-- 回答: 本人と問題の作成者だけが読め、本人として作成できる（正答率などの集計はサービスロールで行う）
+CREATE POLICY answers_select ON answers FOR SELECT TO authenticated
+    USING (user_id = auth.uid()
+        OR EXISTS (SELECT 1 FROM questions q WHERE q.id = answers.question_id AND q.user_id = auth.uid()));
+CREATE POLICY answers_insert ON answers FOR INSERT TO authenticated WITH CHECK (user_id = auth.uid());
+
+-- コメント: 本人として作成し、本人だけが更新できる（削除は deleted_at を設定する更新）
+CREATE POLICY comments_select ON comments FOR SELECT USING (true);
+CREATE POLICY comments_insert ON comments FOR INSERT TO authenticated WITH CHECK (user_id = auth.uid());
+CREATE POLICY comments_update ON comments FOR UPDATE TO authenticated
+    USING (user_id = auth.uid()) WITH CHECK (user_id = auth.uid());
+
+-- 通報: 本人として作成できる（通報キューの閲覧・対応はサービスロールで行う）
+CREATE POLICY reports_insert ON reports FOR INSERT TO authenticated WITH CHECK (user_id = auth.uid());
+
+-- 「今日の一問」: 誰でも閲覧できる（選出・指定はサービスロールで行う）
+CREATE POLICY daily_questions_select ON daily_questions FOR SELECT USING (true);
+
+-- 添付画像: 本人として作成し、本人だけが削除できる（閲覧はサービスロールで行う）
+CREATE POLICY attachments_insert ON attachments FOR INSERT TO authenticated WITH CHECK (user_id = auth.uid());
+CREATE POLICY attachments_delete ON attachments FOR DELETE TO authenticated USING (user_id = auth.uid());
+
+-- フォロー: 誰でも閲覧でき、本人のフォローだけを追加・解除できる
+CREATE POLICY user_follows_select ON user_follows FOR SELECT USING (true);
+CREATE POLICY user_follows_insert ON user_follows FOR INSERT TO authenticated WITH CHECK (follower_id = auth.uid());
+CREATE POLICY user_follows_delete ON user_follows FOR DELETE TO authenticated USING (follower_id = auth.uid());
+
+CREATE POLICY genre_follows_select ON genre_follows FOR SELECT USING (true);
+CREATE POLICY genre_follows_insert ON genre_follows FOR INSERT TO authenticated WITH CHECK (user_id = auth.uid());
+CREATE POLICY genre_follows_delete ON genre_follows FOR DELETE TO authenticated USING (user_id = auth.uid());
@@ -0,0 +1,11 @@
+ALTER TABLE genre_follows DROP CONSTRAINT IF EXISTS genre_follows_user_id_fkey;
+ALTER TABLE user_follows DROP CONSTRAINT IF EXISTS user_follows_followee_id_fkey;
+ALTER TABLE user_follows DROP CONSTRAINT IF EXISTS user_follows_follower_id_fkey;
+ALTER TABLE attachments DROP CONSTRAINT IF EXISTS attachments_user_id_fkey;
+ALTER TABLE daily_questions DROP CONSTRAINT IF EXISTS daily_questions_set_by_fkey;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_resolved_by_fkey;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_user_id_fkey;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS comments_user_id_fkey;
+ALTER TABLE answers DROP CONSTRAINT IF EXISTS answers_user_id_fkey;
+ALTER TABLE question_revisions DROP CONSTRAINT IF EXISTS question_revisions_user_id_fkey;
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS questions_user_id_fkey;
@@ -0,0 +1,37 @@
+-- Supabase Auth のユーザー（auth.users）への外部キー
+-- auth.users が無い PostgreSQL（DATABASE_DRIVER=postgres での単体の開発環境など）では何もしない
+
+DO $$
+BEGIN
+    IF to_regclass('auth.users') IS NULL THEN
+        RETURN;
+    END IF;
+
+    -- 既存の Supabase プロジェクトで問題・回答のテーブルに同じ名前の外部キーがある場合は作り直す
+    ALTER TABLE questions DROP CONSTRAINT IF EXISTS questions_user_id_fkey;
+    ALTER TABLE answers DROP CONSTRAINT IF EXISTS answers_user_id_fkey;
+
+    ALTER TABLE questions ADD CONSTRAINT questions_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE question_revisions ADD CONSTRAINT question_revisions_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE answers ADD CONSTRAINT answers_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE comments ADD CONSTRAINT comments_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE reports ADD CONSTRAINT reports_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE reports ADD CONSTRAINT reports_resolved_by_fkey
+        FOREIGN KEY (resolved_by) REFERENCES auth.users (id) ON DELETE SET NULL;
+    ALTER TABLE daily_questions ADD CONSTRAINT daily_questions_set_by_fkey
+        FOREIGN KEY (set_by) REFERENCES auth.users (id) ON DELETE SET NULL;
+    ALTER TABLE attachments ADD CONSTRAINT attachments_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE user_follows ADD CONSTRAINT user_follows_follower_id_fkey
+        FOREIGN KEY (follower_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE user_follows ADD CONSTRAINT user_follows_followee_id_fkey
+        FOREIGN KEY (followee_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+    ALTER TABLE genre_follows ADD CONSTRAINT genre_follows_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+END
+$$;
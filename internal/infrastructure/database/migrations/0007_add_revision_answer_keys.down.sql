@@ -0,0 +1,6 @@
+ALTER TABLE question_revisions
+    DROP COLUMN IF EXISTS numeric_tolerance,
+    DROP COLUMN IF EXISTS numeric_answer,
+    DROP COLUMN IF EXISTS accepted_answers,
+    DROP COLUMN IF EXISTS partial_credit,
+    DROP COLUMN IF EXISTS type;
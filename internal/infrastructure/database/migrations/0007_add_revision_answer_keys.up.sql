@@ -0,0 +1,10 @@
+-- リビジョンに問題の種類と正解の情報を記録し、リビジョンを戻すときに復元できるようにする
+-- 既存のリビジョンは type が NULL のままとし、戻すときは現在の正解の情報を使う
+
+ALTER TABLE question_revisions
+    ADD COLUMN type              text
+                                 CHECK (type IN ('single_choice', 'multiple_select', 'true_false', 'free_text', 'numeric')),
+    ADD COLUMN partial_credit    boolean NOT NULL DEFAULT false,
+    ADD COLUMN accepted_answers  text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN numeric_answer    double precision,
+    ADD COLUMN numeric_tolerance double precision NOT NULL DEFAULT 0;
@@ -0,0 +1,32 @@
+-- 問題のブックマーク（本人だけが閲覧・追加・解除できる）
+
+CREATE TABLE bookmarks (
+    user_id     uuid NOT NULL,
+    question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, question_id)
+);
+
+-- 一覧（ユーザーのブックマークを新しい順に取得）
+CREATE INDEX bookmarks_user_id_created_at_idx ON bookmarks (user_id, created_at DESC);
+
+-- Supabase Auth のユーザーへの外部キー（auth.users が無い PostgreSQL では付けない）
+DO $$
+BEGIN
+    IF to_regclass('auth.users') IS NULL THEN
+        RETURN;
+    END IF;
+
+    ALTER TABLE bookmarks ADD CONSTRAINT bookmarks_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE;
+END
+$$;
+
+-- 公開キー（anon）には読ませない
+GRANT SELECT, INSERT, DELETE ON bookmarks TO authenticated, service_role;
+
+ALTER TABLE bookmarks ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY bookmarks_select ON bookmarks FOR SELECT TO authenticated USING (user_id = auth.uid());
+CREATE POLICY bookmarks_insert ON bookmarks FOR INSERT TO authenticated WITH CHECK (user_id = auth.uid());
+CREATE POLICY bookmarks_delete ON bookmarks FOR DELETE TO authenticated USING (user_id = auth.uid());
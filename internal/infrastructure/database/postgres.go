@@ -0,0 +1,96 @@
+package database
+
+// postgres.goはPostgreSQLへ直接接続するための共通処理を定義
+// Supabaseを使わずに動かす場合（DATABASE_DRIVER=postgres）に各リポジトリが使う
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// Querier は接続プールとトランザクションのどちらでもクエリを実行できるようにするインターフェース
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// DB はクエリの実行に加えてトランザクションを開始できるインターフェース
+// 接続プールとトランザクションのどちらも満たし、トランザクション内で開始した場合はセーブポイントになる
+// リポジトリはこれを受け取ることで、ユニットオブワークのトランザクションにも参加できる
+type DB interface {
+	Querier
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Open は接続プールを作成し、接続できることを確認する
+func Open(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DATABASE_URL: %w", err)
+	}
+	poolConfig.MaxConnIdleTime = 5 * time.Minute
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return pool, nil
+}
+
+// WithTx は fn をトランザクション内で実行する
+// fn がエラーを返した場合はロールバックし、そうでなければコミットする
+// db が既にトランザクションの場合はセーブポイントを使うため、外側のトランザクションに含まれる
+func WithTx(ctx context.Context, db DB, fn func(tx pgx.Tx) error) error {
+	return pgx.BeginFunc(ctx, db, fn)
+}
+
+// MapError はPostgreSQLのエラーのうち意味の分かるものをドメインエラーに変換する
+// Supabase実装（PostgRESTのエラー）と同じコードに変換し、ハンドラーでの扱いをそろえる
+func MapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case "23505":
+		// 一意制約違反
+		return shared.NewDomainError("CONFLICT", "既に存在します")
+	case "42501":
+		// 権限不足
+		return shared.NewDomainError("FORBIDDEN", "この操作を行う権限がありません")
+	}
+	return err
+}
+
+// IsNoRows は err が該当する行が無いことを表すかどうかを判定する
+func IsNoRows(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows)
+}
+
+// Args はプレースホルダー（$1, $2, ...）と引数を順に組み立てる
+// 条件によって WHERE 句が変わるクエリで使う
+type Args []any
+
+// Add は引数を追加し、対応するプレースホルダーを返す
+func (a *Args) Add(value any) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
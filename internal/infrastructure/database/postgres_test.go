@@ -0,0 +1,23 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+// 接続プールとトランザクションのどちらもリポジトリに渡せる
+var (
+	_ DB = (*pgxpool.Pool)(nil)
+	_ DB = pgx.Tx(nil)
+)
+
+func TestArgs_Add(t *testing.T) {
+	var args Args
+
+	assert.Equal(t, "$1", args.Add("user-1"))
+	assert.Equal(t, "$2", args.Add(int64(3)))
+	assert.Equal(t, Args{"user-1", int64(3)}, args)
+}
@@ -3,34 +3,290 @@ package di
 // container.goは依存関係のコンテナを定義
 // コンンテナとは、依存関係の解決を行う
 // 必要な部品を正しい順で作って配線する工場
+// 全ての部品は検証済みの設定（config.Config）一つから作り、環境変数は直接読まない
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+
 	"Shittaka_back/internal/application/auth/usecases"
+	questionUsecases "Shittaka_back/internal/application/question/usecases"
+	attachmentRepositories "Shittaka_back/internal/domain/attachment/repositories"
 	"Shittaka_back/internal/domain/auth/services"
-	"Shittaka_back/internal/infrastructure/auth/supabase"
 	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/infrastructure/logging"
+	"Shittaka_back/internal/infrastructure/postgrest"
+	"Shittaka_back/internal/infrastructure/seed"
 	"Shittaka_back/internal/presentation/http/handlers"
+	"Shittaka_back/internal/presentation/http/middleware"
+	"Shittaka_back/internal/presentation/http/router"
 )
 
+// Handlers は各機能のHTTPハンドラー一式
+type Handlers struct {
+	Auth       *handlers.AuthHandler
+	Genre      *handlers.GenreHandler
+	Question   *handlers.QuestionHandler
+	Answer     *handlers.AnswerHandler
+	Choice     *handlers.ChoiceHandler
+	Comment    *handlers.CommentHandler
+	Report     *handlers.ReportHandler
+	Daily      *handlers.DailyHandler
+	Attachment *handlers.AttachmentHandler
+	Tag        *handlers.TagHandler
+	User       *handlers.UserHandler
+	Follow     *handlers.FollowHandler
+	Bookmark   *handlers.BookmarkHandler
+	Health     *handlers.HealthHandler
+}
+
+// Hook は起動時・終了時に実行する処理
+type Hook func(ctx context.Context) error
+
 // Container は依存関係のコンテナ
 type Container struct {
-	Config      *config.Config
-	AuthHandler *handlers.AuthHandler
+	Config       *config.Config
+	Repositories *Repositories
+	Handlers     Handlers
+
+	// HTTPClient はSupabaseへの呼び出しで共有するHTTPクライアント
+	HTTPClient *http.Client
+
+	// Logger はリクエストのログを出力するロガー
+	Logger *slog.Logger
+
+	// Storage は添付画像のストレージ
+	Storage attachmentRepositories.FileStorage
+
+	// ViewCounter と PublishScheduler は Start でバックグラウンドに起動する
+	ViewCounter      *questionUsecases.ViewCounter
+	PublishScheduler *questionUsecases.PublishScheduler
+
+	startHooks    []Hook
+	shutdownHooks []Hook
+
+	// stopWorkers はバックグラウンド処理を止める（Start 前は nil）
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
+}
+
+// options は New で差し替えられる依存関係
+type options struct {
+	repos      *Repositories
+	httpClient *http.Client
+	storage    attachmentRepositories.FileStorage
+	logger     *slog.Logger
+}
+
+// Option は New で依存関係を差し替える
+type Option func(*options)
+
+// WithRepositories は設定から作る代わりに指定したリポジトリ一式を使う（テスト用）
+// 指定したリポジトリは Shutdown で閉じる
+func WithRepositories(repos *Repositories) Option {
+	return func(o *options) {
+		o.repos = repos
+	}
 }
 
-// NewContainer は新しいコンテナを作成
-func NewContainer() *Container {
-	// 設定を読み込み
-	cfg := config.LoadConfig()
+// WithHTTPClient はSupabaseへの呼び出しに指定したHTTPクライアントを使う
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithLogger はリクエストのログを指定したロガーに出力する（省略時は slog.Default）
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithFileStorage は設定から作る代わりに指定した添付画像のストレージを使う（テスト用）
+func WithFileStorage(storage attachmentRepositories.FileStorage) Option {
+	return func(o *options) {
+		o.storage = storage
+	}
+}
+
+// New は設定を検証し、全ての機能を配線したコンテナを作成
+func New(cfg *config.Config, opts ...Option) (*Container, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.httpClient == nil {
+		// Supabaseへの呼び出しには受け付けたリクエストのIDを付ける
+		o.httpClient = postgrest.NewHTTPClient()
+		o.httpClient.Transport = logging.NewRequestIDTransport(o.httpClient.Transport, middleware.RequestIDFromContext)
+	}
+	if o.logger == nil {
+		o.logger = slog.Default()
+	}
+
+	// データの保存先に応じたリポジトリを作成
+	repos := o.repos
+	if repos == nil {
+		var err error
+		if repos, err = NewRepositories(cfg, o.httpClient); err != nil {
+			return nil, fmt.Errorf("failed to initialize repositories: %w", err)
+		}
+	}
+	storage := o.storage
+	if storage == nil {
+		storage = newFileStorage(cfg, o.httpClient)
+	}
+
+	// 閲覧数は問題と「今日の一問」で同じカウンターに集計する
+	viewCounter := newViewCounter(cfg, repos)
+
+	// 全てのハンドラーで同じ方法でアクセストークンを検証し、閲覧者を識別する
+	auth := handlers.NewAuthenticator(repos.Tokens, cfg.TrustedProxies)
+
+	return &Container{
+		Config:       cfg,
+		Repositories: repos,
+		HTTPClient:   o.httpClient,
+		Logger:       o.logger,
+		Storage:      storage,
+		Handlers: Handlers{
+			Auth:       newAuthHandler(repos),
+			Genre:      newGenreHandler(repos, auth),
+			Question:   newQuestionHandler(cfg, repos, viewCounter, auth),
+			Answer:     newAnswerHandler(repos, auth),
+			Choice:     newChoiceHandler(cfg, repos, storage, auth),
+			Comment:    newCommentHandler(repos, auth),
+			Report:     newReportHandler(cfg, repos, auth),
+			Daily:      newDailyHandler(cfg, repos, viewCounter, auth),
+			Attachment: newAttachmentHandler(cfg, repos, storage, auth),
+			Tag:        newTagHandler(repos),
+			User:       newUserHandler(repos),
+			Follow:     newFollowHandler(repos, auth),
+			Bookmark:   newBookmarkHandler(repos, auth),
+			Health:     newHealthHandler(cfg, repos, o.httpClient),
+		},
+		ViewCounter:      viewCounter,
+		PublishScheduler: newPublishScheduler(repos),
+	}, nil
+}
 
+// newAuthHandler は認証機能の依存関係を構築し、ハンドラーを返す
+func newAuthHandler(repos *Repositories) *handlers.AuthHandler {
 	// 依存関係を構築（外側から内側へ）
-	userRepo := supabase.NewUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(repos.Users)
 	authUsecase := usecases.NewAuthUsecase(authService)
-	authHandler := handlers.NewAuthHandler(authUsecase)
+	return handlers.NewAuthHandler(authUsecase)
+}
 
-	return &Container{
-		Config:      cfg,
-		AuthHandler: authHandler,
+// Router は全てのエンドポイントを登録し、リクエストIDの付与とログ出力を行うルーターを返す
+func (c *Container) Router() http.Handler {
+	h := c.Handlers
+	mux := router.SetupRoutes(h.Auth, h.Genre, h.Question, h.Answer, h.Choice, h.Comment, h.Report, h.Daily, h.Attachment, h.Tag, h.User, h.Follow, h.Bookmark, h.Health)
+	return middleware.RequestLogger(c.Logger)(mux)
+}
+
+// OnStart は Start で実行する処理を登録する（登録順に実行する）
+func (c *Container) OnStart(hook Hook) {
+	c.startHooks = append(c.startHooks, hook)
+}
+
+// OnShutdown は Shutdown で実行する処理を登録する（登録と逆の順に実行する）
+func (c *Container) OnShutdown(hook Hook) {
+	c.shutdownHooks = append(c.shutdownHooks, hook)
+}
+
+// Start は起動時の確認と登録された処理を実行し、バックグラウンド処理を起動する
+// 確認に失敗した場合はリクエストを受け付ける前にエラーを返す
+// バックグラウンド処理は ctx が終了するか Shutdown を呼ぶと止まる
+func (c *Container) Start(ctx context.Context) error {
+	if err := c.Repositories.Ping(ctx); err != nil {
+		return fmt.Errorf("database is not reachable: %w", err)
 	}
+	if c.Config.StorageDriver == "local" {
+		if err := os.MkdirAll(c.Config.StorageLocalDir, 0o755); err != nil {
+			return fmt.Errorf("failed to prepare storage directory: %w", err)
+		}
+	}
+
+	for _, hook := range c.startHooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+
+	// サンプルデータを投入する（投入済みのデータは作成しない）
+	if c.Config.SeedOnStart {
+		fixtures, err := seed.LoadFixtures()
+		if err != nil {
+			return err
+		}
+		result, err := NewSeeder(c.Config, c.Repositories).Run(ctx, fixtures)
+		if err != nil {
+			return fmt.Errorf("seeding failed: %w", err)
+		}
+		log.Printf("Seeded sample data: %d users, %d questions, %d answers created", result.Users, result.Questions, result.Answers)
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	c.stopWorkers = cancel
+
+	// 閲覧数を定期的にまとめて書き込む
+	c.runWorker(func() { c.ViewCounter.Run(workerCtx, c.Config.ViewFlushInterval) })
+
+	// 予約公開の日時を過ぎた問題を定期的に公開する
+	c.runWorker(func() { c.PublishScheduler.Run(workerCtx, c.Config.PublishSchedulerInterval) })
+
+	return nil
+}
+
+// runWorker はバックグラウンド処理を起動し、Shutdown で終了を待てるようにする
+func (c *Container) runWorker(run func()) {
+	c.workers.Add(1)
+	go func() {
+		defer c.workers.Done()
+		run()
+	}()
+}
+
+// Shutdown はバックグラウンド処理を止め（閲覧数は残りを書き込む）、登録された処理を実行して接続を閉じる
+// ctx の期限までにバックグラウンド処理が終わらない場合も、残りの終了処理は行う
+func (c *Container) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	if c.stopWorkers != nil {
+		c.stopWorkers()
+
+		done := make(chan struct{})
+		go func() {
+			c.workers.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("background workers did not stop: %w", ctx.Err()))
+		}
+	}
+
+	for i := len(c.shutdownHooks) - 1; i >= 0; i-- {
+		if err := c.shutdownHooks[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	c.Repositories.Close()
+	c.HTTPClient.CloseIdleConnections()
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,39 @@
+package di
+
+// container_attachments.goは添付画像機能の依存関係配線を定義
+
+import (
+	"net/http"
+
+	attachmentUsecases "Shittaka_back/internal/application/attachment/usecases"
+	attachmentRepositories "Shittaka_back/internal/domain/attachment/repositories"
+	attachmentServices "Shittaka_back/internal/domain/attachment/services"
+	attachmentLocal "Shittaka_back/internal/infrastructure/attachment/local"
+	attachmentSupabase "Shittaka_back/internal/infrastructure/attachment/supabase"
+	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newAttachmentHandler は添付画像機能の依存関係を構築し、ハンドラーを返す
+func newAttachmentHandler(cfg *config.Config, repos *Repositories, storage attachmentRepositories.FileStorage, auth *handlers.Authenticator) *handlers.AttachmentHandler {
+	return handlers.NewAttachmentHandler(newAttachmentUsecase(cfg, repos, storage), auth)
+}
+
+// newAttachmentUsecase は添付画像ユースケースを作成
+func newAttachmentUsecase(cfg *config.Config, repos *Repositories, storage attachmentRepositories.FileStorage) *attachmentUsecases.AttachmentUsecase {
+	limits := attachmentServices.ImageLimits{
+		MaxBytes:  cfg.AttachmentMaxBytes,
+		MaxWidth:  cfg.AttachmentMaxWidth,
+		MaxHeight: cfg.AttachmentMaxHeight,
+	}
+
+	return attachmentUsecases.NewAttachmentUsecase(repos.Attachments, storage, repos.Questions, repos.Choices, limits)
+}
+
+// newFileStorage は設定に応じた添付画像のストレージを作成
+func newFileStorage(cfg *config.Config, httpClient *http.Client) attachmentRepositories.FileStorage {
+	if cfg.StorageDriver == "supabase" {
+		return attachmentSupabase.NewStorage(cfg, httpClient)
+	}
+	return attachmentLocal.NewStorage(cfg.StorageLocalDir, cfg.StoragePublicBaseURL)
+}
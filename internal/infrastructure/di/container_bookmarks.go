@@ -0,0 +1,17 @@
+package di
+
+// container_bookmarks.goはブックマーク機能の依存関係配線を定義
+
+import (
+	bookmarkUsecases "Shittaka_back/internal/application/bookmark/usecases"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newBookmarkHandler はブックマーク機能の依存関係を構築し、ハンドラーを返す
+func newBookmarkHandler(repos *Repositories, auth *handlers.Authenticator) *handlers.BookmarkHandler {
+	// ユースケース
+	usecase := bookmarkUsecases.NewBookmarkUsecase(repos.Bookmarks, repos.Questions)
+
+	// ハンドラー
+	return handlers.NewBookmarkHandler(usecase, auth)
+}
@@ -3,19 +3,17 @@ package di
 // container_choices.goは選択肢機能の依存関係配線を定義
 
 import (
+	attachmentRepositories "Shittaka_back/internal/domain/attachment/repositories"
 	"Shittaka_back/internal/domain/choices/services"
-	choiceSupabase "Shittaka_back/internal/infrastructure/choice/supabase"
+	"Shittaka_back/internal/infrastructure/config"
 	"Shittaka_back/internal/presentation/http/handlers"
 )
 
-// NewChoiceHandler は選択肢機能の依存関係を構築し、ハンドラーを返す
-func NewChoiceHandler() *handlers.ChoiceHandler {
-	// リポジトリ（Supabase HTTP実装）
-	choiceRepo := choiceSupabase.NewChoiceRepository()
-
+// newChoiceHandler は選択肢機能の依存関係を構築し、ハンドラーを返す
+func newChoiceHandler(cfg *config.Config, repos *Repositories, storage attachmentRepositories.FileStorage, auth *handlers.Authenticator) *handlers.ChoiceHandler {
 	// サービス
-	choiceService := services.NewChoiceService(choiceRepo)
+	choiceService := services.NewChoiceService(repos.Choices, repos.Questions)
 
 	// ハンドラー
-	return handlers.NewChoiceHandler(choiceService)
-}
\ No newline at end of file
+	return handlers.NewChoiceHandler(choiceService, newAttachmentUsecase(cfg, repos, storage), auth)
+}
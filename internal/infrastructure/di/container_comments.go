@@ -0,0 +1,17 @@
+package di
+
+// container_comments.goはコメント機能の依存関係配線を定義
+
+import (
+	commentUsecases "Shittaka_back/internal/application/comment/usecases"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newCommentHandler はコメント機能の依存関係を構築し、ハンドラーを返す
+func newCommentHandler(repos *Repositories, auth *handlers.Authenticator) *handlers.CommentHandler {
+	// ユースケース
+	usecase := commentUsecases.NewCommentUsecase(repos.Comments, repos.Answers, repos.Questions)
+
+	// ハンドラー
+	return handlers.NewCommentHandler(usecase, auth)
+}
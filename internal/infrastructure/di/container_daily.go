@@ -0,0 +1,20 @@
+package di
+
+// container_daily.goは「今日の一問」機能の依存関係配線を定義
+
+import (
+	dailyUsecases "Shittaka_back/internal/application/daily/usecases"
+	questionUsecases "Shittaka_back/internal/application/question/usecases"
+	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newDailyHandler は「今日の一問」機能の依存関係を構築し、ハンドラーを返す
+func newDailyHandler(cfg *config.Config, repos *Repositories, viewCounter *questionUsecases.ViewCounter, auth *handlers.Authenticator) *handlers.DailyHandler {
+	// ユースケース（問題の作成は行わないため重複チェックは不要）
+	questionUsecase := questionUsecases.NewQuestionUsecase(repos.Questions, repos.Revisions, repos.Choices, repos.Attachments, repos.Tags, repos.QuestionUnitOfWork, viewCounter, nil)
+	usecase := dailyUsecases.NewDailyQuestionUsecase(repos.Daily, repos.Questions, questionUsecase, cfg.CuratorUserIDs, cfg.DailyNoRepeatDays, cfg.DailyLocation)
+
+	// ハンドラー
+	return handlers.NewDailyHandler(usecase, auth)
+}
@@ -0,0 +1,17 @@
+package di
+
+// container_follows.goはフォロー機能の依存関係配線を定義
+
+import (
+	followUsecases "Shittaka_back/internal/application/follow/usecases"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newFollowHandler はフォロー機能の依存関係を構築し、ハンドラーを返す
+func newFollowHandler(repos *Repositories, auth *handlers.Authenticator) *handlers.FollowHandler {
+	// ユースケース
+	usecase := followUsecases.NewFollowUsecase(repos.Follows, repos.Users, repos.Genres)
+
+	// ハンドラー
+	return handlers.NewFollowHandler(usecase, auth)
+}
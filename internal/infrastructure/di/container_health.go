@@ -0,0 +1,42 @@
+package di
+
+// container_health.goは死活監視・準備状態の確認の依存関係配線を定義
+
+import (
+	"net/http"
+	"strings"
+
+	healthUsecases "Shittaka_back/internal/application/health/usecases"
+	healthRepositories "Shittaka_back/internal/domain/health/repositories"
+	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/infrastructure/health"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newHealthHandler は死活監視・準備状態の確認の依存関係を構築し、ハンドラーを返す
+func newHealthHandler(cfg *config.Config, repos *Repositories, httpClient *http.Client) *handlers.HealthHandler {
+	// ユースケース
+	usecase := healthUsecases.NewReadinessUsecase(newDependencyCheckers(cfg, repos, httpClient), cfg.ReadinessTimeout, cfg.ReadinessCacheTTL)
+
+	// ハンドラー
+	return handlers.NewHealthHandler(usecase)
+}
+
+// newDependencyCheckers はデータの保存先に応じて確認する依存先を作成
+// memory の場合は外部の依存先が無いため常に準備完了になる
+func newDependencyCheckers(cfg *config.Config, repos *Repositories, httpClient *http.Client) []healthRepositories.DependencyChecker {
+	baseURL := strings.TrimSuffix(cfg.SupabaseURL, "/")
+
+	var checkers []healthRepositories.DependencyChecker
+	switch cfg.DatabaseDriver {
+	case "supabase":
+		checkers = append(checkers, health.NewHTTPChecker("postgrest", baseURL+"/rest/v1/", cfg.SupabaseServiceKey, httpClient))
+	case "postgres":
+		checkers = append(checkers, health.NewPingChecker("postgres", repos.Ping))
+	}
+	// 認証は memory 以外では保存先によらず Supabase Auth を使う
+	if cfg.DatabaseDriver != "memory" {
+		checkers = append(checkers, health.NewHTTPChecker("gotrue", baseURL+"/auth/v1/health", cfg.SupabaseServiceKey, httpClient))
+	}
+	return checkers
+}
@@ -1,19 +1,36 @@
 package di
 
 import (
+	genreUsecases "Shittaka_back/internal/application/genre/usecases"
 	questionUsecases "Shittaka_back/internal/application/question/usecases"
-	questionSupabase "Shittaka_back/internal/infrastructure/question/supabase"
+	questionServices "Shittaka_back/internal/domain/question/services"
+	"Shittaka_back/internal/infrastructure/config"
 	"Shittaka_back/internal/presentation/http/handlers"
 )
 
-// NewQuestionHandler は問題機能の依存関係を構築し、ハンドラーを返す
-func NewQuestionHandler() *handlers.QuestionHandler {
-	// リポジトリ（Supabase 実装）
-	questionRepo := questionSupabase.NewQuestionRepository()
+// newViewCounter は閲覧数カウンターを作成
+// 返り値の Run をサーバープロセス内で起動して定期的に書き込む
+func newViewCounter(cfg *config.Config, repos *Repositories) *questionUsecases.ViewCounter {
+	return questionUsecases.NewViewCounter(repos.Questions, cfg.ViewDedupWindow)
+}
+
+// newQuestionHandler は問題機能の依存関係を構築し、ハンドラーを返す
+func newQuestionHandler(cfg *config.Config, repos *Repositories, viewCounter *questionUsecases.ViewCounter, auth *handlers.Authenticator) *handlers.QuestionHandler {
+	// ドメインサービス
+	duplicates := questionServices.NewDuplicateChecker(cfg.DuplicateWarnThreshold, cfg.DuplicateRejectThreshold)
 
 	// ユースケース
-	usecase := questionUsecases.NewQuestionUsecase(questionRepo)
+	usecase := questionUsecases.NewQuestionUsecase(repos.Questions, repos.Revisions, repos.Choices, repos.Attachments, repos.Tags, repos.QuestionUnitOfWork, viewCounter, duplicates)
+	importUsecase := questionUsecases.NewQuestionImportUsecase(repos.Questions, repos.Revisions, repos.Choices, repos.QuestionUnitOfWork, genreUsecases.NewGenreUsecase(repos.Genres))
+	exportUsecase := questionUsecases.NewQuestionExportUsecase(repos.Questions, repos.Choices, repos.Genres, repos.Bookmarks)
+	feedUsecase := questionUsecases.NewQuestionFeedUsecase(repos.Questions, repos.Follows, usecase)
 
 	// ハンドラー
-	return handlers.NewQuestionHandler(usecase)
-}
\ No newline at end of file
+	return handlers.NewQuestionHandler(usecase, importUsecase, exportUsecase, feedUsecase, auth)
+}
+
+// newPublishScheduler は予約公開スケジューラーを作成
+// 返り値の Run をサーバープロセス内で起動して定期的に公開する
+func newPublishScheduler(repos *Repositories) *questionUsecases.PublishScheduler {
+	return questionUsecases.NewPublishScheduler(repos.Questions, repos.Choices)
+}
@@ -0,0 +1,18 @@
+package di
+
+// container_reports.goは通報機能の依存関係配線を定義
+
+import (
+	reportUsecases "Shittaka_back/internal/application/report/usecases"
+	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newReportHandler は通報機能の依存関係を構築し、ハンドラーを返す
+func newReportHandler(cfg *config.Config, repos *Repositories, auth *handlers.Authenticator) *handlers.ReportHandler {
+	// ユースケース
+	usecase := reportUsecases.NewReportUsecase(repos.Reports, repos.Questions, cfg.ModeratorUserIDs, cfg.ReportHideThreshold)
+
+	// ハンドラー
+	return handlers.NewReportHandler(usecase, auth)
+}
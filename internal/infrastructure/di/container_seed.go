@@ -0,0 +1,22 @@
+package di
+
+// container_seed.goはサンプルデータ投入の依存関係配線を定義
+
+import (
+	answerUsecases "Shittaka_back/internal/application/answer/usecases"
+	genreUsecases "Shittaka_back/internal/application/genre/usecases"
+	questionUsecases "Shittaka_back/internal/application/question/usecases"
+	questionServices "Shittaka_back/internal/domain/question/services"
+	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/infrastructure/seed"
+)
+
+// NewSeeder はサンプルデータを投入するSeederを作成
+// 問題・回答はAPIと同じユースケースを通して作成する（閲覧数は扱わないためカウンターは不要）
+func NewSeeder(cfg *config.Config, repos *Repositories) *seed.Seeder {
+	duplicates := questionServices.NewDuplicateChecker(cfg.DuplicateWarnThreshold, cfg.DuplicateRejectThreshold)
+	questionUsecase := questionUsecases.NewQuestionUsecase(repos.Questions, repos.Revisions, repos.Choices, repos.Attachments, repos.Tags, repos.QuestionUnitOfWork, nil, duplicates)
+	answerUsecase := answerUsecases.NewAnswerUsecase(repos.Answers, repos.Questions, repos.Choices)
+
+	return seed.NewSeeder(repos.Users, genreUsecases.NewGenreUsecase(repos.Genres), questionUsecase, answerUsecase, repos.Questions, repos.Choices, repos.Answers)
+}
@@ -0,0 +1,17 @@
+package di
+
+// container_tags.goはタグ機能の依存関係配線を定義
+
+import (
+	tagUsecases "Shittaka_back/internal/application/tag/usecases"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newTagHandler はタグ機能の依存関係を構築し、ハンドラーを返す
+func newTagHandler(repos *Repositories) *handlers.TagHandler {
+	// ユースケース
+	usecase := tagUsecases.NewTagUsecase(repos.Tags)
+
+	// ハンドラー
+	return handlers.NewTagHandler(usecase)
+}
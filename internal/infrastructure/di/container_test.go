@@ -0,0 +1,232 @@
+package di
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Shittaka_back/internal/infrastructure/config"
+)
+
+// newTestConfig は外部サービスに接続しない設定を作成
+func newTestConfig(t *testing.T) *config.Config {
+	return &config.Config{
+		AppEnv:                   "memory",
+		DatabaseDriver:           "memory",
+		StorageDriver:            "local",
+		StorageLocalDir:          t.TempDir(),
+		StoragePublicBaseURL:     "/uploads",
+		ReportHideThreshold:      3,
+		ViewDedupWindow:          time.Minute,
+		ViewFlushInterval:        time.Hour,
+		PublishSchedulerInterval: time.Hour,
+		DailyLocation:            time.UTC,
+		AttachmentMaxBytes:       1 << 20,
+		AttachmentMaxWidth:       1024,
+		AttachmentMaxHeight:      1024,
+		DuplicateWarnThreshold:   0.6,
+		DuplicateRejectThreshold: 0.9,
+		LogFormat:                "json",
+		LogLevel:                 "info",
+		ReadinessTimeout:         time.Second,
+		ReadinessCacheTTL:        time.Second,
+	}
+}
+
+func TestNew_ServesFullAPIWithInjectedRepositories(t *testing.T) {
+	container, err := New(newTestConfig(t), WithRepositories(NewMemoryRepositories()))
+	require.NoError(t, err)
+	require.NoError(t, container.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, container.Shutdown(context.Background()))
+	}()
+
+	server := httptest.NewServer(container.Router())
+	defer server.Close()
+
+	post := func(path, token string, body interface{}) *http.Response {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewReader(data))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	credentials := map[string]string{"email": "hanako@example.com", "password": "password123", "username": "hanako"}
+	resp := post("/api/auth/signup", "", credentials)
+	resp.Body.Close()
+	require.Less(t, resp.StatusCode, 300)
+
+	resp = post("/api/auth/login", "", credentials)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var login struct {
+		Token string `json:"token"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&login))
+	resp.Body.Close()
+	require.NotEmpty(t, login.Token)
+
+	resp = post("/api/genres", login.Token, map[string]string{"name": "歴史"})
+	resp.Body.Close()
+	assert.Less(t, resp.StatusCode, 300)
+
+	resp, err = server.Client().Get(server.URL + "/api/genres")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// memory には外部の依存先が無いため準備完了になる
+	resp, err = server.Client().Get(server.URL + "/readyz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNew_RejectsTokensWithoutValidSignature(t *testing.T) {
+	container, err := New(newTestConfig(t), WithRepositories(NewMemoryRepositories()))
+	require.NoError(t, err)
+	handler := container.Router()
+
+	// 署名を検証しない場合、ペイロードを書き換えるだけで任意のユーザーになりすませる
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"00000000-0000-0000-0000-000000000001","exp":9999999999}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte("signature"))
+
+	for _, path := range []string{"/api/genres", "/api/questions", "/api/answers"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer "+forged)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, path)
+	}
+}
+
+func TestNew_RequiresJWTSecretOutsideMemory(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.AppEnv = ""
+	cfg.DatabaseDriver = "postgres"
+	cfg.DatabaseURL = "postgres://localhost/test"
+	cfg.SupabaseURL = "https://example.supabase.co"
+	cfg.SupabaseServiceKey = "service-key"
+
+	_, err := New(cfg)
+	assert.ErrorContains(t, err, "SUPABASE_JWT_SECRET")
+}
+
+func TestNew_RequiresAnonKeyForSupabase(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.AppEnv = ""
+	cfg.DatabaseDriver = "supabase"
+	cfg.SupabaseURL = "https://example.supabase.co"
+	cfg.SupabaseServiceKey = "service-key"
+	cfg.SupabaseJWTSecret = "jwt-secret"
+
+	_, err := New(cfg)
+	assert.ErrorContains(t, err, "SUPABASE_ANON_KEY")
+}
+
+func TestNewDependencyCheckers_ProbesSupabaseEndpoints(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/auth/v1/health" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer upstream.Close()
+
+	cfg := newTestConfig(t)
+	cfg.AppEnv = ""
+	cfg.DatabaseDriver = "supabase"
+	cfg.SupabaseURL = upstream.URL + "/"
+	cfg.SupabaseServiceKey = "service-key"
+	cfg.SupabaseAnonKey = "anon-key"
+	cfg.SupabaseJWTSecret = "jwt-secret"
+
+	container, err := New(cfg, WithRepositories(NewMemoryRepositories()), WithHTTPClient(upstream.Client()))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	container.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var body struct {
+		Status       string `json:"status"`
+		Dependencies []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"dependencies"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "not_ready", body.Status)
+	require.Len(t, body.Dependencies, 2)
+	assert.Equal(t, "postgrest", body.Dependencies[0].Name)
+	assert.Equal(t, "ok", body.Dependencies[0].Status)
+	assert.Equal(t, "gotrue", body.Dependencies[1].Name)
+	assert.Equal(t, "error", body.Dependencies[1].Status)
+	assert.ElementsMatch(t, []string{"/rest/v1/", "/auth/v1/health"}, paths)
+}
+
+func TestNew_RejectsInvalidConfig(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.DatabaseDriver = "postgres"
+
+	_, err := New(cfg)
+	assert.ErrorContains(t, err, "DATABASE_URL")
+}
+
+func TestContainer_ShutdownRunsHooksInReverseOrder(t *testing.T) {
+	container, err := New(newTestConfig(t), WithRepositories(NewMemoryRepositories()))
+	require.NoError(t, err)
+
+	var order []string
+	container.OnStart(func(ctx context.Context) error {
+		order = append(order, "start")
+		return nil
+	})
+	container.OnShutdown(func(ctx context.Context) error {
+		order = append(order, "first")
+		return nil
+	})
+	container.OnShutdown(func(ctx context.Context) error {
+		order = append(order, "second")
+		return nil
+	})
+
+	require.NoError(t, container.Start(context.Background()))
+	require.NoError(t, container.Shutdown(context.Background()))
+	assert.Equal(t, []string{"start", "second", "first"}, order)
+}
+
+func TestContainer_WorkersStopWithStartContext(t *testing.T) {
+	container, err := New(newTestConfig(t), WithRepositories(NewMemoryRepositories()))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, container.Start(ctx))
+	cancel()
+
+	// 終了シグナルで ctx が終了した後も、期限内にバックグラウンド処理の終了を待てる
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	assert.NoError(t, container.Shutdown(shutdownCtx))
+}
@@ -0,0 +1,17 @@
+package di
+
+// container_users.goはユーザーの公開プロフィール機能の依存関係配線を定義
+
+import (
+	profileUsecases "Shittaka_back/internal/application/profile/usecases"
+	"Shittaka_back/internal/presentation/http/handlers"
+)
+
+// newUserHandler はユーザーの公開プロフィール機能の依存関係を構築し、ハンドラーを返す
+func newUserHandler(repos *Repositories) *handlers.UserHandler {
+	// ユースケース
+	usecase := profileUsecases.NewProfileUsecase(repos.Users, repos.UserStats)
+
+	// ハンドラー
+	return handlers.NewUserHandler(usecase)
+}
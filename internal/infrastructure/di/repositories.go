@@ -0,0 +1,205 @@
+package di
+
+// repositories.goはデータの保存先（DATABASE_DRIVER）に応じたリポジトリ一式の作成を定義
+// 各機能の配線はここで作ったリポジトリを共有する
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	answerRepositories "Shittaka_back/internal/domain/answer/repositories"
+	attachmentRepositories "Shittaka_back/internal/domain/attachment/repositories"
+	authRepositories "Shittaka_back/internal/domain/auth/repositories"
+	bookmarkRepositories "Shittaka_back/internal/domain/bookmark/repositories"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	commentRepositories "Shittaka_back/internal/domain/comment/repositories"
+	dailyRepositories "Shittaka_back/internal/domain/daily/repositories"
+	followRepositories "Shittaka_back/internal/domain/follow/repositories"
+	genreRepositories "Shittaka_back/internal/domain/genre/repositories"
+	profileRepositories "Shittaka_back/internal/domain/profile/repositories"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+	reportRepositories "Shittaka_back/internal/domain/report/repositories"
+	tagRepositories "Shittaka_back/internal/domain/tag/repositories"
+	answerMemory "Shittaka_back/internal/infrastructure/answer/memory"
+	answerPostgres "Shittaka_back/internal/infrastructure/answer/postgres"
+	answerSupabase "Shittaka_back/internal/infrastructure/answer/supabase"
+	attachmentMemory "Shittaka_back/internal/infrastructure/attachment/memory"
+	attachmentPostgres "Shittaka_back/internal/infrastructure/attachment/postgres"
+	attachmentSupabase "Shittaka_back/internal/infrastructure/attachment/supabase"
+	"Shittaka_back/internal/infrastructure/auth/jwt"
+	authMemory "Shittaka_back/internal/infrastructure/auth/memory"
+	authSupabase "Shittaka_back/internal/infrastructure/auth/supabase"
+	bookmarkMemory "Shittaka_back/internal/infrastructure/bookmark/memory"
+	bookmarkPostgres "Shittaka_back/internal/infrastructure/bookmark/postgres"
+	bookmarkSupabase "Shittaka_back/internal/infrastructure/bookmark/supabase"
+	choiceMemory "Shittaka_back/internal/infrastructure/choice/memory"
+	choicePostgres "Shittaka_back/internal/infrastructure/choice/postgres"
+	choiceSupabase "Shittaka_back/internal/infrastructure/choice/supabase"
+	commentMemory "Shittaka_back/internal/infrastructure/comment/memory"
+	commentPostgres "Shittaka_back/internal/infrastructure/comment/postgres"
+	commentSupabase "Shittaka_back/internal/infrastructure/comment/supabase"
+	"Shittaka_back/internal/infrastructure/config"
+	dailyMemory "Shittaka_back/internal/infrastructure/daily/memory"
+	dailyPostgres "Shittaka_back/internal/infrastructure/daily/postgres"
+	dailySupabase "Shittaka_back/internal/infrastructure/daily/supabase"
+	"Shittaka_back/internal/infrastructure/database"
+	followMemory "Shittaka_back/internal/infrastructure/follow/memory"
+	followPostgres "Shittaka_back/internal/infrastructure/follow/postgres"
+	followSupabase "Shittaka_back/internal/infrastructure/follow/supabase"
+	genreMemory "Shittaka_back/internal/infrastructure/genre/memory"
+	genrePostgres "Shittaka_back/internal/infrastructure/genre/postgres"
+	genreSupabase "Shittaka_back/internal/infrastructure/genre/supabase"
+	"Shittaka_back/internal/infrastructure/postgrest"
+	profileMemory "Shittaka_back/internal/infrastructure/profile/memory"
+	profilePostgres "Shittaka_back/internal/infrastructure/profile/postgres"
+	profileSupabase "Shittaka_back/internal/infrastructure/profile/supabase"
+	questionMemory "Shittaka_back/internal/infrastructure/question/memory"
+	questionPostgres "Shittaka_back/internal/infrastructure/question/postgres"
+	questionSupabase "Shittaka_back/internal/infrastructure/question/supabase"
+	reportMemory "Shittaka_back/internal/infrastructure/report/memory"
+	reportPostgres "Shittaka_back/internal/infrastructure/report/postgres"
+	reportSupabase "Shittaka_back/internal/infrastructure/report/supabase"
+	tagMemory "Shittaka_back/internal/infrastructure/tag/memory"
+	tagPostgres "Shittaka_back/internal/infrastructure/tag/postgres"
+	tagSupabase "Shittaka_back/internal/infrastructure/tag/supabase"
+)
+
+// Repositories は各機能のリポジトリ一式
+type Repositories struct {
+	Questions   questionRepositories.QuestionRepository
+	Revisions   questionRepositories.QuestionRevisionRepository
+	Choices     choiceRepositories.ChoiceRepository
+	Answers     answerRepositories.AnswerRepository
+	Genres      genreRepositories.GenreRepository
+	Comments    commentRepositories.CommentRepository
+	Reports     reportRepositories.ReportRepository
+	Daily       dailyRepositories.DailyQuestionRepository
+	Attachments attachmentRepositories.AttachmentRepository
+	Tags        tagRepositories.TagRepository
+	Follows     followRepositories.FollowRepository
+	Bookmarks   bookmarkRepositories.BookmarkRepository
+	UserStats   profileRepositories.UserStatsRepository
+
+	// QuestionUnitOfWork は問題とそれに付随するデータをまとめて書き込むトランザクションで、postgres の場合のみ設定する
+	// nil の場合、ユースケースは上の各リポジトリで順に書き込む
+	QuestionUnitOfWork questionRepositories.QuestionUnitOfWork
+
+	// Users は認証のユーザーで、memory 以外では保存先によらずSupabase Authを使う
+	Users authRepositories.UserRepository
+	// Tokens はアクセストークンの検証で、memory 以外ではSupabase AuthのJWTシークレットで署名を確認する
+	Tokens authRepositories.TokenVerifier
+
+	// pool はPostgreSQLに直接接続する場合の接続プール
+	pool *pgxpool.Pool
+}
+
+// NewRepositories は設定に応じたリポジトリ一式を作成
+// Supabaseへの呼び出しは全て httpClient を共有する
+// postgres の場合はデータベースへ接続できることを確認する
+func NewRepositories(cfg *config.Config, httpClient *http.Client) (*Repositories, error) {
+	switch cfg.DatabaseDriver {
+	case "postgres":
+		pool, err := database.Open(context.Background(), cfg.DatabaseURL)
+		if err != nil {
+			return nil, err
+		}
+		return newPostgresRepositories(cfg, httpClient, pool), nil
+	case "memory":
+		return NewMemoryRepositories(), nil
+	default:
+		return newSupabaseRepositories(cfg, httpClient), nil
+	}
+}
+
+// Ping はデータベースへ接続できることを確認する（PostgreSQLに直接接続する場合のみ）
+func (r *Repositories) Ping(ctx context.Context) error {
+	if r.pool == nil {
+		return nil
+	}
+	return r.pool.Ping(ctx)
+}
+
+// Close はデータベースへの接続を閉じる
+func (r *Repositories) Close() {
+	if r.pool != nil {
+		r.pool.Close()
+	}
+}
+
+// newSupabaseRepositories はSupabase（PostgREST）を使うリポジトリ一式を作成
+func newSupabaseRepositories(cfg *config.Config, httpClient *http.Client) *Repositories {
+	client := postgrest.NewClient(cfg.SupabaseURL, cfg.SupabaseAnonKey, cfg.SupabaseServiceKey, httpClient)
+
+	return &Repositories{
+		Questions:   questionSupabase.NewQuestionRepository(client),
+		Revisions:   questionSupabase.NewQuestionRevisionRepository(client),
+		Choices:     choiceSupabase.NewChoiceRepository(client),
+		Answers:     answerSupabase.NewAnswerRepository(client),
+		Genres:      genreSupabase.NewGenreRepository(client),
+		Comments:    commentSupabase.NewCommentRepository(client),
+		Reports:     reportSupabase.NewReportRepository(client),
+		Daily:       dailySupabase.NewDailyQuestionRepository(client),
+		Attachments: attachmentSupabase.NewAttachmentRepository(client),
+		Tags:        tagSupabase.NewTagRepository(client),
+		Follows:     followSupabase.NewFollowRepository(client),
+		Bookmarks:   bookmarkSupabase.NewBookmarkRepository(client),
+		UserStats:   profileSupabase.NewUserStatsRepository(client),
+		Users:       authSupabase.NewUserRepository(cfg, httpClient),
+		Tokens:      jwt.NewVerifier([]byte(cfg.SupabaseJWTSecret)),
+	}
+}
+
+// newPostgresRepositories はPostgreSQLに直接接続するリポジトリ一式を作成
+func newPostgresRepositories(cfg *config.Config, httpClient *http.Client, pool *pgxpool.Pool) *Repositories {
+	return &Repositories{
+		Questions:   questionPostgres.NewQuestionRepository(pool),
+		Revisions:   questionPostgres.NewQuestionRevisionRepository(pool),
+		Choices:     choicePostgres.NewChoiceRepository(pool),
+		Answers:     answerPostgres.NewAnswerRepository(pool),
+		Genres:      genrePostgres.NewGenreRepository(pool),
+		Comments:    commentPostgres.NewCommentRepository(pool),
+		Reports:     reportPostgres.NewReportRepository(pool),
+		Daily:       dailyPostgres.NewDailyQuestionRepository(pool),
+		Attachments: attachmentPostgres.NewAttachmentRepository(pool),
+		Tags:        tagPostgres.NewTagRepository(pool),
+		Follows:     followPostgres.NewFollowRepository(pool),
+		Bookmarks:   bookmarkPostgres.NewBookmarkRepository(pool),
+		UserStats:   profilePostgres.NewUserStatsRepository(pool),
+		Users:       authSupabase.NewUserRepository(cfg, httpClient),
+		Tokens:      jwt.NewVerifier([]byte(cfg.SupabaseJWTSecret)),
+
+		QuestionUnitOfWork: questionPostgres.NewQuestionUnitOfWork(pool),
+
+		pool: pool,
+	}
+}
+
+// NewMemoryRepositories はメモリ上にデータを保持するリポジトリ一式を作成
+// 外部サービスに接続せずにサーバーを起動する場合やユースケースのテストで使う
+func NewMemoryRepositories() *Repositories {
+	tags := tagMemory.NewTagRepository()
+	questions := questionMemory.NewQuestionRepository(tags)
+	answers := answerMemory.NewAnswerRepository()
+	tokens := authMemory.NewTokenIssuer(24 * time.Hour)
+
+	return &Repositories{
+		Questions:   questions,
+		Revisions:   questionMemory.NewQuestionRevisionRepository(),
+		Choices:     choiceMemory.NewChoiceRepository(),
+		Answers:     answers,
+		Genres:      genreMemory.NewGenreRepository(),
+		Comments:    commentMemory.NewCommentRepository(),
+		Reports:     reportMemory.NewReportRepository(),
+		Daily:       dailyMemory.NewDailyQuestionRepository(),
+		Attachments: attachmentMemory.NewAttachmentRepository(),
+		Tags:        tags,
+		Follows:     followMemory.NewFollowRepository(),
+		Bookmarks:   bookmarkMemory.NewBookmarkRepository(),
+		UserStats:   profileMemory.NewUserStatsRepository(questions, answers),
+		Users:       authMemory.NewUserRepository(tokens),
+		Tokens:      tokens,
+	}
+}
@@ -0,0 +1,144 @@
+package memory
+
+// follow_repository_impl.goはメモリ上に保持するFollowRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/follow/entities"
+	"Shittaka_back/internal/domain/follow/repositories"
+)
+
+// FollowRepositoryImpl はメモリ上に保持するFollowRepositoryの実装
+type FollowRepositoryImpl struct {
+	mu           sync.RWMutex
+	userFollows  []entities.UserFollow  // フォローした順
+	genreFollows []entities.GenreFollow // フォローした順
+}
+
+// NewFollowRepository は新しいFollowRepositoryImplを作成
+func NewFollowRepository() repositories.FollowRepository {
+	return &FollowRepositoryImpl{}
+}
+
+// FollowUser はユーザーをフォロー（既にフォローしている場合は何もしない）
+func (r *FollowRepositoryImpl) FollowUser(ctx context.Context, follow *entities.UserFollow, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, f := range r.userFollows {
+		if f.FollowerID == follow.FollowerID && f.FolloweeID == follow.FolloweeID {
+			return nil
+		}
+	}
+	r.userFollows = append(r.userFollows, entities.UserFollow{
+		FollowerID: follow.FollowerID,
+		FolloweeID: follow.FolloweeID,
+		CreatedAt:  time.Now(),
+	})
+	return nil
+}
+
+// UnfollowUser はユーザーのフォローを解除
+func (r *FollowRepositoryImpl) UnfollowUser(ctx context.Context, followerID, followeeID string, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.userFollows = slices.DeleteFunc(r.userFollows, func(f entities.UserFollow) bool {
+		return f.FollowerID == followerID && f.FolloweeID == followeeID
+	})
+	return nil
+}
+
+// GetFollowers はユーザーのフォロワーを新しい順に取得
+func (r *FollowRepositoryImpl) GetFollowers(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	return r.getUserFollows(func(f entities.UserFollow) bool { return f.FolloweeID == userID }, limit, offset), nil
+}
+
+// GetFollowing はユーザーがフォローしているユーザーを新しい順に取得
+func (r *FollowRepositoryImpl) GetFollowing(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	return r.getUserFollows(func(f entities.UserFollow) bool { return f.FollowerID == userID }, limit, offset), nil
+}
+
+// GetFollowingIDs はユーザーがフォローしている全てのユーザーIDを取得
+func (r *FollowRepositoryImpl) GetFollowingIDs(ctx context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []string
+	for _, f := range r.userFollows {
+		if f.FollowerID == userID {
+			ids = append(ids, f.FolloweeID)
+		}
+	}
+	return ids, nil
+}
+
+// FollowGenre はジャンルをフォロー（既にフォローしている場合は何もしない）
+func (r *FollowRepositoryImpl) FollowGenre(ctx context.Context, follow *entities.GenreFollow, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, f := range r.genreFollows {
+		if f.UserID == follow.UserID && f.GenreID == follow.GenreID {
+			return nil
+		}
+	}
+	r.genreFollows = append(r.genreFollows, entities.GenreFollow{
+		UserID:    follow.UserID,
+		GenreID:   follow.GenreID,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+// UnfollowGenre はジャンルのフォローを解除
+func (r *FollowRepositoryImpl) UnfollowGenre(ctx context.Context, userID string, genreID int64, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.genreFollows = slices.DeleteFunc(r.genreFollows, func(f entities.GenreFollow) bool {
+		return f.UserID == userID && f.GenreID == genreID
+	})
+	return nil
+}
+
+// GetFollowedGenreIDs はユーザーがフォローしている全てのジャンルIDを取得
+func (r *FollowRepositoryImpl) GetFollowedGenreIDs(ctx context.Context, userID string) ([]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []int64
+	for _, f := range r.genreFollows {
+		if f.UserID == userID {
+			ids = append(ids, f.GenreID)
+		}
+	}
+	return ids, nil
+}
+
+// getUserFollows は条件に合うフォロー関係を新しい順に取得
+func (r *FollowRepositoryImpl) getUserFollows(match func(f entities.UserFollow) bool, limit, offset int) []*entities.UserFollow {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var follows []*entities.UserFollow
+	for i := len(r.userFollows) - 1; i >= 0; i-- {
+		if f := r.userFollows[i]; match(f) {
+			follows = append(follows, &f)
+		}
+	}
+
+	if offset >= len(follows) {
+		return nil
+	}
+	follows = follows[offset:]
+	if limit < len(follows) {
+		follows = follows[:limit]
+	}
+	return follows
+}
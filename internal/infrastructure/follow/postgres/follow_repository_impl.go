@@ -0,0 +1,126 @@
+package postgres
+
+// follow_repository_impl.goはPostgreSQLを直接使用したFollowRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/follow/entities"
+	"Shittaka_back/internal/domain/follow/repositories"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// FollowRepositoryImpl はPostgreSQLを使用したFollowRepositoryの実装
+type FollowRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewFollowRepository は新しいFollowRepositoryImplを作成
+func NewFollowRepository(pool *pgxpool.Pool) repositories.FollowRepository {
+	return &FollowRepositoryImpl{pool: pool}
+}
+
+// FollowUser はユーザーをフォロー（既にフォローしている場合は何もしない）
+func (r *FollowRepositoryImpl) FollowUser(ctx context.Context, follow *entities.UserFollow, userToken string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO user_follows (follower_id, followee_id) VALUES ($1, $2)
+		ON CONFLICT (follower_id, followee_id) DO NOTHING`,
+		follow.FollowerID, follow.FolloweeID)
+	return database.MapError(err)
+}
+
+// UnfollowUser はユーザーのフォローを解除
+func (r *FollowRepositoryImpl) UnfollowUser(ctx context.Context, followerID, followeeID string, userToken string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM user_follows WHERE follower_id = $1 AND followee_id = $2`, followerID, followeeID)
+	return database.MapError(err)
+}
+
+// GetFollowers はユーザーのフォロワーを新しい順に取得
+func (r *FollowRepositoryImpl) GetFollowers(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	return r.getUserFollows(ctx, "followee_id", userID, limit, offset)
+}
+
+// GetFollowing はユーザーがフォローしているユーザーを新しい順に取得
+func (r *FollowRepositoryImpl) GetFollowing(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	return r.getUserFollows(ctx, "follower_id", userID, limit, offset)
+}
+
+// GetFollowingIDs はユーザーがフォローしている全てのユーザーIDを取得
+func (r *FollowRepositoryImpl) GetFollowingIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT followee_id FROM user_follows WHERE follower_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// FollowGenre はジャンルをフォロー（既にフォローしている場合は何もしない）
+func (r *FollowRepositoryImpl) FollowGenre(ctx context.Context, follow *entities.GenreFollow, userToken string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO genre_follows (user_id, genre_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, genre_id) DO NOTHING`,
+		follow.UserID, follow.GenreID)
+	return database.MapError(err)
+}
+
+// UnfollowGenre はジャンルのフォローを解除
+func (r *FollowRepositoryImpl) UnfollowGenre(ctx context.Context, userID string, genreID int64, userToken string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM genre_follows WHERE user_id = $1 AND genre_id = $2`, userID, genreID)
+	return database.MapError(err)
+}
+
+// GetFollowedGenreIDs はユーザーがフォローしている全てのジャンルIDを取得
+func (r *FollowRepositoryImpl) GetFollowedGenreIDs(ctx context.Context, userID string) ([]int64, error) {
+	rows, err := r.pool.Query(ctx, `SELECT genre_id FROM genre_follows WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// getUserFollows は column で指定した側のユーザーで絞り込んだフォロー関係を新しい順に取得
+// column は呼び出し元で固定の列名のみを渡す
+func (r *FollowRepositoryImpl) getUserFollows(ctx context.Context, column, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT follower_id, followee_id, created_at FROM user_follows
+		WHERE `+column+` = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`,
+		userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var follows []*entities.UserFollow
+	for rows.Next() {
+		follow := &entities.UserFollow{}
+		if err := rows.Scan(&follow.FollowerID, &follow.FolloweeID, &follow.CreatedAt); err != nil {
+			return nil, err
+		}
+		follows = append(follows, follow)
+	}
+	return follows, rows.Err()
+}
@@ -0,0 +1,191 @@
+package supabase
+
+// follow_repository_impl.goはSupabaseを使用したFollowRepositoryの実装
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"Shittaka_back/internal/domain/follow/entities"
+	"Shittaka_back/internal/domain/follow/repositories"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// FollowRepositoryImpl はSupabaseを使用したFollowRepositoryの実装
+type FollowRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewFollowRepository は新しいFollowRepositoryImplを作成
+func NewFollowRepository(client *postgrest.Client) repositories.FollowRepository {
+	return &FollowRepositoryImpl{client: client}
+}
+
+// FollowUser はユーザーをフォローする（RLS適用のためユーザートークンを使用）
+func (r *FollowRepositoryImpl) FollowUser(ctx context.Context, follow *entities.UserFollow, userToken string) error {
+	followData := map[string]interface{}{
+		"follower_id": follow.FollowerID,
+		"followee_id": follow.FolloweeID,
+	}
+
+	// 既にフォローしている場合はそのままにする
+	return r.client.From("user_follows").WithToken(userToken).IgnoreDuplicates("follower_id", "followee_id").Insert(ctx, followData, nil)
+}
+
+// UnfollowUser はユーザーのフォローを外す（RLS適用のためユーザートークンを使用）
+func (r *FollowRepositoryImpl) UnfollowUser(ctx context.Context, followerID, followeeID string, userToken string) error {
+	return r.client.From("user_follows").WithToken(userToken).
+		Eq("follower_id", followerID).
+		Eq("followee_id", followeeID).
+		Delete(ctx)
+}
+
+// GetFollowers はユーザーのフォロワーを新しい順に取得
+func (r *FollowRepositoryImpl) GetFollowers(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	return r.getUserFollows(ctx, "followee_id", userID, limit, offset)
+}
+
+// GetFollowing はユーザーがフォローしているユーザーを新しい順に取得
+func (r *FollowRepositoryImpl) GetFollowing(ctx context.Context, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	return r.getUserFollows(ctx, "follower_id", userID, limit, offset)
+}
+
+// followIDPageSize はフォロー先のIDを1回で取得する件数（PostgRESTの既定の最大行数以下）
+const followIDPageSize = 1000
+
+// GetFollowingIDs はユーザーがフォローしている全てのユーザーIDを取得
+// PostgRESTは1回の応答の行数に上限があるため、ページごとに取得する
+func (r *FollowRepositoryImpl) GetFollowingIDs(ctx context.Context, userID string) ([]string, error) {
+	var ids []string
+	for {
+		var rows []map[string]interface{}
+		err := r.client.From("user_follows").
+			Select("followee_id").
+			Eq("follower_id", userID).
+			Order("followee_id", true).
+			Limit(followIDPageSize).
+			Offset(len(ids)).
+			Find(ctx, &rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			ids = append(ids, getString(row, "followee_id"))
+		}
+		if len(rows) < followIDPageSize {
+			return ids, nil
+		}
+	}
+}
+
+// FollowGenre はジャンルをフォローする（RLS適用のためユーザートークンを使用）
+func (r *FollowRepositoryImpl) FollowGenre(ctx context.Context, follow *entities.GenreFollow, userToken string) error {
+	followData := map[string]interface{}{
+		"user_id":  follow.UserID,
+		"genre_id": follow.GenreID,
+	}
+
+	// 既にフォローしている場合はそのままにする
+	return r.client.From("genre_follows").WithToken(userToken).IgnoreDuplicates("user_id", "genre_id").Insert(ctx, followData, nil)
+}
+
+// UnfollowGenre はジャンルのフォローを外す（RLS適用のためユーザートークンを使用）
+func (r *FollowRepositoryImpl) UnfollowGenre(ctx context.Context, userID string, genreID int64, userToken string) error {
+	return r.client.From("genre_follows").WithToken(userToken).
+		Eq("user_id", userID).
+		Eq("genre_id", genreID).
+		Delete(ctx)
+}
+
+// GetFollowedGenreIDs はユーザーがフォローしている全てのジャンルIDを取得
+// PostgRESTは1回の応答の行数に上限があるため、ページごとに取得する
+func (r *FollowRepositoryImpl) GetFollowedGenreIDs(ctx context.Context, userID string) ([]int64, error) {
+	var ids []int64
+	for {
+		var rows []map[string]interface{}
+		err := r.client.From("genre_follows").
+			Select("genre_id").
+			Eq("user_id", userID).
+			Order("genre_id", true).
+			Limit(followIDPageSize).
+			Offset(len(ids)).
+			Find(ctx, &rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			ids = append(ids, getInt64(row, "genre_id"))
+		}
+		if len(rows) < followIDPageSize {
+			return ids, nil
+		}
+	}
+}
+
+// getUserFollows は column で指定した側のユーザーで絞り込んだフォロー関係を新しい順に取得
+func (r *FollowRepositoryImpl) getUserFollows(ctx context.Context, column, userID string, limit, offset int) ([]*entities.UserFollow, error) {
+	var rows []map[string]interface{}
+	err := r.client.From("user_follows").
+		Eq(column, userID).
+		Order("created_at", false).
+		Limit(limit).
+		Offset(offset).
+		Find(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	follows := make([]*entities.UserFollow, len(rows))
+	for i, row := range rows {
+		follows[i] = &entities.UserFollow{
+			FollowerID: getString(row, "follower_id"),
+			FolloweeID: getString(row, "followee_id"),
+			CreatedAt:  getTime(row, "created_at"),
+		}
+	}
+	return follows, nil
+}
+
+// getString は map から文字列を安全に取得
+func getString(m map[string]interface{}, key string) string {
+	if val, ok := m[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// getInt64 は map から int64 を安全に取得
+func getInt64(m map[string]interface{}, key string) int64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// getTime は map から time.Time を安全に取得
+func getTime(m map[string]interface{}, key string) time.Time {
+	if val, ok := m[key]; ok {
+		if timeStr, ok := val.(string); ok {
+			if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,76 @@
+package memory
+
+// genre_repository_impl.goはメモリ上に保持するGenreRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"sync"
+
+	"Shittaka_back/internal/domain/genre/entities"
+	"Shittaka_back/internal/domain/genre/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// GenreRepositoryImpl はメモリ上に保持するGenreRepositoryの実装
+type GenreRepositoryImpl struct {
+	mu     sync.RWMutex
+	genres []entities.Genre // ID順
+}
+
+// NewGenreRepository は新しいGenreRepositoryImplを作成
+func NewGenreRepository() repositories.GenreRepository {
+	return &GenreRepositoryImpl{}
+}
+
+// Create は新しいジャンルを作成（同じ名前のジャンルは作成できない）
+func (r *GenreRepositoryImpl) Create(ctx context.Context, genre *entities.Genre, userToken string) (*entities.Genre, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, g := range r.genres {
+		if g.Name == genre.Name {
+			return nil, shared.NewDomainError("GENRE_EXISTS", "ジャンルが既に存在します")
+		}
+	}
+
+	created := entities.Genre{ID: int64(len(r.genres) + 1), Name: genre.Name}
+	r.genres = append(r.genres, created)
+	return &created, nil
+}
+
+// FindByID はIDでジャンルを検索
+func (r *GenreRepositoryImpl) FindByID(ctx context.Context, id int64) (*entities.Genre, error) {
+	return r.findOne(func(g entities.Genre) bool { return g.ID == id })
+}
+
+// FindAll は全てのジャンルをID順に取得
+func (r *GenreRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Genre, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	genres := make([]*entities.Genre, len(r.genres))
+	for i, g := range r.genres {
+		g := g
+		genres[i] = &g
+	}
+	return genres, nil
+}
+
+// FindByName は名前でジャンルを検索
+func (r *GenreRepositoryImpl) FindByName(ctx context.Context, name string, userToken string) (*entities.Genre, error) {
+	return r.findOne(func(g entities.Genre) bool { return g.Name == name })
+}
+
+// findOne は条件に合うジャンルを1件検索する共通処理
+func (r *GenreRepositoryImpl) findOne(match func(g entities.Genre) bool) (*entities.Genre, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, g := range r.genres {
+		if match(g) {
+			return &g, nil
+		}
+	}
+	return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
+}
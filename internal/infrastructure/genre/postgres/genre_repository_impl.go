@@ -0,0 +1,89 @@
+package postgres
+
+// genre_repository_impl.goはPostgreSQLを直接使用したGenreRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/genre/entities"
+	"Shittaka_back/internal/domain/genre/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// GenreRepositoryImpl はPostgreSQLを使用したGenreRepositoryの実装
+type GenreRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewGenreRepository は新しいGenreRepositoryImplを作成
+func NewGenreRepository(pool *pgxpool.Pool) repositories.GenreRepository {
+	return &GenreRepositoryImpl{pool: pool}
+}
+
+// Create は新しいジャンルを作成
+func (r *GenreRepositoryImpl) Create(ctx context.Context, genre *entities.Genre, userToken string) (*entities.Genre, error) {
+	row := r.pool.QueryRow(ctx, `INSERT INTO genres (name) VALUES ($1) RETURNING id, name`, genre.Name)
+	created, err := scanGenre(row)
+	if err != nil {
+		err = database.MapError(err)
+		if domainErr, ok := err.(shared.DomainError); ok && domainErr.Code == "CONFLICT" {
+			return nil, shared.NewDomainError("GENRE_EXISTS", "ジャンルが既に存在します")
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// FindByID はIDでジャンルを検索
+func (r *GenreRepositoryImpl) FindByID(ctx context.Context, id int64) (*entities.Genre, error) {
+	return r.findOne(ctx, `SELECT id, name FROM genres WHERE id = $1`, id)
+}
+
+// FindAll は全てのジャンルを取得
+func (r *GenreRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Genre, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, name FROM genres ORDER BY id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var genres []*entities.Genre
+	for rows.Next() {
+		genre, err := scanGenre(rows)
+		if err != nil {
+			return nil, err
+		}
+		genres = append(genres, genre)
+	}
+	return genres, rows.Err()
+}
+
+// FindByName は名前でジャンルを検索
+func (r *GenreRepositoryImpl) FindByName(ctx context.Context, name string, userToken string) (*entities.Genre, error) {
+	return r.findOne(ctx, `SELECT id, name FROM genres WHERE name = $1`, name)
+}
+
+// findOne はジャンルを1件検索する共通処理
+func (r *GenreRepositoryImpl) findOne(ctx context.Context, sql string, args ...any) (*entities.Genre, error) {
+	genre, err := scanGenre(r.pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
+		}
+		return nil, err
+	}
+	return genre, nil
+}
+
+// scanGenre は id, name の順に読み込んだ行を Genre エンティティに変換
+func scanGenre(row pgx.Row) (*entities.Genre, error) {
+	g := &entities.Genre{}
+	if err := row.Scan(&g.ID, &g.Name); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
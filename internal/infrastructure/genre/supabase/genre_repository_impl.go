@@ -3,27 +3,24 @@ package supabase
 // genre_repository_impl.goはSupabaseを使用したGenreRepositoryの実装
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"os"
 	"strconv"
 
 	"Shittaka_back/internal/domain/genre/entities"
 	"Shittaka_back/internal/domain/genre/repositories"
 	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
 )
 
 // GenreRepositoryImpl はSupabaseを使用したGenreRepositoryの実装
-type GenreRepositoryImpl struct{}
+type GenreRepositoryImpl struct {
+	client *postgrest.Client
+}
 
 // NewGenreRepository は新しいGenreRepositoryImplを作成
-func NewGenreRepository() repositories.GenreRepository {
-	return &GenreRepositoryImpl{}
+func NewGenreRepository(client *postgrest.Client) repositories.GenreRepository {
+	return &GenreRepositoryImpl{client: client}
 }
 
 // Create は新しいジャンルを作成（RLS適用のためユーザートークンを使用）
@@ -32,135 +29,44 @@ func (r *GenreRepositoryImpl) Create(ctx context.Context, genre *entities.Genre,
 		"name": genre.Name,
 	}
 
-	jsonData, err := json.Marshal(genreData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal genre data: %w", err)
-	}
-
-	url := os.Getenv("SUPABASE_URL") + "/rest/v1/genres"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
-	req.Header.Set("Prefer", "return=representation")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("create genre failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var genreList []map[string]interface{}
-	if err := json.Unmarshal(body, &genreList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := r.client.From("genres").WithToken(userToken).Insert(ctx, genreData, &genreList); err != nil {
+		if postgrest.IsCode(err, "CONFLICT") {
+			return nil, shared.NewDomainError("GENRE_EXISTS", "ジャンルが既に存在します")
+		}
+		return nil, err
 	}
 
 	if len(genreList) == 0 {
 		return nil, fmt.Errorf("no genre returned from create operation")
 	}
 
-	genreResp := genreList[0]
-	return &entities.Genre{
-		ID:   getInt64(genreResp, "id"),
-		Name: getString(genreResp, "name"),
-	}, nil
+	return mapToGenre(genreList[0]), nil
 }
 
 // FindByID はIDでジャンルを検索
 func (r *GenreRepositoryImpl) FindByID(ctx context.Context, id int64) (*entities.Genre, error) {
-	url := fmt.Sprintf("%s/rest/v1/genres?id=eq.%d", os.Getenv("SUPABASE_URL"), id)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find genre failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var genreList []map[string]interface{}
-	if err := json.Unmarshal(body, &genreList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if len(genreList) == 0 {
-		return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
+	var genreData map[string]interface{}
+	if err := r.client.From("genres").Eq("id", id).Single(ctx, &genreData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
+		}
+		return nil, err
 	}
 
-	genreData := genreList[0]
-	return &entities.Genre{
-		ID:   getInt64(genreData, "id"),
-		Name: getString(genreData, "name"),
-	}, nil
+	return mapToGenre(genreData), nil
 }
 
 // FindAll は全てのジャンルを取得
 func (r *GenreRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Genre, error) {
-	url := os.Getenv("SUPABASE_URL") + "/rest/v1/genres"
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find all genres failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var genreList []map[string]interface{}
-	if err := json.Unmarshal(body, &genreList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := r.client.From("genres").Find(ctx, &genreList); err != nil {
+		return nil, err
 	}
 
 	genres := make([]*entities.Genre, len(genreList))
 	for i, genreData := range genreList {
-		genres[i] = &entities.Genre{
-			ID:   getInt64(genreData, "id"),
-			Name: getString(genreData, "name"),
-		}
+		genres[i] = mapToGenre(genreData)
 	}
 
 	return genres, nil
@@ -168,47 +74,23 @@ func (r *GenreRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Genre, e
 
 // FindByName は名前でジャンルを検索（RLS適用のためユーザートークンを使用）
 func (r *GenreRepositoryImpl) FindByName(ctx context.Context, name string, userToken string) (*entities.Genre, error) {
-	// URLエンコーディングを適用
-	encodedName := url.QueryEscape(name)
-	apiURL := fmt.Sprintf("%s/rest/v1/genres?name=eq.%s", os.Getenv("SUPABASE_URL"), encodedName)
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find genre by name failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var genreList []map[string]interface{}
-	if err := json.Unmarshal(body, &genreList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	var genreData map[string]interface{}
+	if err := r.client.From("genres").Eq("name", name).WithToken(userToken).Single(ctx, &genreData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
+		}
+		return nil, err
 	}
 
-	if len(genreList) == 0 {
-		return nil, shared.NewDomainError("NOT_FOUND", "ジャンルが見つかりません")
-	}
+	return mapToGenre(genreData), nil
+}
 
-	genreData := genreList[0]
+// mapToGenre は map[string]interface{} を Genre エンティティに変換
+func mapToGenre(m map[string]interface{}) *entities.Genre {
 	return &entities.Genre{
-		ID:   getInt64(genreData, "id"),
-		Name: getString(genreData, "name"),
-	}, nil
+		ID:   getInt64(m, "id"),
+		Name: getString(m, "name"),
+	}
 }
 
 // ヘルパー関数
@@ -240,4 +122,4 @@ func getInt64(m map[string]interface{}, key string) int64 {
 		}
 	}
 	return 0
-}
\ No newline at end of file
+}
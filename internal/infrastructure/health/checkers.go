@@ -0,0 +1,82 @@
+package health
+
+// checkers.goは依存先の疎通確認（DependencyChecker）の実装を定義
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"Shittaka_back/internal/domain/health/repositories"
+)
+
+// HTTPChecker はHTTPのエンドポイントが 2xx を返すことを確認する
+// Supabase の PostgREST（/rest/v1/）と GoTrue（/auth/v1/health）の確認に使う
+type HTTPChecker struct {
+	name       string
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHTTPChecker は新しいHTTPCheckerを作成
+// apiKey が空でない場合は apikey ヘッダーに付けて送る
+func NewHTTPChecker(name, url, apiKey string, httpClient *http.Client) repositories.DependencyChecker {
+	return &HTTPChecker{
+		name:       name,
+		url:        url,
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+// Name は依存先の名前を返す
+func (c *HTTPChecker) Name() string {
+	return c.name
+}
+
+// Check はエンドポイントにGETリクエストを送り、2xx 以外の場合はエラーを返す
+func (c *HTTPChecker) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("apikey", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	// 接続を使い回せるようにボディを読み捨てる
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// PingChecker は接続確認の関数でデータベースなどへの疎通を確認する
+type PingChecker struct {
+	name string
+	ping func(ctx context.Context) error
+}
+
+// NewPingChecker は新しいPingCheckerを作成
+func NewPingChecker(name string, ping func(ctx context.Context) error) repositories.DependencyChecker {
+	return &PingChecker{name: name, ping: ping}
+}
+
+// Name は依存先の名前を返す
+func (c *PingChecker) Name() string {
+	return c.name
+}
+
+// Check は接続確認の関数を実行する
+func (c *PingChecker) Check(ctx context.Context) error {
+	return c.ping(ctx)
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPChecker_Check(t *testing.T) {
+	var gotKey string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("apikey")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	checker := NewHTTPChecker("gotrue", server.URL+"/auth/v1/health", "service-key", server.Client())
+	assert.Equal(t, "gotrue", checker.Name())
+	assert.NoError(t, checker.Check(context.Background()))
+	assert.Equal(t, "service-key", gotKey)
+
+	status = http.StatusServiceUnavailable
+	assert.ErrorContains(t, checker.Check(context.Background()), "503")
+}
+
+func TestHTTPChecker_CheckTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	checker := NewHTTPChecker("postgrest", server.URL, "", server.Client())
+	assert.Error(t, checker.Check(ctx))
+}
@@ -0,0 +1,69 @@
+package logging
+
+// logger.goは構造化ログ（log/slog）のロガーを定義
+// トークンやパスワードは属性のキーと値から判定し、出力する前に自動で伏せる
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Redacted は伏せた値の代わりに出力する文字列
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys はキーの一部に含まれていれば値を伏せる語（小文字）
+var sensitiveKeys = []string{"password", "token", "secret", "authorization", "apikey", "api_key", "cookie"}
+
+// New は format（json / text）と level（debug / info / warn / error）に応じたロガーを作成
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lv slog.Level
+	if err := lv.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error: %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lv, ReplaceAttr: redact}
+	switch format {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("LOG_FORMAT must be json or text: %q", format)
+	}
+}
+
+// redact は機密情報を表すキーの値と、トークンに見える値を伏せる
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		return a
+	}
+	if isSensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	if a.Value.Kind() == slog.KindString && looksLikeToken(a.Value.String()) {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
+
+// isSensitiveKey はキーが機密情報を表すかどうか
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeToken は値が Authorization ヘッダーの値またはJWTに見えるかどうか
+func looksLikeToken(value string) bool {
+	if strings.HasPrefix(value, "Bearer ") {
+		return true
+	}
+	// JWT のヘッダーは {"alg": ... を Base64URL にしたもので始まる
+	return strings.HasPrefix(value, "eyJ") && strings.Count(value, ".") == 2
+}
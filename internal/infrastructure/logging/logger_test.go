@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_RedactsTokensAndPasswords(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "json", "info")
+	require.NoError(t, err)
+
+	logger.Info("login",
+		"email", "taro@example.com",
+		"password", "shittaka-sample",
+		"access_token", "opaque",
+		"header", "Bearer abc.def.ghi",
+		"value", "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig",
+	)
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "taro@example.com", line["email"])
+	for _, key := range []string{"password", "access_token", "header", "value"} {
+		assert.Equal(t, Redacted, line[key], key)
+	}
+}
+
+func TestNew_RejectsUnknownSettings(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "xml", "info")
+	assert.Error(t, err)
+	_, err = New(&bytes.Buffer{}, "json", "verbose")
+	assert.Error(t, err)
+}
+
+type requestIDKey struct{}
+
+func TestRequestIDTransport_PropagatesRequestID(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Request-ID")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRequestIDTransport(nil, func(ctx context.Context) string {
+		id, _ := ctx.Value(requestIDKey{}).(string)
+		return id
+	})}
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "req-1", got)
+	assert.Empty(t, req.Header.Get("X-Request-ID"), "the caller's request must not be modified")
+}
@@ -0,0 +1,46 @@
+package logging
+
+// transport.goは外部サービスへのリクエストにリクエストIDを付けるHTTPトランスポートを定義
+
+import (
+	"context"
+	"net/http"
+)
+
+// requestIDHeader はリクエストIDを伝えるヘッダー
+const requestIDHeader = "X-Request-ID"
+
+// requestIDTransport はコンテキストのリクエストIDを X-Request-ID ヘッダーに付けて送る
+type requestIDTransport struct {
+	base      http.RoundTripper
+	requestID func(ctx context.Context) string
+}
+
+// NewRequestIDTransport は base で送る前に、requestID がコンテキストから返したIDをヘッダーに付けるトランスポートを作成
+// Supabase 側のログと突き合わせられるように、受け付けたリクエストのIDを上流の呼び出しにも付ける
+func NewRequestIDTransport(base http.RoundTripper, requestID func(ctx context.Context) string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &requestIDTransport{base: base, requestID: requestID}
+}
+
+// RoundTrip はリクエストIDを付けてリクエストを送る
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := t.requestID(req.Context())
+	if id == "" || req.Header.Get(requestIDHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTripper は受け取ったリクエストを変更してはいけないため複製する
+	req = req.Clone(req.Context())
+	req.Header.Set(requestIDHeader, id)
+	return t.base.RoundTrip(req)
+}
+
+// CloseIdleConnections は base のアイドル接続を閉じる（http.Client.CloseIdleConnections から呼ばれる）
+func (t *requestIDTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,59 @@
+package postgrest
+
+// client.goはSupabaseのPostgREST APIを呼び出す共有クライアントを定義
+// 各リポジトリはHTTPの組み立て・認証ヘッダー・エラーの解釈をこのクライアントに任せる
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultHTTPClient は HTTPクライアントを指定しなかったクライアントで共有するHTTPクライアント
+var defaultHTTPClient = NewHTTPClient()
+
+// NewHTTPClient はSupabaseの呼び出しに使うHTTPクライアントを作成
+// 同じホストへの接続を使い回すため、リクエストごとに作らずリポジトリ間で共有する
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 32,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// Client はPostgRESTのクライアント
+type Client struct {
+	restURL    string
+	anonKey    string
+	serviceKey string
+	httpClient *http.Client
+}
+
+// NewClient は新しいClientを作成
+// httpClient が nil の場合は共有のHTTPクライアントを使う
+func NewClient(supabaseURL, anonKey, serviceKey string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	return &Client{
+		restURL:    strings.TrimSuffix(supabaseURL, "/") + "/rest/v1",
+		anonKey:    anonKey,
+		serviceKey: serviceKey,
+		httpClient: httpClient,
+	}
+}
+
+// From はテーブルに対するクエリを作成
+func (c *Client) From(table string) *Query {
+	return newQuery(c, table)
+}
+
+// RPC はストアドファンクションの呼び出しを作成（Call で実行する）
+func (c *Client) RPC(function string) *Query {
+	return newQuery(c, "rpc/"+function)
+}
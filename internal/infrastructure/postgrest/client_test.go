@@ -0,0 +1,165 @@
+package postgrest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, "anon-key", "service-key", server.Client())
+}
+
+func TestQuery_Find_BuildsFiltersAndUsesToken(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Write([]byte(`[{"id":1},{"id":2}]`))
+	})
+
+	var rows []map[string]interface{}
+	err := client.From("questions").
+		Select("*,question_tags!inner(tags(slug))").
+		Eq("user_id", "u1").
+		In("genre_id", Int64s([]int64{3, 4})...).
+		Order("published_at", false).
+		Order("id", false).
+		Limit(10).
+		WithToken("user-token").
+		Find(context.Background(), &rows)
+
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "/rest/v1/questions", got.URL.Path)
+	query := got.URL.Query()
+	assert.Equal(t, "*,question_tags!inner(tags(slug))", query.Get("select"))
+	assert.Equal(t, "eq.u1", query.Get("user_id"))
+	assert.Equal(t, "in.(3,4)", query.Get("genre_id"))
+	assert.Equal(t, "published_at.desc,id.desc", query.Get("order"))
+	assert.Equal(t, "10", query.Get("limit"))
+	assert.Equal(t, "anon-key", got.Header.Get("apikey"))
+	assert.Equal(t, "Bearer user-token", got.Header.Get("Authorization"))
+}
+
+func TestQuery_Headers(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Write([]byte(`[]`))
+	})
+
+	// トークンを指定しない場合は匿名キー
+	var rows []map[string]interface{}
+	require.NoError(t, client.From("genres").Range(20, 39).Find(context.Background(), &rows))
+	assert.Equal(t, "Bearer anon-key", got.Header.Get("Authorization"))
+	assert.Equal(t, "20-39", got.Header.Get("Range"))
+
+	// サービスロールは apikey もサービスロールキーにする
+	require.NoError(t, client.From("genres").AsServiceRole().Find(context.Background(), &rows))
+	assert.Equal(t, "Bearer service-key", got.Header.Get("Authorization"))
+	assert.Equal(t, "service-key", got.Header.Get("apikey"))
+}
+
+func TestQuery_Insert_Prefer(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.From("user_follows").
+		IgnoreDuplicates("follower_id", "followee_id").
+		Insert(context.Background(), map[string]interface{}{"follower_id": "a"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "follower_id,followee_id", got.URL.Query().Get("on_conflict"))
+	assert.Equal(t, "resolution=ignore-duplicates,return=minimal", got.Header.Get("Prefer"))
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+}
+
+func TestQuery_Count(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		assert.Equal(t, "count=exact", r.Header.Get("Prefer"))
+		w.Header().Set("Content-Range", "0-24/123")
+	})
+
+	count, err := client.From("answers").Eq("user_id", "u1").Count(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 123, count)
+}
+
+func TestQuery_OrConditionsQuoteValues(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Write([]byte(`[]`))
+	})
+
+	before := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var rows []map[string]interface{}
+	err := client.From("questions").
+		Or("user_id.in."+List("a,b", `c"d`), "published_at.lt."+Quote(before)).
+		Find(context.Background(), &rows)
+
+	require.NoError(t, err)
+	assert.Equal(t, `(user_id.in.("a,b","c\"d"),published_at.lt."2026-01-02T03:04:05Z")`, got.URL.Query().Get("or"))
+}
+
+func TestQuery_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantCode string
+	}{
+		{"unique violation", http.StatusConflict, `{"code":"23505","message":"duplicate key value"}`, "CONFLICT"},
+		{"no rows", http.StatusNotAcceptable, `{"code":"PGRST116","message":"JSON object requested, multiple (or no) rows returned"}`, "NOT_FOUND"},
+		{"rls violation", http.StatusForbidden, `{"code":"42501","message":"new row violates row-level security policy"}`, "FORBIDDEN"},
+		{"expired token", http.StatusUnauthorized, `{"code":"PGRST301","message":"JWT expired"}`, "UNAUTHORIZED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			var row map[string]interface{}
+			err := client.From("questions").Eq("id", 1).Single(context.Background(), &row)
+
+			domainErr, ok := err.(shared.DomainError)
+			require.True(t, ok, "expected DomainError, got %T", err)
+			assert.Equal(t, tt.wantCode, domainErr.Code)
+		})
+	}
+}
+
+func TestQuery_UnknownErrorKeepsDetails(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"42703","message":"column questions.foo does not exist","hint":"Perhaps you meant bar"}`))
+	})
+
+	var rows []map[string]interface{}
+	err := client.From("questions").Find(context.Background(), &rows)
+
+	pgErr, ok := err.(*Error)
+	require.True(t, ok, "expected *Error, got %T", err)
+	assert.Equal(t, http.StatusBadRequest, pgErr.Status)
+	assert.Equal(t, "42703", pgErr.Code)
+	assert.Equal(t, "Perhaps you meant bar", pgErr.Hint)
+	assert.Contains(t, err.Error(), "GET questions failed with status 400")
+}
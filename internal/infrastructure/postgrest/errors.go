@@ -0,0 +1,60 @@
+package postgrest
+
+// errors.goはPostgRESTのエラーレスポンスの解釈とドメインエラーへの変換を定義
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// Error はPostgRESTが返したエラー
+// ドメインエラーに変換できないエラーはこの型のまま返す
+type Error struct {
+	Method  string
+	Path    string
+	Status  int
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+	Hint    string `json:"hint"`
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("postgrest: %s %s failed with status %d (%s): %s", e.Method, e.Path, e.Status, e.Code, e.Message)
+	}
+	return fmt.Sprintf("postgrest: %s %s failed with status %d: %s", e.Method, e.Path, e.Status, e.Message)
+}
+
+// decodeError はエラーレスポンスを解釈し、意味の分かるものはドメインエラーに変換する
+func decodeError(method, path string, status int, body []byte) error {
+	e := &Error{Method: method, Path: path, Status: status}
+	if err := json.Unmarshal(body, e); err != nil || (e.Code == "" && e.Message == "") {
+		e.Message = string(body)
+	}
+
+	switch {
+	case e.Code == "23505":
+		// 一意制約違反
+		return shared.NewDomainError("CONFLICT", "既に存在します")
+	case e.Code == "PGRST116":
+		// 1行を要求したが該当する行が無い（複数行の場合も同じコード）
+		return shared.NewDomainError("NOT_FOUND", "データが見つかりません")
+	case e.Code == "42501":
+		// RLSポリシー違反・権限不足
+		return shared.NewDomainError("FORBIDDEN", "この操作を行う権限がありません")
+	case e.Code == "PGRST301" || e.Code == "PGRST302" || status == http.StatusUnauthorized:
+		// トークンが無効・期限切れ
+		return shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+	return e
+}
+
+// IsCode は err が指定したコードのドメインエラーかどうかを判定する
+func IsCode(err error, code string) bool {
+	domainErr, ok := err.(shared.DomainError)
+	return ok && domainErr.Code == code
+}
@@ -0,0 +1,357 @@
+package postgrest
+
+// query.goはPostgRESTのクエリビルダーと実行を定義
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query はテーブル（またはRPC）に対する1回のリクエスト
+// フィルターなどのメソッドは自身を返すため、つなげて書ける
+type Query struct {
+	client *Client
+	path   string
+	params url.Values
+	header http.Header
+	prefer []string
+	token  string
+}
+
+func newQuery(client *Client, path string) *Query {
+	return &Query{
+		client: client,
+		path:   path,
+		params: url.Values{},
+		header: http.Header{},
+	}
+}
+
+// Select は取得する列を指定（埋め込みも可、例: "*,question_tags!inner(tags(slug))"）
+func (q *Query) Select(columns string) *Query {
+	q.params.Set("select", columns)
+	return q
+}
+
+// Eq は column = value で絞り込む
+func (q *Query) Eq(column string, value interface{}) *Query {
+	return q.Filter(column, "eq", formatValue(value))
+}
+
+// Neq は column <> value で絞り込む
+func (q *Query) Neq(column string, value interface{}) *Query {
+	return q.Filter(column, "neq", formatValue(value))
+}
+
+// Gt は column > value で絞り込む
+func (q *Query) Gt(column string, value interface{}) *Query {
+	return q.Filter(column, "gt", formatValue(value))
+}
+
+// Gte は column >= value で絞り込む
+func (q *Query) Gte(column string, value interface{}) *Query {
+	return q.Filter(column, "gte", formatValue(value))
+}
+
+// Lt は column < value で絞り込む
+func (q *Query) Lt(column string, value interface{}) *Query {
+	return q.Filter(column, "lt", formatValue(value))
+}
+
+// Lte は column <= value で絞り込む
+func (q *Query) Lte(column string, value interface{}) *Query {
+	return q.Filter(column, "lte", formatValue(value))
+}
+
+// Like は column LIKE pattern で絞り込む（ワイルドカードは *）
+func (q *Query) Like(column, pattern string) *Query {
+	return q.Filter(column, "like", pattern)
+}
+
+// In は column が values のいずれかに一致するもので絞り込む
+// カンマや括弧を含む値も扱えるよう、文字列は引用符で囲む
+func (q *Query) In(column string, values ...interface{}) *Query {
+	return q.Filter(column, "in", List(values...))
+}
+
+// Filter は任意の演算子で絞り込む（例: Filter("question_tags.tags.slug", "eq", slug)）
+func (q *Query) Filter(column, operator, value string) *Query {
+	q.params.Add(column, operator+"."+value)
+	return q
+}
+
+// Or は条件のいずれかに一致するもので絞り込む（例: Or("user_id.eq.x", "genre_id.in.(1,2)")）
+func (q *Query) Or(conditions ...string) *Query {
+	q.params.Add("or", "("+strings.Join(conditions, ",")+")")
+	return q
+}
+
+// And は条件の全てに一致するもので絞り込む（Or を入れ子にする場合に使う）
+func (q *Query) And(conditions ...string) *Query {
+	q.params.Add("and", "("+strings.Join(conditions, ",")+")")
+	return q
+}
+
+// Order は並び順を追加する（複数回呼ぶと先に指定したものが優先）
+func (q *Query) Order(column string, ascending bool) *Query {
+	direction := "desc"
+	if ascending {
+		direction = "asc"
+	}
+	if order := q.params.Get("order"); order != "" {
+		q.params.Set("order", order+","+column+"."+direction)
+	} else {
+		q.params.Set("order", column+"."+direction)
+	}
+	return q
+}
+
+// Limit は取得する最大件数を指定
+func (q *Query) Limit(n int) *Query {
+	q.params.Set("limit", strconv.Itoa(n))
+	return q
+}
+
+// Offset は先頭から読み飛ばす件数を指定
+func (q *Query) Offset(n int) *Query {
+	q.params.Set("offset", strconv.Itoa(n))
+	return q
+}
+
+// Range は from 件目から to 件目まで（0始まり、to を含む）を取得する
+func (q *Query) Range(from, to int) *Query {
+	q.header.Set("Range-Unit", "items")
+	q.header.Set("Range", fmt.Sprintf("%d-%d", from, to))
+	return q
+}
+
+// OnConflict は Insert を指定した列の一意制約での upsert にする（重複した行は更新する）
+func (q *Query) OnConflict(columns ...string) *Query {
+	q.params.Set("on_conflict", strings.Join(columns, ","))
+	q.prefer = append(q.prefer, "resolution=merge-duplicates")
+	return q
+}
+
+// IgnoreDuplicates は Insert を指定した列の一意制約で重複した行を無視するようにする
+func (q *Query) IgnoreDuplicates(columns ...string) *Query {
+	q.params.Set("on_conflict", strings.Join(columns, ","))
+	q.prefer = append(q.prefer, "resolution=ignore-duplicates")
+	return q
+}
+
+// WithToken はユーザーのトークンでリクエストする（RLSをユーザーとして適用する）
+// 空文字の場合は匿名キーのまま
+func (q *Query) WithToken(token string) *Query {
+	q.token = token
+	return q
+}
+
+// AsServiceRole はサービスロールキーでリクエストする（RLSを適用しない管理用の操作）
+func (q *Query) AsServiceRole() *Query {
+	q.token = q.client.serviceKey
+	q.header.Set("apikey", q.client.serviceKey)
+	return q
+}
+
+// Find は条件に合う行を取得して dest（スライスへのポインタ）に読み込む
+func (q *Query) Find(ctx context.Context, dest interface{}) error {
+	_, err := q.execute(ctx, http.MethodGet, nil, dest)
+	return err
+}
+
+// Single は条件に合う1行を取得して dest に読み込む
+// 該当する行が無い場合（PGRST116）は NOT_FOUND のドメインエラーを返す
+func (q *Query) Single(ctx context.Context, dest interface{}) error {
+	q.header.Set("Accept", "application/vnd.pgrst.object+json")
+	_, err := q.execute(ctx, http.MethodGet, nil, dest)
+	return err
+}
+
+// Count は条件に合う行数を返す（行そのものは取得しない）
+func (q *Query) Count(ctx context.Context) (int, error) {
+	q.prefer = append(q.prefer, "count=exact")
+	resp, err := q.execute(ctx, http.MethodHead, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Content-Range は "0-24/100" または "*/0" の形式
+	contentRange := resp.Header.Get("Content-Range")
+	slash := strings.LastIndex(contentRange, "/")
+	if slash < 0 {
+		return 0, fmt.Errorf("postgrest: count %s returned invalid Content-Range %q", q.path, contentRange)
+	}
+	count, err := strconv.Atoi(contentRange[slash+1:])
+	if err != nil {
+		return 0, fmt.Errorf("postgrest: count %s returned invalid Content-Range %q", q.path, contentRange)
+	}
+	return count, nil
+}
+
+// Insert は行（または行のスライス）を作成する
+// dest を指定した場合は作成された行を読み込む
+func (q *Query) Insert(ctx context.Context, payload interface{}, dest interface{}) error {
+	_, err := q.execute(ctx, http.MethodPost, payload, dest)
+	return err
+}
+
+// Update は条件に合う行を payload の内容で更新する
+// dest を指定した場合は更新後の行を読み込む
+func (q *Query) Update(ctx context.Context, payload interface{}, dest interface{}) error {
+	_, err := q.execute(ctx, http.MethodPatch, payload, dest)
+	return err
+}
+
+// Delete は条件に合う行を削除する
+func (q *Query) Delete(ctx context.Context) error {
+	_, err := q.execute(ctx, http.MethodDelete, nil, nil)
+	return err
+}
+
+// Call はRPCを引数 args で呼び出し、dest を指定した場合は戻り値を読み込む
+func (q *Query) Call(ctx context.Context, args interface{}, dest interface{}) error {
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	_, err := q.execute(ctx, http.MethodPost, args, dest)
+	return err
+}
+
+// URL はリクエスト先のURLを返す
+func (q *Query) URL() string {
+	apiURL := q.client.restURL + "/" + q.path
+	if len(q.params) > 0 {
+		apiURL += "?" + q.params.Encode()
+	}
+	return apiURL
+}
+
+// execute はリクエストを送り、エラーを解釈してレスポンスを dest に読み込む
+func (q *Query) execute(ctx context.Context, method string, payload interface{}, dest interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("postgrest: failed to marshal %s payload: %w", q.path, err)
+		}
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, q.URL(), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("postgrest: failed to create request: %w", err)
+	}
+
+	for key, values := range q.header {
+		req.Header[key] = values
+	}
+	if req.Header.Get("apikey") == "" {
+		req.Header.Set("apikey", q.client.anonKey)
+	}
+	token := q.token
+	if token == "" {
+		token = q.client.anonKey
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	prefer := q.prefer
+	if method == http.MethodPost || method == http.MethodPatch {
+		// 書き込み結果が不要な場合は返させない
+		if dest != nil {
+			prefer = append(prefer, "return=representation")
+		} else {
+			prefer = append(prefer, "return=minimal")
+		}
+	}
+	if len(prefer) > 0 {
+		req.Header.Set("Prefer", strings.Join(prefer, ","))
+	}
+
+	resp, err := q.client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("postgrest: failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("postgrest: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, decodeError(method, q.path, resp.StatusCode, body)
+	}
+
+	if dest != nil && len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, dest); err != nil {
+			return nil, fmt.Errorf("postgrest: failed to parse %s response: %w", q.path, err)
+		}
+	}
+	return resp, nil
+}
+
+// List は値の一覧を in 演算子に渡す形式 "(a,b,c)" に変換する
+func List(values ...interface{}) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			formatted[i] = quote(s)
+		} else {
+			formatted[i] = formatValue(value)
+		}
+	}
+	return "(" + strings.Join(formatted, ",") + ")"
+}
+
+// Int64s は []int64 を In に渡せる []interface{} に変換する
+func Int64s(values []int64) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
+// Strings は []string を In に渡せる []interface{} に変換する
+func Strings(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
+// quote は or・and・in の中で使う値を引用符で囲む（カンマ・括弧・コロンを含む値も1つの値として扱われる）
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// formatValue はフィルターの値を文字列に変換する
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Quote は or・and の条件の値に使う文字列を引用符で囲む
+func Quote(value interface{}) string {
+	return quote(formatValue(value))
+}
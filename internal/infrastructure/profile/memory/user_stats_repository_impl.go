@@ -0,0 +1,69 @@
+package memory
+
+// user_stats_repository_impl.goはメモリ上の問題・回答から集計するUserStatsRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+
+	answerRepositories "Shittaka_back/internal/domain/answer/repositories"
+	"Shittaka_back/internal/domain/profile/entities"
+	"Shittaka_back/internal/domain/profile/repositories"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+)
+
+// UserStatsRepositoryImpl は問題・回答のリポジトリから集計するUserStatsRepositoryの実装
+type UserStatsRepositoryImpl struct {
+	questions questionRepositories.QuestionRepository
+	answers   answerRepositories.AnswerRepository
+}
+
+// NewUserStatsRepository は新しいUserStatsRepositoryImplを作成
+func NewUserStatsRepository(questions questionRepositories.QuestionRepository, answers answerRepositories.AnswerRepository) repositories.UserStatsRepository {
+	return &UserStatsRepositoryImpl{questions: questions, answers: answers}
+}
+
+// GetStats はユーザーの問題数・回答数を集計
+func (r *UserStatsRepositoryImpl) GetStats(ctx context.Context, userID string) (*entities.UserStats, error) {
+	stats := &entities.UserStats{}
+
+	questions, err := r.questions.GetByUserID(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		// 公開中の作成した問題
+		if q.IsPubliclyVisible() {
+			stats.QuestionCount++
+		}
+
+		// 作成した問題への他のユーザーの回答
+		answers, err := r.answers.GetByQuestionID(ctx, q.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range answers {
+			if a.UserID == userID {
+				continue
+			}
+			stats.ReceivedAnswerCount++
+			if a.IsCorrect {
+				stats.ReceivedCorrectCount++
+			}
+		}
+	}
+
+	// ユーザー自身の回答
+	answers, err := r.answers.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range answers {
+		stats.AnswerCount++
+		if a.IsCorrect {
+			stats.CorrectCount++
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,44 @@
+package postgres
+
+// user_stats_repository_impl.goはPostgreSQLを直接使用したUserStatsRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/profile/entities"
+	"Shittaka_back/internal/domain/profile/repositories"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+)
+
+// UserStatsRepositoryImpl はPostgreSQLを使用したUserStatsRepositoryの実装
+type UserStatsRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewUserStatsRepository は新しいUserStatsRepositoryImplを作成
+func NewUserStatsRepository(pool *pgxpool.Pool) repositories.UserStatsRepository {
+	return &UserStatsRepositoryImpl{pool: pool}
+}
+
+// GetStats はユーザーの問題数・回答数を1回のクエリでまとめて集計
+func (r *UserStatsRepositoryImpl) GetStats(ctx context.Context, userID string) (*entities.UserStats, error) {
+	stats := &entities.UserStats{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			-- 公開中の作成した問題
+			(SELECT COUNT(*) FROM questions WHERE user_id = $1 AND status = $2 AND is_hidden = false),
+			-- 作成した問題への他のユーザーの回答
+			(SELECT COUNT(*) FROM answers a JOIN questions q ON q.id = a.question_id WHERE q.user_id = $1 AND a.user_id <> $1),
+			(SELECT COUNT(*) FROM answers a JOIN questions q ON q.id = a.question_id WHERE q.user_id = $1 AND a.user_id <> $1 AND a.is_correct),
+			-- ユーザー自身の回答
+			(SELECT COUNT(*) FROM answers WHERE user_id = $1),
+			(SELECT COUNT(*) FROM answers WHERE user_id = $1 AND is_correct)`,
+		userID, questionEntities.StatusPublished,
+	).Scan(&stats.QuestionCount, &stats.ReceivedAnswerCount, &stats.ReceivedCorrectCount, &stats.AnswerCount, &stats.CorrectCount)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,70 @@
+package supabase
+
+// user_stats_repository_impl.goはSupabaseを使用したUserStatsRepositoryの実装
+
+import (
+	"context"
+
+	"Shittaka_back/internal/domain/profile/entities"
+	"Shittaka_back/internal/domain/profile/repositories"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// UserStatsRepositoryImpl はSupabaseを使用したUserStatsRepositoryの実装
+type UserStatsRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewUserStatsRepository は新しいUserStatsRepositoryImplを作成
+func NewUserStatsRepository(client *postgrest.Client) repositories.UserStatsRepository {
+	return &UserStatsRepositoryImpl{client: client}
+}
+
+// GetStats はユーザーの問題数・回答数をPostgRESTの件数取得でまとめて集計
+// 行そのものは取得せず、件数だけを返すため個々の回答内容は公開されない
+// 回答は本人と問題の作成者にしか読めないため、集計はサービスロールキーで行う
+func (r *UserStatsRepositoryImpl) GetStats(ctx context.Context, userID string) (*entities.UserStats, error) {
+	stats := &entities.UserStats{}
+
+	// 公開中の作成した問題
+	questions := func() *postgrest.Query {
+		return r.client.From("questions").AsServiceRole().
+			Eq("user_id", userID).
+			Eq("status", questionEntities.StatusPublished).
+			Eq("is_hidden", false)
+	}
+
+	// 作成した問題への他のユーザーの回答（問題を内部結合して作成者で絞り込む）
+	received := func() *postgrest.Query {
+		return r.client.From("answers").AsServiceRole().
+			Select("id,questions!inner(user_id)").
+			Eq("questions.user_id", userID).
+			Neq("user_id", userID)
+	}
+
+	// ユーザー自身の回答
+	answers := func() *postgrest.Query {
+		return r.client.From("answers").AsServiceRole().Eq("user_id", userID)
+	}
+
+	counts := []struct {
+		query *postgrest.Query
+		dest  *int
+	}{
+		{questions(), &stats.QuestionCount},
+		{received(), &stats.ReceivedAnswerCount},
+		{received().Eq("is_correct", true), &stats.ReceivedCorrectCount},
+		{answers(), &stats.AnswerCount},
+		{answers().Eq("is_correct", true), &stats.CorrectCount},
+	}
+	for _, c := range counts {
+		count, err := c.query.Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		*c.dest = count
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,285 @@
+package memory
+
+// question_repository_impl.goはメモリ上に保持するQuestionRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+// RLSは無いため userToken は参照しない（権限はユースケースで確認する）
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// TagIndex はタグのキーから問題を引く索引（タグでの絞り込みに使う）
+type TagIndex interface {
+	QuestionIDsBySlug(slug string) []int64
+}
+
+// QuestionRepositoryImpl はメモリ上に保持するQuestionRepositoryの実装
+type QuestionRepositoryImpl struct {
+	mu        sync.RWMutex
+	nextID    int64
+	questions map[int64]*entities.Question
+	tags      TagIndex
+}
+
+// NewQuestionRepository は新しいQuestionRepositoryImplを作成
+// tags が nil の場合、タグでの絞り込みは常に0件になる
+func NewQuestionRepository(tags TagIndex) repositories.QuestionRepository {
+	return &QuestionRepositoryImpl{
+		questions: make(map[int64]*entities.Question),
+		tags:      tags,
+	}
+}
+
+// Create は新しい問題を作成
+func (r *QuestionRepositoryImpl) Create(ctx context.Context, question *entities.Question, userToken string) (*entities.Question, error) {
+	created, err := r.CreateBatch(ctx, []*entities.Question{question}, userToken)
+	if err != nil {
+		return nil, err
+	}
+	return created[0], nil
+}
+
+// CreateBatch は複数の問題をまとめて作成し、渡した順に返す
+func (r *QuestionRepositoryImpl) CreateBatch(ctx context.Context, questions []*entities.Question, userToken string) ([]*entities.Question, error) {
+	if len(questions) == 0 {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := make([]*entities.Question, len(questions))
+	for i, question := range questions {
+		r.nextID++
+		q := cloneQuestion(question)
+		q.ID = r.nextID
+		if q.CreatedAt.IsZero() {
+			q.CreatedAt = time.Now()
+		}
+		r.questions[q.ID] = q
+		created[i] = cloneQuestion(q)
+	}
+	return created, nil
+}
+
+// GetByID はIDで問題を検索
+func (r *QuestionRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Question, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	q, ok := r.questions[id]
+	if !ok {
+		return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	}
+	return cloneQuestion(q), nil
+}
+
+// GetByUserID はユーザーIDで問題一覧を取得
+func (r *QuestionRepositoryImpl) GetByUserID(ctx context.Context, userID string, userToken string) ([]*entities.Question, error) {
+	return r.filter(func(q *entities.Question) bool { return q.UserID == userID }), nil
+}
+
+// Update は問題を更新（公開状態・集計値は UpdateStatus などで更新する）
+func (r *QuestionRepositoryImpl) Update(ctx context.Context, question *entities.Question, userToken string) error {
+	return r.modify(question.ID, func(q *entities.Question) {
+		q.Title = question.Title
+		q.Body = question.Body
+		q.Explanation = question.Explanation
+		q.Revision = question.Revision
+		q.ShuffleChoices = question.ShuffleChoices
+		q.BodyFormat = question.BodyFormat
+		q.Type = question.Type
+		q.PartialCredit = question.PartialCredit
+		q.AcceptedAnswers = slices.Clone(question.AcceptedAnswers)
+		q.NumericAnswer = question.NumericAnswer
+		q.NumericTolerance = question.NumericTolerance
+	})
+}
+
+// Delete は問題を削除
+func (r *QuestionRepositoryImpl) Delete(ctx context.Context, id int64, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.questions, id)
+	return nil
+}
+
+// GetAll は公開中の問題を全て取得（通報により非公開になった問題は除く）
+func (r *QuestionRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Question, error) {
+	return r.filter(func(q *entities.Question) bool { return q.IsPubliclyVisible() }), nil
+}
+
+// GetPage は条件に合う問題をID順に最大 Limit 件取得
+func (r *QuestionRepositoryImpl) GetPage(ctx context.Context, query repositories.QuestionPageQuery, userToken string) ([]*entities.Question, error) {
+	var tagged map[int64]bool
+	if query.TagSlug != "" {
+		tagged = make(map[int64]bool)
+		if r.tags != nil {
+			for _, id := range r.tags.QuestionIDsBySlug(query.TagSlug) {
+				tagged[id] = true
+			}
+		}
+	}
+
+	questions := r.filter(func(q *entities.Question) bool {
+		switch {
+		case !query.NewestFirst && q.ID <= query.AfterID:
+			return false
+		case query.UserID != "" && q.UserID != query.UserID:
+			return false
+		case query.GenreID != 0 && q.GenreID != query.GenreID:
+			return false
+		case tagged != nil && !tagged[q.ID]:
+			return false
+		case len(query.IDs) > 0 && !slices.Contains(query.IDs, q.ID):
+			return false
+		case query.PublishedOnly && !q.IsPubliclyVisible():
+			return false
+		}
+		return true
+	})
+
+	if query.NewestFirst {
+		slices.Reverse(questions)
+	}
+	return page(questions, query.Offset, query.Limit), nil
+}
+
+// GetFeed はフォローしている作成者・ジャンルの公開中の問題を公開日時の新しい順に最大 Limit 件取得
+func (r *QuestionRepositoryImpl) GetFeed(ctx context.Context, query repositories.QuestionFeedQuery) ([]*entities.Question, error) {
+	if len(query.AuthorIDs) == 0 && len(query.GenreIDs) == 0 {
+		return nil, nil
+	}
+
+	questions := r.filter(func(q *entities.Question) bool {
+		if !q.IsPubliclyVisible() || q.PublishedAt == nil {
+			return false
+		}
+		if !slices.Contains(query.AuthorIDs, q.UserID) && !slices.Contains(query.GenreIDs, q.GenreID) {
+			return false
+		}
+		// カーソルより古い問題（公開日時が同じ場合はIDで順序を決める）
+		if query.Before != nil {
+			if q.PublishedAt.After(query.Before.PublishedAt) {
+				return false
+			}
+			if q.PublishedAt.Equal(query.Before.PublishedAt) && q.ID >= query.Before.ID {
+				return false
+			}
+		}
+		return true
+	})
+
+	sort.SliceStable(questions, func(i, j int) bool {
+		if !questions[i].PublishedAt.Equal(*questions[j].PublishedAt) {
+			return questions[i].PublishedAt.After(*questions[j].PublishedAt)
+		}
+		return questions[i].ID > questions[j].ID
+	})
+	return page(questions, 0, query.Limit), nil
+}
+
+// UpdateStatus は問題の公開状態を更新
+func (r *QuestionRepositoryImpl) UpdateStatus(ctx context.Context, question *entities.Question, userToken string) error {
+	return r.modify(question.ID, func(q *entities.Question) {
+		q.Status = question.Status
+		q.PublishedAt = question.PublishedAt
+		q.PublishAt = question.PublishAt
+	})
+}
+
+// GetDueForPublish は予約公開の日時を過ぎた下書きを予約日時の古い順に取得
+func (r *QuestionRepositoryImpl) GetDueForPublish(ctx context.Context, now time.Time) ([]*entities.Question, error) {
+	questions := r.filter(func(q *entities.Question) bool { return q.IsDueForPublish(now) })
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].PublishAt.Before(*questions[j].PublishAt)
+	})
+	return questions, nil
+}
+
+// PublishScheduled は予約公開の問題を公開状態に更新
+// 予約後に作成者が状態を変えていた場合に上書きしないよう、下書きの場合のみ更新する
+func (r *QuestionRepositoryImpl) PublishScheduled(ctx context.Context, question *entities.Question) error {
+	return r.modify(question.ID, func(q *entities.Question) {
+		if q.Status != entities.StatusDraft {
+			return
+		}
+		q.Status = question.Status
+		q.PublishedAt = question.PublishedAt
+		q.PublishAt = question.PublishAt
+	})
+}
+
+// CancelScheduledPublish は下書きの予約公開を取り消す
+func (r *QuestionRepositoryImpl) CancelScheduledPublish(ctx context.Context, id int64) error {
+	return r.modify(id, func(q *entities.Question) {
+		if q.Status != entities.StatusDraft {
+			return
+		}
+		q.PublishAt = nil
+	})
+}
+
+// SetHidden は問題の非公開フラグを更新
+func (r *QuestionRepositoryImpl) SetHidden(ctx context.Context, id int64, hidden bool) error {
+	return r.modify(id, func(q *entities.Question) { q.IsHidden = hidden })
+}
+
+// IncrementViews は閲覧数を delta だけ加算
+func (r *QuestionRepositoryImpl) IncrementViews(ctx context.Context, id int64, delta int) error {
+	return r.modify(id, func(q *entities.Question) { q.Views += delta })
+}
+
+// filter は条件に合う問題をID順に複製して返す
+func (r *QuestionRepositoryImpl) filter(match func(q *entities.Question) bool) []*entities.Question {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var questions []*entities.Question
+	for _, q := range r.questions {
+		if match(q) {
+			questions = append(questions, cloneQuestion(q))
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool { return questions[i].ID < questions[j].ID })
+	return questions
+}
+
+// modify は保持している問題を書き換える（存在しない場合は何もしない）
+func (r *QuestionRepositoryImpl) modify(id int64, update func(q *entities.Question)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if q, ok := r.questions[id]; ok {
+		update(q)
+	}
+	return nil
+}
+
+// cloneQuestion は呼び出し元が書き換えても保持している問題に影響しないよう複製する
+func cloneQuestion(q *entities.Question) *entities.Question {
+	c := *q
+	c.AcceptedAnswers = slices.Clone(q.AcceptedAnswers)
+	return &c
+}
+
+// page は offset 件読み飛ばした後の最大 limit 件を返す（limit が0以下の場合は全件）
+func page[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
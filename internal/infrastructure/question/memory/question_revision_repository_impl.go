@@ -0,0 +1,110 @@
+package memory
+
+// question_revision_repository_impl.goはメモリ上に保持するQuestionRevisionRepositoryの実装
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// QuestionRevisionRepositoryImpl はメモリ上に保持するQuestionRevisionRepositoryの実装
+type QuestionRevisionRepositoryImpl struct {
+	mu        sync.RWMutex
+	nextID    int64
+	revisions []*entities.QuestionRevision
+}
+
+// NewQuestionRevisionRepository は新しいQuestionRevisionRepositoryImplを作成
+func NewQuestionRevisionRepository() repositories.QuestionRevisionRepository {
+	return &QuestionRevisionRepositoryImpl{}
+}
+
+// Create は新しいリビジョンを作成
+func (r *QuestionRevisionRepositoryImpl) Create(ctx context.Context, revision *entities.QuestionRevision, userToken string) (*entities.QuestionRevision, error) {
+	created, err := r.CreateBatch(ctx, []*entities.QuestionRevision{revision}, userToken)
+	if err != nil {
+		return nil, err
+	}
+	return created[0], nil
+}
+
+// CreateBatch は複数のリビジョンをまとめて作成
+// 同じ問題・リビジョン番号が既にある場合は1件も作成しない
+func (r *QuestionRevisionRepositoryImpl) CreateBatch(ctx context.Context, revisions []*entities.QuestionRevision, userToken string) ([]*entities.QuestionRevision, error) {
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, revision := range revisions {
+		if r.find(revision.QuestionID, revision.Revision) != nil {
+			return nil, shared.NewDomainError("CONFLICT", "既に存在します")
+		}
+	}
+
+	created := make([]*entities.QuestionRevision, len(revisions))
+	for i, revision := range revisions {
+		r.nextID++
+		rev := cloneRevision(revision)
+		rev.ID = r.nextID
+		if rev.CreatedAt.IsZero() {
+			rev.CreatedAt = time.Now()
+		}
+		r.revisions = append(r.revisions, rev)
+		created[i] = cloneRevision(rev)
+	}
+	return created, nil
+}
+
+// GetByQuestionID は問題のリビジョン一覧を新しい順に取得
+func (r *QuestionRevisionRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.QuestionRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var revisions []*entities.QuestionRevision
+	for _, rev := range r.revisions {
+		if rev.QuestionID == questionID {
+			revisions = append(revisions, cloneRevision(rev))
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision > revisions[j].Revision })
+	return revisions, nil
+}
+
+// GetByRevision は問題の特定のリビジョンを取得
+func (r *QuestionRevisionRepositoryImpl) GetByRevision(ctx context.Context, questionID int64, revision int) (*entities.QuestionRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rev := r.find(questionID, revision)
+	if rev == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "リビジョンが見つかりません")
+	}
+	return cloneRevision(rev), nil
+}
+
+// find は問題とリビジョン番号でリビジョンを探す（呼び出し元でロックを取得する）
+func (r *QuestionRevisionRepositoryImpl) find(questionID int64, revision int) *entities.QuestionRevision {
+	for _, rev := range r.revisions {
+		if rev.QuestionID == questionID && rev.Revision == revision {
+			return rev
+		}
+	}
+	return nil
+}
+
+// cloneRevision は呼び出し元が書き換えても保持しているリビジョンに影響しないよう複製する
+func cloneRevision(rev *entities.QuestionRevision) *entities.QuestionRevision {
+	c := *rev
+	c.AcceptedAnswers = slices.Clone(rev.AcceptedAnswers)
+	return &c
+}
@@ -0,0 +1,278 @@
+package postgres
+
+// question_repository_impl.goはPostgreSQLを直接使用したQuestionRepositoryの実装
+// RLSは使わないため userToken は参照しない（権限はユースケースで確認する）
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// questionColumns は問題を取得する際の列（scanQuestion と同じ順序）
+// 種類・形式が未設定の既存の問題は単一選択・プレーンテキストとして扱う
+const questionColumns = `id, genre_id, user_id, title, body, COALESCE(explanation, ''),
+	COALESCE(body_format, 'plain'), created_at, views, correct_count, incorrect_count,
+	is_hidden, revision, status, published_at, publish_at,
+	COALESCE(type, 'single_choice'), partial_credit, accepted_answers, numeric_answer,
+	numeric_tolerance, shuffle_choices`
+
+// QuestionRepositoryImpl はPostgreSQLを使用したQuestionRepositoryの実装
+type QuestionRepositoryImpl struct {
+	db database.DB
+}
+
+// NewQuestionRepository は新しいQuestionRepositoryImplを作成
+// db には接続プールのほか、ユニットオブワークのトランザクションも渡せる
+func NewQuestionRepository(db database.DB) repositories.QuestionRepository {
+	return &QuestionRepositoryImpl{db: db}
+}
+
+// Create は新しい問題を作成
+func (r *QuestionRepositoryImpl) Create(ctx context.Context, question *entities.Question, userToken string) (*entities.Question, error) {
+	created, err := r.CreateBatch(ctx, []*entities.Question{question}, userToken)
+	if err != nil {
+		return nil, err
+	}
+	return created[0], nil
+}
+
+// CreateBatch は複数の問題を1つのトランザクションでまとめて作成
+// 作成された問題は渡した順に返す
+func (r *QuestionRepositoryImpl) CreateBatch(ctx context.Context, questions []*entities.Question, userToken string) ([]*entities.Question, error) {
+	if len(questions) == 0 {
+		return nil, nil
+	}
+
+	created := make([]*entities.Question, len(questions))
+	err := database.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		for i, question := range questions {
+			row := tx.QueryRow(ctx, `
+				INSERT INTO questions (genre_id, user_id, title, body, explanation, body_format, revision,
+					shuffle_choices, status, published_at, publish_at,
+					type, partial_credit, accepted_answers, numeric_answer, numeric_tolerance)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+				RETURNING `+questionColumns,
+				question.GenreID, question.UserID, question.Title, question.Body, question.Explanation,
+				question.BodyFormat, question.Revision, question.ShuffleChoices,
+				question.Status, question.PublishedAt, question.PublishAt,
+				question.Type, question.PartialCredit, nonNilStrings(question.AcceptedAnswers), question.NumericAnswer, question.NumericTolerance,
+			)
+			q, err := scanQuestion(row)
+			if err != nil {
+				return database.MapError(err)
+			}
+			created[i] = q
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetByID はIDで問題を検索
+func (r *QuestionRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Question, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+questionColumns+` FROM questions WHERE id = $1`, id)
+	question, err := scanQuestion(row)
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+		}
+		return nil, err
+	}
+	return question, nil
+}
+
+// GetByUserID はユーザーIDで問題一覧を取得
+func (r *QuestionRepositoryImpl) GetByUserID(ctx context.Context, userID string, userToken string) ([]*entities.Question, error) {
+	return r.query(ctx, `SELECT `+questionColumns+` FROM questions WHERE user_id = $1`, userID)
+}
+
+// Update は問題を更新
+func (r *QuestionRepositoryImpl) Update(ctx context.Context, question *entities.Question, userToken string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE questions SET title = $1, body = $2, explanation = $3, revision = $4,
+			shuffle_choices = $5, body_format = $6,
+			type = $7, partial_credit = $8, accepted_answers = $9, numeric_answer = $10, numeric_tolerance = $11
+		WHERE id = $12`,
+		question.Title, question.Body, question.Explanation, question.Revision,
+		question.ShuffleChoices, question.BodyFormat,
+		question.Type, question.PartialCredit, nonNilStrings(question.AcceptedAnswers), question.NumericAnswer, question.NumericTolerance,
+		question.ID,
+	)
+	return database.MapError(err)
+}
+
+// Delete は問題を削除
+func (r *QuestionRepositoryImpl) Delete(ctx context.Context, id int64, userToken string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM questions WHERE id = $1`, id)
+	return database.MapError(err)
+}
+
+// GetAll は公開中の問題を全て取得（通報により非公開になった問題は除く）
+func (r *QuestionRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Question, error) {
+	return r.query(ctx, `SELECT `+questionColumns+` FROM questions WHERE status = $1 AND is_hidden = false`, entities.StatusPublished)
+}
+
+// GetPage は条件に合う問題をID順に最大 Limit 件取得
+func (r *QuestionRepositoryImpl) GetPage(ctx context.Context, query repositories.QuestionPageQuery, userToken string) ([]*entities.Question, error) {
+	sql, args := buildPageQuery(query)
+	return r.query(ctx, sql, args...)
+}
+
+// GetFeed はフォローしている作成者・ジャンルの公開中の問題を公開日時の新しい順に最大 Limit 件取得
+func (r *QuestionRepositoryImpl) GetFeed(ctx context.Context, query repositories.QuestionFeedQuery) ([]*entities.Question, error) {
+	if len(query.AuthorIDs) == 0 && len(query.GenreIDs) == 0 {
+		return nil, nil
+	}
+
+	sql, args := buildFeedQuery(query)
+	return r.query(ctx, sql, args...)
+}
+
+// buildPageQuery は GetPage の条件からSQLと引数を組み立てる
+func buildPageQuery(query repositories.QuestionPageQuery) (string, database.Args) {
+	var args database.Args
+	conditions := []string{"true"}
+	if !query.NewestFirst {
+		conditions = append(conditions, "id > "+args.Add(query.AfterID))
+	}
+	if query.UserID != "" {
+		conditions = append(conditions, "user_id = "+args.Add(query.UserID))
+	}
+	if query.GenreID != 0 {
+		conditions = append(conditions, "genre_id = "+args.Add(query.GenreID))
+	}
+	if query.TagSlug != "" {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM question_tags qt JOIN tags t ON t.id = qt.tag_id
+			WHERE qt.question_id = questions.id AND t.slug = `+args.Add(query.TagSlug)+`)`)
+	}
+	if len(query.IDs) > 0 {
+		conditions = append(conditions, "id = ANY("+args.Add(query.IDs)+")")
+	}
+	if query.PublishedOnly {
+		conditions = append(conditions, "status = "+args.Add(entities.StatusPublished), "is_hidden = false")
+	}
+
+	order := "id ASC"
+	if query.NewestFirst {
+		order = "id DESC"
+	}
+	sql := fmt.Sprintf(`SELECT %s FROM questions WHERE %s ORDER BY %s LIMIT %s OFFSET %s`,
+		questionColumns, strings.Join(conditions, " AND "), order, args.Add(query.Limit), args.Add(query.Offset))
+
+	return sql, args
+}
+
+// buildFeedQuery は GetFeed の条件からSQLと引数を組み立てる
+func buildFeedQuery(query repositories.QuestionFeedQuery) (string, database.Args) {
+	var args database.Args
+	conditions := []string{
+		"status = " + args.Add(entities.StatusPublished),
+		"is_hidden = false",
+		// 作成者またはジャンルのいずれかに一致する問題
+		fmt.Sprintf("(user_id = ANY(%s) OR genre_id = ANY(%s))", args.Add(query.AuthorIDs), args.Add(query.GenreIDs)),
+	}
+	// カーソルより古い問題（公開日時が同じ場合はIDで順序を決める）
+	if query.Before != nil {
+		conditions = append(conditions, fmt.Sprintf("(published_at, id) < (%s, %s)", args.Add(query.Before.PublishedAt), args.Add(query.Before.ID)))
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM questions WHERE %s ORDER BY published_at DESC, id DESC LIMIT %s`,
+		questionColumns, strings.Join(conditions, " AND "), args.Add(query.Limit))
+
+	return sql, args
+}
+
+// UpdateStatus は問題の公開状態を更新
+func (r *QuestionRepositoryImpl) UpdateStatus(ctx context.Context, question *entities.Question, userToken string) error {
+	_, err := r.db.Exec(ctx, `UPDATE questions SET status = $1, published_at = $2, publish_at = $3 WHERE id = $4`,
+		question.Status, question.PublishedAt, question.PublishAt, question.ID)
+	return database.MapError(err)
+}
+
+// GetDueForPublish は予約公開の日時を過ぎた下書きを取得
+func (r *QuestionRepositoryImpl) GetDueForPublish(ctx context.Context, now time.Time) ([]*entities.Question, error) {
+	return r.query(ctx, `SELECT `+questionColumns+` FROM questions WHERE status = $1 AND publish_at <= $2 ORDER BY publish_at ASC`,
+		entities.StatusDraft, now)
+}
+
+// PublishScheduled は予約公開の問題を公開状態に更新
+// 予約後に作成者が状態を変えていた場合に上書きしないよう、下書きの場合のみ更新する
+func (r *QuestionRepositoryImpl) PublishScheduled(ctx context.Context, question *entities.Question) error {
+	_, err := r.db.Exec(ctx, `UPDATE questions SET status = $1, published_at = $2, publish_at = $3 WHERE id = $4 AND status = $5`,
+		question.Status, question.PublishedAt, question.PublishAt, question.ID, entities.StatusDraft)
+	return database.MapError(err)
+}
+
+// CancelScheduledPublish は下書きの予約公開を取り消す
+func (r *QuestionRepositoryImpl) CancelScheduledPublish(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE questions SET publish_at = NULL WHERE id = $1 AND status = $2`, id, entities.StatusDraft)
+	return database.MapError(err)
+}
+
+// SetHidden は問題の非公開フラグを更新
+func (r *QuestionRepositoryImpl) SetHidden(ctx context.Context, id int64, hidden bool) error {
+	_, err := r.db.Exec(ctx, `UPDATE questions SET is_hidden = $1 WHERE id = $2`, hidden, id)
+	return database.MapError(err)
+}
+
+// IncrementViews は閲覧数を delta だけ原子的に加算
+func (r *QuestionRepositoryImpl) IncrementViews(ctx context.Context, id int64, delta int) error {
+	_, err := r.db.Exec(ctx, `UPDATE questions SET views = views + $1 WHERE id = $2`, delta, id)
+	return database.MapError(err)
+}
+
+// query は問題を検索する共通処理
+func (r *QuestionRepositoryImpl) query(ctx context.Context, sql string, args ...any) ([]*entities.Question, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []*entities.Question
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	return questions, rows.Err()
+}
+
+// scanQuestion は questionColumns の順に読み込んだ行を Question エンティティに変換
+func scanQuestion(row pgx.Row) (*entities.Question, error) {
+	q := &entities.Question{}
+	err := row.Scan(
+		&q.ID, &q.GenreID, &q.UserID, &q.Title, &q.Body, &q.Explanation,
+		&q.BodyFormat, &q.CreatedAt, &q.Views, &q.CorrectCount, &q.IncorrectCount,
+		&q.IsHidden, &q.Revision, &q.Status, &q.PublishedAt, &q.PublishAt,
+		&q.Type, &q.PartialCredit, &q.AcceptedAnswers, &q.NumericAnswer,
+		&q.NumericTolerance, &q.ShuffleChoices,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// nonNilStrings は正解表記を書き込む値に変換（未設定の場合は空配列）
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
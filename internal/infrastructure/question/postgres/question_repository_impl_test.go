@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+func TestBuildPageQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     repositories.QuestionPageQuery
+		wantWhere string
+		wantOrder string
+		wantArgs  database.Args
+	}{
+		{
+			name:      "条件なしはカーソルより後をID順に取得する",
+			query:     repositories.QuestionPageQuery{AfterID: 10, Limit: 20},
+			wantWhere: "WHERE true AND id > $1 ORDER BY",
+			wantOrder: "ORDER BY id ASC LIMIT $2 OFFSET $3",
+			wantArgs:  database.Args{int64(10), 20, 0},
+		},
+		{
+			name: "新しい順ではカーソルを使わずに全ての条件を番号順に並べる",
+			query: repositories.QuestionPageQuery{
+				UserID:        "user-1",
+				GenreID:       3,
+				IDs:           []int64{1, 2},
+				PublishedOnly: true,
+				NewestFirst:   true,
+				Offset:        40,
+				Limit:         20,
+			},
+			wantWhere: "WHERE true AND user_id = $1 AND genre_id = $2 AND id = ANY($3) AND status = $4 AND is_hidden = false ORDER BY",
+			wantOrder: "ORDER BY id DESC LIMIT $5 OFFSET $6",
+			wantArgs:  database.Args{"user-1", int64(3), []int64{1, 2}, entities.StatusPublished, 20, 40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := buildPageQuery(tt.query)
+
+			assert.Contains(t, sql, "SELECT "+questionColumns+" FROM questions ")
+			assert.Contains(t, sql, tt.wantWhere)
+			assert.Contains(t, sql, tt.wantOrder)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestBuildPageQuery_TagSlug(t *testing.T) {
+	sql, args := buildPageQuery(repositories.QuestionPageQuery{TagSlug: "history", Limit: 20})
+
+	assert.Contains(t, sql, "AND t.slug = $2)")
+	assert.Equal(t, database.Args{int64(0), "history", 20, 0}, args)
+}
+
+func TestBuildFeedQuery(t *testing.T) {
+	query := repositories.QuestionFeedQuery{
+		AuthorIDs: []string{"user-1"},
+		GenreIDs:  []int64{2},
+		Limit:     20,
+	}
+
+	sql, args := buildFeedQuery(query)
+	assert.Contains(t, sql, "WHERE status = $1 AND is_hidden = false AND (user_id = ANY($2) OR genre_id = ANY($3)) ORDER BY published_at DESC, id DESC LIMIT $4")
+	assert.Equal(t, database.Args{entities.StatusPublished, []string{"user-1"}, []int64{2}, 20}, args)
+
+	// カーソルがある場合は公開日時・IDの組で比較する
+	publishedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	query.Before = &repositories.FeedPosition{PublishedAt: publishedAt, ID: 99}
+	sql, args = buildFeedQuery(query)
+	assert.Contains(t, sql, "AND (published_at, id) < ($4, $5) ORDER BY published_at DESC, id DESC LIMIT $6")
+	assert.Equal(t, database.Args{entities.StatusPublished, []string{"user-1"}, []int64{2}, publishedAt, int64(99), 20}, args)
+}
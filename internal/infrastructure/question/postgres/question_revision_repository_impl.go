@@ -0,0 +1,117 @@
+package postgres
+
+// question_revision_repository_impl.goはPostgreSQLを直接使用したQuestionRevisionRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// revisionColumns はリビジョンを取得する際の列（scanQuestionRevision と同じ順序）
+// 正解の情報を記録する前のリビジョンは種類が空文字になる
+const revisionColumns = `id, question_id, revision, user_id, title, body, COALESCE(explanation, ''),
+	COALESCE(type, ''), partial_credit, accepted_answers, numeric_answer, numeric_tolerance,
+	COALESCE(diff, ''), created_at`
+
+// QuestionRevisionRepositoryImpl はPostgreSQLを使用したQuestionRevisionRepositoryの実装
+type QuestionRevisionRepositoryImpl struct {
+	db database.DB
+}
+
+// NewQuestionRevisionRepository は新しいQuestionRevisionRepositoryImplを作成
+// db には接続プールのほか、ユニットオブワークのトランザクションも渡せる
+func NewQuestionRevisionRepository(db database.DB) repositories.QuestionRevisionRepository {
+	return &QuestionRevisionRepositoryImpl{db: db}
+}
+
+// Create は新しいリビジョンを作成
+func (r *QuestionRevisionRepositoryImpl) Create(ctx context.Context, revision *entities.QuestionRevision, userToken string) (*entities.QuestionRevision, error) {
+	created, err := r.CreateBatch(ctx, []*entities.QuestionRevision{revision}, userToken)
+	if err != nil {
+		return nil, err
+	}
+	return created[0], nil
+}
+
+// CreateBatch は複数のリビジョンを1つのトランザクションでまとめて作成
+func (r *QuestionRevisionRepositoryImpl) CreateBatch(ctx context.Context, revisions []*entities.QuestionRevision, userToken string) ([]*entities.QuestionRevision, error) {
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+
+	created := make([]*entities.QuestionRevision, len(revisions))
+	err := database.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		for i, revision := range revisions {
+			row := tx.QueryRow(ctx, `
+				INSERT INTO question_revisions (question_id, revision, user_id, title, body, explanation,
+					type, partial_credit, accepted_answers, numeric_answer, numeric_tolerance, diff)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+				RETURNING `+revisionColumns,
+				revision.QuestionID, revision.Revision, revision.UserID, revision.Title, revision.Body, revision.Explanation,
+				revision.Type, revision.PartialCredit, nonNilStrings(revision.AcceptedAnswers), revision.NumericAnswer, revision.NumericTolerance,
+				revision.Diff,
+			)
+			rev, err := scanQuestionRevision(row)
+			if err != nil {
+				return database.MapError(err)
+			}
+			created[i] = rev
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetByQuestionID は問題のリビジョン一覧を新しい順に取得
+func (r *QuestionRevisionRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.QuestionRevision, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+revisionColumns+` FROM question_revisions WHERE question_id = $1 ORDER BY revision DESC`, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*entities.QuestionRevision
+	for rows.Next() {
+		revision, err := scanQuestionRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+// GetByRevision は問題の特定のリビジョンを取得
+func (r *QuestionRevisionRepositoryImpl) GetByRevision(ctx context.Context, questionID int64, revision int) (*entities.QuestionRevision, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+revisionColumns+` FROM question_revisions WHERE question_id = $1 AND revision = $2`, questionID, revision)
+	found, err := scanQuestionRevision(row)
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "リビジョンが見つかりません")
+		}
+		return nil, err
+	}
+	return found, nil
+}
+
+// scanQuestionRevision は revisionColumns の順に読み込んだ行を QuestionRevision エンティティに変換
+func scanQuestionRevision(row pgx.Row) (*entities.QuestionRevision, error) {
+	r := &entities.QuestionRevision{}
+	err := row.Scan(&r.ID, &r.QuestionID, &r.Revision, &r.UserID, &r.Title, &r.Body, &r.Explanation,
+		&r.Type, &r.PartialCredit, &r.AcceptedAnswers, &r.NumericAnswer, &r.NumericTolerance,
+		&r.Diff, &r.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,37 @@
+package postgres
+
+// question_unit_of_work_impl.goはPostgreSQLのトランザクションを使用したQuestionUnitOfWorkの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"Shittaka_back/internal/domain/question/repositories"
+	choicePostgres "Shittaka_back/internal/infrastructure/choice/postgres"
+	"Shittaka_back/internal/infrastructure/database"
+	tagPostgres "Shittaka_back/internal/infrastructure/tag/postgres"
+)
+
+// QuestionUnitOfWorkImpl はPostgreSQLのトランザクションを使用したQuestionUnitOfWorkの実装
+type QuestionUnitOfWorkImpl struct {
+	db database.DB
+}
+
+// NewQuestionUnitOfWork は新しいQuestionUnitOfWorkImplを作成
+func NewQuestionUnitOfWork(db database.DB) repositories.QuestionUnitOfWork {
+	return &QuestionUnitOfWorkImpl{db: db}
+}
+
+// Do はトランザクションを開始し、そのトランザクションで動くリポジトリを fn に渡す
+// リポジトリ内のトランザクション（CreateBatch など）はセーブポイントになり、全体が1回でコミットされる
+func (u *QuestionUnitOfWorkImpl) Do(ctx context.Context, fn func(repos repositories.QuestionWriteRepositories) error) error {
+	return database.WithTx(ctx, u.db, func(tx pgx.Tx) error {
+		return fn(repositories.QuestionWriteRepositories{
+			Questions: NewQuestionRepository(tx),
+			Revisions: NewQuestionRevisionRepository(tx),
+			Choices:   choicePostgres.NewChoiceRepository(tx),
+			Tags:      tagPostgres.NewTagRepository(tx),
+		})
+	})
+}
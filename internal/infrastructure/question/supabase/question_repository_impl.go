@@ -1,161 +1,104 @@
 package supabase
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"Shittaka_back/internal/domain/question/entities"
 	"Shittaka_back/internal/domain/question/repositories"
 	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
 )
 
 // QuestionRepositoryImpl はSupabaseを使用したQuestionRepositoryの実装
-type QuestionRepositoryImpl struct{}
+// 正解の列は公開キーやユーザーのトークンでは読めない（列の権限で除いている）ため、
+// 読み取りはサービスロールキーで行い、閲覧できるかどうかはユースケースで判定する
+type QuestionRepositoryImpl struct {
+	client *postgrest.Client
+}
 
 // NewQuestionRepository は新しいQuestionRepositoryImplを作成
-func NewQuestionRepository() repositories.QuestionRepository {
-	return &QuestionRepositoryImpl{}
+func NewQuestionRepository(client *postgrest.Client) repositories.QuestionRepository {
+	return &QuestionRepositoryImpl{client: client}
 }
 
 // Create は新しい問題を作成（RLS適用のためユーザートークンを使用）
 func (r *QuestionRepositoryImpl) Create(ctx context.Context, question *entities.Question, userToken string) (*entities.Question, error) {
-	questionData := map[string]interface{}{
-		"genre_id":    question.GenreID,
-		"user_id":     question.UserID,
-		"title":       question.Title,
-		"body":        question.Body,
-		"explanation": question.Explanation,
-	}
-
-	jsonData, err := json.Marshal(questionData)
+	created, err := r.CreateBatch(ctx, []*entities.Question{question}, userToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal question data: %w", err)
+		return nil, err
 	}
+	return created[0], nil
+}
 
-	url := os.Getenv("SUPABASE_URL") + "/rest/v1/questions"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// CreateBatch は複数の問題を1回のリクエストでまとめて作成（RLS適用のためユーザートークンを使用）
+// ユーザーのトークンでは正解の列を読めないため作成時は ID だけを返させ、作成した行をサービスロールキーで読み直す
+// 作成された問題は渡した順に返す
+func (r *QuestionRepositoryImpl) CreateBatch(ctx context.Context, questions []*entities.Question, userToken string) ([]*entities.Question, error) {
+	if len(questions) == 0 {
+		return nil, nil
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
-	req.Header.Set("Prefer", "return=representation")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+	questionDataList := make([]map[string]interface{}, len(questions))
+	for i, question := range questions {
+		questionDataList[i] = createData(question)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	var inserted []struct {
+		ID int64 `json:"id"`
+	}
+	if err := r.client.From("questions").WithToken(userToken).Select("id").Insert(ctx, questionDataList, &inserted); err != nil {
+		return nil, err
+	}
+	if len(inserted) != len(questions) {
+		return nil, fmt.Errorf("create question returned %d rows for %d questions", len(inserted), len(questions))
 	}
 
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("create question failed with status %d: %s", resp.StatusCode, string(body))
+	ids := make([]int64, len(inserted))
+	for i, row := range inserted {
+		ids[i] = row.ID
 	}
 
+	// 複数行の INSERT では ID が渡した順に採番される
 	var questionList []map[string]interface{}
-	if err := json.Unmarshal(body, &questionList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	err := r.client.From("questions").AsServiceRole().
+		In("id", postgrest.Int64s(ids)...).
+		Order("id", true).
+		Find(ctx, &questionList)
+	if err != nil {
+		return nil, err
 	}
-
-	if len(questionList) == 0 {
-		return nil, fmt.Errorf("no question returned from create operation")
+	if len(questionList) != len(questions) {
+		return nil, fmt.Errorf("create question read back %d rows for %d questions", len(questionList), len(questions))
 	}
 
-	questionResp := questionList[0]
-	return mapToQuestion(questionResp), nil
+	return mapToQuestions(questionList), nil
 }
 
 // GetByID はIDで問題を検索
 func (r *QuestionRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Question, error) {
-	url := fmt.Sprintf("%s/rest/v1/questions?id=eq.%d", os.Getenv("SUPABASE_URL"), id)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find question failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var questionList []map[string]interface{}
-	if err := json.Unmarshal(body, &questionList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if len(questionList) == 0 {
-		return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+	var questionData map[string]interface{}
+	if err := r.client.From("questions").AsServiceRole().Eq("id", id).Single(ctx, &questionData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "問題が見つかりません")
+		}
+		return nil, err
 	}
 
-	return mapToQuestion(questionList[0]), nil
+	return mapToQuestion(questionData), nil
 }
 
 // GetByUserID はユーザーIDで問題一覧を取得
 func (r *QuestionRepositoryImpl) GetByUserID(ctx context.Context, userID string, userToken string) ([]*entities.Question, error) {
-	url := fmt.Sprintf("%s/rest/v1/questions?user_id=eq.%s", os.Getenv("SUPABASE_URL"), userID)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find questions by user failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var questionList []map[string]interface{}
-	if err := json.Unmarshal(body, &questionList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	questions := make([]*entities.Question, len(questionList))
-	for i, questionData := range questionList {
-		questions[i] = mapToQuestion(questionData)
+	if err := r.client.From("questions").AsServiceRole().Eq("user_id", userID).Find(ctx, &questionList); err != nil {
+		return nil, err
 	}
 
-	return questions, nil
+	return mapToQuestions(questionList), nil
 }
 
 // Update は問題を更新（RLS適用のためユーザートークンを使用）
@@ -164,115 +107,181 @@ func (r *QuestionRepositoryImpl) Update(ctx context.Context, question *entities.
 		"title":       question.Title,
 		"body":        question.Body,
 		"explanation": question.Explanation,
+		"revision":    question.Revision,
 	}
-
-	jsonData, err := json.Marshal(questionData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal question data: %w", err)
+	questionData["shuffle_choices"] = question.ShuffleChoices
+	questionData["body_format"] = question.BodyFormat
+	for key, value := range answerKeyData(question) {
+		questionData[key] = value
 	}
 
-	url := fmt.Sprintf("%s/rest/v1/questions?id=eq.%d", os.Getenv("SUPABASE_URL"), question.ID)
-	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	return r.client.From("questions").Eq("id", question.ID).WithToken(userToken).Update(ctx, questionData, nil)
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
+// Delete は問題を削除（RLS適用のためユーザートークンを使用）
+func (r *QuestionRepositoryImpl) Delete(ctx context.Context, id int64, userToken string) error {
+	return r.client.From("questions").Eq("id", id).WithToken(userToken).Delete(ctx)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// GetAll は公開中の問題を全て取得（通報により非公開になった問題は除く）
+func (r *QuestionRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Question, error) {
+	var questionList []map[string]interface{}
+	err := r.client.From("questions").AsServiceRole().
+		Eq("status", entities.StatusPublished).
+		Eq("is_hidden", false).
+		Find(ctx, &questionList)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+	return mapToQuestions(questionList), nil
+}
+
+// GetPage は条件に合う問題をID順に最大 Limit 件取得（下書きを含めるかどうかは query.PublishedOnly でユースケースが決める）
+func (r *QuestionRepositoryImpl) GetPage(ctx context.Context, query repositories.QuestionPageQuery, userToken string) ([]*entities.Question, error) {
+	q := r.client.From("questions").AsServiceRole()
+	if query.NewestFirst {
+		q.Order("id", false)
+	} else {
+		q.Gt("id", query.AfterID).Order("id", true)
+	}
+	q.Limit(query.Limit)
+	if query.Offset > 0 {
+		q.Offset(query.Offset)
+	}
+	if query.UserID != "" {
+		q.Eq("user_id", query.UserID)
+	}
+	if query.GenreID != 0 {
+		q.Eq("genre_id", query.GenreID)
+	}
+	if query.TagSlug != "" {
+		// タグを内部結合で埋め込み、タグの付いた問題だけに絞り込む
+		q.Select("*,question_tags!inner(tags!inner(slug))").Eq("question_tags.tags.slug", query.TagSlug)
+	}
+	if len(query.IDs) > 0 {
+		q.In("id", postgrest.Int64s(query.IDs)...)
+	}
+	if query.PublishedOnly {
+		q.Eq("status", entities.StatusPublished).Eq("is_hidden", false)
 	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("update question failed with status %d: %s", resp.StatusCode, string(body))
+	var questionList []map[string]interface{}
+	if err := q.Find(ctx, &questionList); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return mapToQuestions(questionList), nil
 }
 
-// Delete は問題を削除（RLS適用のためユーザートークンを使用）
-func (r *QuestionRepositoryImpl) Delete(ctx context.Context, id int64, userToken string) error {
-	url := fmt.Sprintf("%s/rest/v1/questions?id=eq.%d", os.Getenv("SUPABASE_URL"), id)
-	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// GetFeed はフォローしている作成者・ジャンルの公開中の問題を公開日時の新しい順に最大 Limit 件取得
+func (r *QuestionRepositoryImpl) GetFeed(ctx context.Context, query repositories.QuestionFeedQuery) ([]*entities.Question, error) {
+	// 作成者またはジャンルのいずれかに一致する問題
+	var sources []string
+	if len(query.AuthorIDs) > 0 {
+		sources = append(sources, "user_id.in."+postgrest.List(postgrest.Strings(query.AuthorIDs)...))
 	}
+	if len(query.GenreIDs) > 0 {
+		sources = append(sources, "genre_id.in."+postgrest.List(postgrest.Int64s(query.GenreIDs)...))
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	conditions := []string{"or(" + strings.Join(sources, ",") + ")"}
 
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+userToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+	// カーソルより古い問題（公開日時が同じ場合はIDで順序を決める）
+	if query.Before != nil {
+		publishedAt := postgrest.Quote(query.Before.PublishedAt)
+		conditions = append(conditions, fmt.Sprintf("or(published_at.lt.%s,and(published_at.eq.%s,id.lt.%d))", publishedAt, publishedAt, query.Before.ID))
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	var questionList []map[string]interface{}
+	err := r.client.From("questions").AsServiceRole().
+		Eq("status", entities.StatusPublished).
+		Eq("is_hidden", false).
+		And(conditions...).
+		Order("published_at", false).
+		Order("id", false).
+		Limit(query.Limit).
+		Find(ctx, &questionList)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("delete question failed with status %d: %s", resp.StatusCode, string(body))
-	}
+	return mapToQuestions(questionList), nil
+}
 
-	return nil
+// UpdateStatus は問題の公開状態を更新（RLS適用のためユーザートークンを使用）
+func (r *QuestionRepositoryImpl) UpdateStatus(ctx context.Context, question *entities.Question, userToken string) error {
+	return r.client.From("questions").Eq("id", question.ID).WithToken(userToken).Update(ctx, statusData(question), nil)
 }
 
-// GetAll は全ての問題を取得
-func (r *QuestionRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Question, error) {
-	url := os.Getenv("SUPABASE_URL") + "/rest/v1/questions"
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// GetDueForPublish は予約公開の日時を過ぎた下書きを取得（スケジューラー用のためサービスロールキーを使用）
+func (r *QuestionRepositoryImpl) GetDueForPublish(ctx context.Context, now time.Time) ([]*entities.Question, error) {
+	var questionList []map[string]interface{}
+	err := r.client.From("questions").
+		Eq("status", entities.StatusDraft).
+		Lte("publish_at", now).
+		Order("publish_at", true).
+		AsServiceRole().
+		Find(ctx, &questionList)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("apikey", os.Getenv("SUPABASE_ANON_KEY"))
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_ANON_KEY"))
+	return mapToQuestions(questionList), nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
+// PublishScheduled は予約公開の問題を公開状態に更新（スケジューラー用のためサービスロールキーを使用）
+// 予約後に作成者が状態を変えていた場合に上書きしないよう、下書きの場合のみ更新する
+func (r *QuestionRepositoryImpl) PublishScheduled(ctx context.Context, question *entities.Question) error {
+	return r.client.From("questions").
+		Eq("id", question.ID).
+		Eq("status", entities.StatusDraft).
+		AsServiceRole().
+		Update(ctx, statusData(question), nil)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+// CancelScheduledPublish は下書きの予約公開を取り消す（スケジューラー用のためサービスロールキーを使用）
+func (r *QuestionRepositoryImpl) CancelScheduledPublish(ctx context.Context, id int64) error {
+	return r.client.From("questions").
+		Eq("id", id).
+		Eq("status", entities.StatusDraft).
+		AsServiceRole().
+		Update(ctx, map[string]interface{}{"publish_at": nil}, nil)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find all questions failed with status %d: %s", resp.StatusCode, string(body))
-	}
+// SetHidden は問題の非公開フラグを更新（通報処理のためサービスロールキーを使用）
+func (r *QuestionRepositoryImpl) SetHidden(ctx context.Context, id int64, hidden bool) error {
+	return r.client.From("questions").
+		Eq("id", id).
+		AsServiceRole().
+		Update(ctx, map[string]interface{}{"is_hidden": hidden}, nil)
+}
 
-	var questionList []map[string]interface{}
-	if err := json.Unmarshal(body, &questionList); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+// IncrementViews は閲覧数を delta だけ加算（RPCで原子的に加算するためサービスロールキーを使用）
+func (r *QuestionRepositoryImpl) IncrementViews(ctx context.Context, id int64, delta int) error {
+	return r.client.RPC("increment_question_views").
+		AsServiceRole().
+		Call(ctx, map[string]interface{}{
+			"question_id": id,
+			"delta":       delta,
+		}, nil)
+}
 
+// mapToQuestions は取得した行の一覧を Question エンティティの一覧に変換
+func mapToQuestions(questionList []map[string]interface{}) []*entities.Question {
 	questions := make([]*entities.Question, len(questionList))
 	for i, questionData := range questionList {
 		questions[i] = mapToQuestion(questionData)
 	}
-
-	return questions, nil
+	return questions
 }
 
 // mapToQuestion は map[string]interface{} を Question エンティティに変換
+// 種類が未設定の既存の問題は単一選択として扱う
 func mapToQuestion(m map[string]interface{}) *entities.Question {
-	return &entities.Question{
+	question := &entities.Question{
 		ID:             getInt64(m, "id"),
 		GenreID:        getInt64(m, "genre_id"),
 		UserID:         getString(m, "user_id"),
@@ -283,6 +292,73 @@ func mapToQuestion(m map[string]interface{}) *entities.Question {
 		Views:          getInt(m, "views"),
 		CorrectCount:   getInt(m, "correct_count"),
 		IncorrectCount: getInt(m, "incorrect_count"),
+		IsHidden:       getBool(m, "is_hidden"),
+		Revision:       getInt(m, "revision"),
+		Status:         getString(m, "status"),
+		PublishedAt:    getOptionalTime(m, "published_at"),
+		PublishAt:      getOptionalTime(m, "publish_at"),
+
+		Type:             getString(m, "type"),
+		PartialCredit:    getBool(m, "partial_credit"),
+		AcceptedAnswers:  getStringSlice(m, "accepted_answers"),
+		NumericAnswer:    getOptionalFloat64(m, "numeric_answer"),
+		NumericTolerance: getFloat64(m, "numeric_tolerance"),
+		ShuffleChoices:   getBool(m, "shuffle_choices"),
+		BodyFormat:       getString(m, "body_format"),
+	}
+	if question.Type == "" {
+		question.Type = entities.TypeSingleChoice
+	}
+	if question.BodyFormat == "" {
+		question.BodyFormat = entities.BodyFormatPlain
+	}
+	return question
+}
+
+// createData は作成する問題を map に変換
+// まとめて作成する場合も全ての行が同じキーを持つようにする
+func createData(question *entities.Question) map[string]interface{} {
+	questionData := map[string]interface{}{
+		"genre_id":        question.GenreID,
+		"user_id":         question.UserID,
+		"title":           question.Title,
+		"body":            question.Body,
+		"explanation":     question.Explanation,
+		"body_format":     question.BodyFormat,
+		"revision":        question.Revision,
+		"shuffle_choices": question.ShuffleChoices,
+	}
+	for key, value := range statusData(question) {
+		questionData[key] = value
+	}
+	for key, value := range answerKeyData(question) {
+		questionData[key] = value
+	}
+	return questionData
+}
+
+// answerKeyData は問題の種類と正解の情報を map に変換
+func answerKeyData(question *entities.Question) map[string]interface{} {
+	acceptedAnswers := question.AcceptedAnswers
+	if acceptedAnswers == nil {
+		acceptedAnswers = []string{}
+	}
+
+	return map[string]interface{}{
+		"type":              question.Type,
+		"partial_credit":    question.PartialCredit,
+		"accepted_answers":  acceptedAnswers,
+		"numeric_answer":    question.NumericAnswer,
+		"numeric_tolerance": question.NumericTolerance,
+	}
+}
+
+// statusData は公開状態の更新内容を map に変換
+func statusData(question *entities.Question) map[string]interface{} {
+	return map[string]interface{}{
+		"status":       question.Status,
+		"published_at": formatTimePtr(question.PublishedAt),
+		"publish_at":   formatTimePtr(question.PublishAt),
 	}
 }
 
@@ -336,6 +412,56 @@ func getInt(m map[string]interface{}, key string) int {
 	return 0
 }
 
+// getFloat64 は map から float64 を安全に取得
+func getFloat64(m map[string]interface{}, key string) float64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return v
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+// getOptionalFloat64 は map から NULL 許容の float64 を取得
+func getOptionalFloat64(m map[string]interface{}, key string) *float64 {
+	if val, ok := m[key]; !ok || val == nil {
+		return nil
+	}
+	f := getFloat64(m, key)
+	return &f
+}
+
+// getStringSlice は map から []string を安全に取得
+func getStringSlice(m map[string]interface{}, key string) []string {
+	values, ok := m[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if str, ok := v.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+// getBool は map から bool を安全に取得
+func getBool(m map[string]interface{}, key string) bool {
+	if val, ok := m[key]; ok {
+		if b, ok := val.(bool); ok {
+			return b
+		}
+	}
+	return false
+}
+
 // getTime は map から time.Time を安全に取得
 func getTime(m map[string]interface{}, key string) time.Time {
 	if val, ok := m[key]; ok {
@@ -346,4 +472,21 @@ func getTime(m map[string]interface{}, key string) time.Time {
 		}
 	}
 	return time.Time{}
-}
\ No newline at end of file
+}
+
+// getOptionalTime は map から *time.Time を安全に取得（null の場合は nil）
+func getOptionalTime(m map[string]interface{}, key string) *time.Time {
+	t := getTime(m, key)
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// formatTimePtr は *time.Time をJSON用の値に変換（nil の場合は null）
+func formatTimePtr(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,150 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+
+	"Shittaka_back/internal/domain/question/entities"
+	"Shittaka_back/internal/domain/question/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// QuestionRevisionRepositoryImpl はSupabaseを使用したQuestionRevisionRepositoryの実装
+// リビジョンは作成者と問題の作成者にしか読めないため、読み取りはサービスロールキーで行い、閲覧できるかどうかはユースケースで判定する
+type QuestionRevisionRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewQuestionRevisionRepository は新しいQuestionRevisionRepositoryImplを作成
+func NewQuestionRevisionRepository(client *postgrest.Client) repositories.QuestionRevisionRepository {
+	return &QuestionRevisionRepositoryImpl{client: client}
+}
+
+// Create は新しいリビジョンを作成（RLS適用のためユーザートークンを使用）
+func (r *QuestionRevisionRepositoryImpl) Create(ctx context.Context, revision *entities.QuestionRevision, userToken string) (*entities.QuestionRevision, error) {
+	created, err := r.CreateBatch(ctx, []*entities.QuestionRevision{revision}, userToken)
+	if err != nil {
+		return nil, err
+	}
+	return created[0], nil
+}
+
+// CreateBatch は複数のリビジョンを1回のリクエストでまとめて作成（RLS適用のためユーザートークンを使用）
+// ユーザーのトークンでは正解の列を読めないため作成時は ID だけを返させ、作成した行をサービスロールキーで読み直す
+func (r *QuestionRevisionRepositoryImpl) CreateBatch(ctx context.Context, revisions []*entities.QuestionRevision, userToken string) ([]*entities.QuestionRevision, error) {
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+
+	revisionDataList := make([]map[string]interface{}, len(revisions))
+	for i, revision := range revisions {
+		revisionDataList[i] = map[string]interface{}{
+			"question_id": revision.QuestionID,
+			"revision":    revision.Revision,
+			"user_id":     revision.UserID,
+			"title":       revision.Title,
+			"body":        revision.Body,
+			"explanation": revision.Explanation,
+			"diff":        revision.Diff,
+		}
+		for key, value := range answerKeyData(&entities.Question{
+			Type:             revision.Type,
+			PartialCredit:    revision.PartialCredit,
+			AcceptedAnswers:  revision.AcceptedAnswers,
+			NumericAnswer:    revision.NumericAnswer,
+			NumericTolerance: revision.NumericTolerance,
+		}) {
+			revisionDataList[i][key] = value
+		}
+	}
+
+	var inserted []struct {
+		ID int64 `json:"id"`
+	}
+	if err := r.client.From("question_revisions").WithToken(userToken).Select("id").Insert(ctx, revisionDataList, &inserted); err != nil {
+		return nil, err
+	}
+	if len(inserted) != len(revisions) {
+		return nil, fmt.Errorf("create question revision returned %d rows for %d revisions", len(inserted), len(revisions))
+	}
+
+	ids := make([]int64, len(inserted))
+	for i, row := range inserted {
+		ids[i] = row.ID
+	}
+
+	var revisionList []map[string]interface{}
+	err := r.client.From("question_revisions").AsServiceRole().
+		In("id", postgrest.Int64s(ids)...).
+		Order("id", true).
+		Find(ctx, &revisionList)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisionList) != len(revisions) {
+		return nil, fmt.Errorf("create question revision read back %d rows for %d revisions", len(revisionList), len(revisions))
+	}
+
+	return mapToQuestionRevisions(revisionList), nil
+}
+
+// GetByQuestionID は問題のリビジョン一覧を新しい順に取得
+func (r *QuestionRevisionRepositoryImpl) GetByQuestionID(ctx context.Context, questionID int64) ([]*entities.QuestionRevision, error) {
+	var revisionList []map[string]interface{}
+	err := r.client.From("question_revisions").AsServiceRole().
+		Eq("question_id", questionID).
+		Order("revision", false).
+		Find(ctx, &revisionList)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToQuestionRevisions(revisionList), nil
+}
+
+// GetByRevision は問題の特定のリビジョンを取得
+func (r *QuestionRevisionRepositoryImpl) GetByRevision(ctx context.Context, questionID int64, revision int) (*entities.QuestionRevision, error) {
+	var revisionData map[string]interface{}
+	err := r.client.From("question_revisions").AsServiceRole().
+		Eq("question_id", questionID).
+		Eq("revision", revision).
+		Single(ctx, &revisionData)
+	if err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "リビジョンが見つかりません")
+		}
+		return nil, err
+	}
+
+	return mapToQuestionRevision(revisionData), nil
+}
+
+// mapToQuestionRevisions は取得した行の一覧を QuestionRevision エンティティの一覧に変換
+func mapToQuestionRevisions(revisionList []map[string]interface{}) []*entities.QuestionRevision {
+	revisions := make([]*entities.QuestionRevision, len(revisionList))
+	for i, revisionData := range revisionList {
+		revisions[i] = mapToQuestionRevision(revisionData)
+	}
+	return revisions
+}
+
+// mapToQuestionRevision は map[string]interface{} を QuestionRevision エンティティに変換
+func mapToQuestionRevision(m map[string]interface{}) *entities.QuestionRevision {
+	return &entities.QuestionRevision{
+		ID:               getInt64(m, "id"),
+		QuestionID:       getInt64(m, "question_id"),
+		Revision:         getInt(m, "revision"),
+		UserID:           getString(m, "user_id"),
+		Title:            getString(m, "title"),
+		Body:             getString(m, "body"),
+		Explanation:      getString(m, "explanation"),
+		Type:             getString(m, "type"),
+		PartialCredit:    getBool(m, "partial_credit"),
+		AcceptedAnswers:  getStringSlice(m, "accepted_answers"),
+		NumericAnswer:    getOptionalFloat64(m, "numeric_answer"),
+		NumericTolerance: getFloat64(m, "numeric_tolerance"),
+		Diff:             getString(m, "diff"),
+		CreatedAt:        getTime(m, "created_at"),
+	}
+}
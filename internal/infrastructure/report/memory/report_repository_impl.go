@@ -0,0 +1,111 @@
+package memory
+
+// report_repository_impl.goはメモリ上に保持するReportRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"Shittaka_back/internal/domain/report/entities"
+	"Shittaka_back/internal/domain/report/repositories"
+	"Shittaka_back/internal/domain/shared"
+)
+
+// ReportRepositoryImpl はメモリ上に保持するReportRepositoryの実装
+type ReportRepositoryImpl struct {
+	mu      sync.RWMutex
+	reports []*entities.Report // 作成順（ID順）
+}
+
+// NewReportRepository は新しいReportRepositoryImplを作成
+func NewReportRepository() repositories.ReportRepository {
+	return &ReportRepositoryImpl{}
+}
+
+// Create は新しい通報を作成
+func (r *ReportRepositoryImpl) Create(ctx context.Context, report *entities.Report, userToken string) (*entities.Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := *report
+	rep.ID = int64(len(r.reports) + 1)
+	rep.CreatedAt = time.Now()
+	r.reports = append(r.reports, &rep)
+
+	created := rep
+	return &created, nil
+}
+
+// GetByID はIDで通報を取得
+func (r *ReportRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Report, error) {
+	reports := r.filter(func(rep *entities.Report) bool { return rep.ID == id })
+	if len(reports) == 0 {
+		return nil, shared.NewDomainError("NOT_FOUND", "通報が見つかりません")
+	}
+	return reports[0], nil
+}
+
+// ListByStatus は状態で絞り込んだ通報を古い順に取得
+func (r *ReportRepositoryImpl) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*entities.Report, error) {
+	reports := r.filter(func(rep *entities.Report) bool { return rep.Status == status })
+	if offset >= len(reports) {
+		return nil, nil
+	}
+	reports = reports[offset:]
+	if limit < len(reports) {
+		reports = reports[:limit]
+	}
+	return reports, nil
+}
+
+// CountOpenByQuestionID は問題に対する未対応の通報の件数を返す
+func (r *ReportRepositoryImpl) CountOpenByQuestionID(ctx context.Context, questionID int64) (int, error) {
+	reports := r.filter(func(rep *entities.Report) bool {
+		return rep.QuestionID == questionID && rep.Status == entities.StatusOpen
+	})
+	return len(reports), nil
+}
+
+// ExistsOpenByUserAndQuestion はユーザーが問題に未対応の通報をしているかどうかを判定
+func (r *ReportRepositoryImpl) ExistsOpenByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error) {
+	reports := r.filter(func(rep *entities.Report) bool {
+		return rep.UserID == userID && rep.QuestionID == questionID && rep.Status == entities.StatusOpen
+	})
+	return len(reports) > 0, nil
+}
+
+// UpdateStatus は通報の状態を更新（対応日時は指定された場合のみ更新する）
+func (r *ReportRepositoryImpl) UpdateStatus(ctx context.Context, report *entities.Report) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rep := range r.reports {
+		if rep.ID != report.ID {
+			continue
+		}
+		rep.Status = report.Status
+		rep.ResolvedBy = report.ResolvedBy
+		if report.ResolvedAt != nil {
+			rep.ResolvedAt = report.ResolvedAt
+		}
+		return nil
+	}
+	return nil
+}
+
+// filter は条件に合う通報を作成順に複製して返す
+func (r *ReportRepositoryImpl) filter(match func(rep *entities.Report) bool) []*entities.Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var reports []*entities.Report
+	for _, rep := range r.reports {
+		if match(rep) {
+			copied := *rep
+			reports = append(reports, &copied)
+		}
+	}
+	return reports
+}
@@ -0,0 +1,114 @@
+package postgres
+
+// report_repository_impl.goはPostgreSQLを直接使用したReportRepositoryの実装
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"Shittaka_back/internal/domain/report/entities"
+	"Shittaka_back/internal/domain/report/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// reportColumns は通報を取得する際の列（scanReport と同じ順序）
+const reportColumns = `id, question_id, user_id, reason, COALESCE(comment, ''), status, created_at, resolved_at, COALESCE(resolved_by::text, '')`
+
+// ReportRepositoryImpl はPostgreSQLを使用したReportRepositoryの実装
+type ReportRepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// NewReportRepository は新しいReportRepositoryImplを作成
+func NewReportRepository(pool *pgxpool.Pool) repositories.ReportRepository {
+	return &ReportRepositoryImpl{pool: pool}
+}
+
+// Create は新しい通報を作成
+func (r *ReportRepositoryImpl) Create(ctx context.Context, report *entities.Report, userToken string) (*entities.Report, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO reports (question_id, user_id, reason, comment, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+reportColumns,
+		report.QuestionID, report.UserID, report.Reason, report.Comment, report.Status,
+	)
+	created, err := scanReport(row)
+	if err != nil {
+		return nil, database.MapError(err)
+	}
+	return created, nil
+}
+
+// GetByID はIDで通報を取得
+func (r *ReportRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Report, error) {
+	report, err := scanReport(r.pool.QueryRow(ctx, `SELECT `+reportColumns+` FROM reports WHERE id = $1`, id))
+	if err != nil {
+		if database.IsNoRows(err) {
+			return nil, shared.NewDomainError("NOT_FOUND", "通報が見つかりません")
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// ListByStatus は状態で絞り込んだ通報を古い順に取得
+func (r *ReportRepositoryImpl) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*entities.Report, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+reportColumns+` FROM reports
+		WHERE status = $1
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2 OFFSET $3`,
+		status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*entities.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// CountOpenByQuestionID は問題に対する未対応の通報の件数を返す
+func (r *ReportRepositoryImpl) CountOpenByQuestionID(ctx context.Context, questionID int64) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM reports WHERE question_id = $1 AND status = $2`,
+		questionID, entities.StatusOpen).Scan(&count)
+	return count, err
+}
+
+// ExistsOpenByUserAndQuestion はユーザーが問題に未対応の通報をしているかどうかを判定
+func (r *ReportRepositoryImpl) ExistsOpenByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM reports WHERE user_id = $1 AND question_id = $2 AND status = $3)`,
+		userID, questionID, entities.StatusOpen).Scan(&exists)
+	return exists, err
+}
+
+// UpdateStatus は通報の状態を更新
+func (r *ReportRepositoryImpl) UpdateStatus(ctx context.Context, report *entities.Report) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE reports SET status = $1, resolved_by = NULLIF($2, '')::uuid, resolved_at = COALESCE($3, resolved_at)
+		WHERE id = $4`,
+		report.Status, report.ResolvedBy, report.ResolvedAt, report.ID)
+	return database.MapError(err)
+}
+
+// scanReport は reportColumns の順に読み込んだ行を Report エンティティに変換
+func scanReport(row pgx.Row) (*entities.Report, error) {
+	r := &entities.Report{}
+	err := row.Scan(&r.ID, &r.QuestionID, &r.UserID, &r.Reason, &r.Comment, &r.Status, &r.CreatedAt, &r.ResolvedAt, &r.ResolvedBy)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,188 @@
+package supabase
+
+// report_repository_impl.goはSupabaseを使用したReportRepositoryの実装
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"Shittaka_back/internal/domain/report/entities"
+	"Shittaka_back/internal/domain/report/repositories"
+	"Shittaka_back/internal/domain/shared"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// ReportRepositoryImpl はSupabaseを使用したReportRepositoryの実装
+// 通報は通報者以外に見せないため、参照系はサービスロールキーで実行する
+type ReportRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewReportRepository は新しいReportRepositoryImplを作成
+func NewReportRepository(client *postgrest.Client) repositories.ReportRepository {
+	return &ReportRepositoryImpl{client: client}
+}
+
+// Create は新しい通報を作成（RLS適用のためユーザートークンを使用）
+func (r *ReportRepositoryImpl) Create(ctx context.Context, report *entities.Report, userToken string) (*entities.Report, error) {
+	reportData := map[string]interface{}{
+		"question_id": report.QuestionID,
+		"user_id":     report.UserID,
+		"reason":      report.Reason,
+		"comment":     report.Comment,
+		"status":      report.Status,
+	}
+
+	var reportList []map[string]interface{}
+	if err := r.client.From("reports").WithToken(userToken).Insert(ctx, reportData, &reportList); err != nil {
+		return nil, err
+	}
+
+	if len(reportList) == 0 {
+		return nil, fmt.Errorf("no report returned from create operation")
+	}
+
+	return mapToReport(reportList[0]), nil
+}
+
+// GetByID はIDで通報を取得
+func (r *ReportRepositoryImpl) GetByID(ctx context.Context, id int64) (*entities.Report, error) {
+	var reportData map[string]interface{}
+	if err := r.client.From("reports").AsServiceRole().Eq("id", id).Single(ctx, &reportData); err != nil {
+		if postgrest.IsCode(err, "NOT_FOUND") {
+			return nil, shared.NewDomainError("NOT_FOUND", "通報が見つかりません")
+		}
+		return nil, err
+	}
+
+	return mapToReport(reportData), nil
+}
+
+// ListByStatus は状態で絞り込んだ通報を取得
+func (r *ReportRepositoryImpl) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*entities.Report, error) {
+	var reportList []map[string]interface{}
+	err := r.client.From("reports").AsServiceRole().
+		Eq("status", status).
+		Order("created_at", true).
+		Order("id", true).
+		Limit(limit).
+		Offset(offset).
+		Find(ctx, &reportList)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*entities.Report, len(reportList))
+	for i, reportData := range reportList {
+		reports[i] = mapToReport(reportData)
+	}
+
+	return reports, nil
+}
+
+// CountOpenByQuestionID は問題に対する未対応の通報件数を取得
+func (r *ReportRepositoryImpl) CountOpenByQuestionID(ctx context.Context, questionID int64) (int, error) {
+	return r.client.From("reports").AsServiceRole().
+		Eq("question_id", questionID).
+		Eq("status", entities.StatusOpen).
+		Count(ctx)
+}
+
+// ExistsOpenByUserAndQuestion はユーザーが同じ問題に未対応の通報をしているかを判定
+func (r *ReportRepositoryImpl) ExistsOpenByUserAndQuestion(ctx context.Context, userID string, questionID int64) (bool, error) {
+	var reportList []map[string]interface{}
+	err := r.client.From("reports").AsServiceRole().
+		Select("id").
+		Eq("user_id", userID).
+		Eq("question_id", questionID).
+		Eq("status", entities.StatusOpen).
+		Limit(1).
+		Find(ctx, &reportList)
+	if err != nil {
+		return false, err
+	}
+
+	return len(reportList) > 0, nil
+}
+
+// UpdateStatus は通報の状態を更新
+func (r *ReportRepositoryImpl) UpdateStatus(ctx context.Context, report *entities.Report) error {
+	reportData := map[string]interface{}{
+		"status":      report.Status,
+		"resolved_by": report.ResolvedBy,
+	}
+	if report.ResolvedAt != nil {
+		reportData["resolved_at"] = report.ResolvedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return r.client.From("reports").AsServiceRole().Eq("id", report.ID).Update(ctx, reportData, nil)
+}
+
+// mapToReport は map[string]interface{} を Report エンティティに変換
+func mapToReport(m map[string]interface{}) *entities.Report {
+	return &entities.Report{
+		ID:         getInt64(m, "id"),
+		QuestionID: getInt64(m, "question_id"),
+		UserID:     getString(m, "user_id"),
+		Reason:     getString(m, "reason"),
+		Comment:    getString(m, "comment"),
+		Status:     getString(m, "status"),
+		CreatedAt:  getTime(m, "created_at"),
+		ResolvedAt: getOptionalTime(m, "resolved_at"),
+		ResolvedBy: getString(m, "resolved_by"),
+	}
+}
+
+// ヘルパー関数
+
+// getString は map から文字列を安全に取得
+func getString(m map[string]interface{}, key string) string {
+	if val, ok := m[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// getInt64 は map から int64 を安全に取得
+func getInt64(m map[string]interface{}, key string) int64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// getTime は map から time.Time を安全に取得
+func getTime(m map[string]interface{}, key string) time.Time {
+	if val, ok := m[key]; ok {
+		if timeStr, ok := val.(string); ok {
+			if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// getOptionalTime は map から NULL 許容の time.Time を取得
+func getOptionalTime(m map[string]interface{}, key string) *time.Time {
+	if val, ok := m[key]; !ok || val == nil {
+		return nil
+	}
+	t := getTime(m, key)
+	return &t
+}
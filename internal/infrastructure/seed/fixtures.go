@@ -0,0 +1,148 @@
+package seed
+
+// fixtures.goは開発用のサンプルデータ（fixtures/*.json）の読み込みを定義
+// サンプルデータはバイナリに埋め込むため、リポジトリのファイルが無い環境でも投入できる
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+)
+
+//go:embed fixtures/*.json
+var fixtureFiles embed.FS
+
+// UserFixture はサンプルのユーザー
+type UserFixture struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// ChoiceFixture はサンプルの問題の選択肢
+type ChoiceFixture struct {
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// QuestionFixture はサンプルの問題（作成者はメールアドレス、ジャンルは名前で指定する）
+type QuestionFixture struct {
+	Author           string          `json:"author"`
+	Genre            string          `json:"genre"`
+	Title            string          `json:"title"`
+	Body             string          `json:"body"`
+	Explanation      string          `json:"explanation"`
+	BodyFormat       string          `json:"body_format"`
+	Type             string          `json:"type"`
+	PartialCredit    bool            `json:"partial_credit"`
+	AcceptedAnswers  []string        `json:"accepted_answers"`
+	NumericAnswer    *float64        `json:"numeric_answer"`
+	NumericTolerance float64         `json:"numeric_tolerance"`
+	ShuffleChoices   bool            `json:"shuffle_choices"`
+	Tags             []string        `json:"tags"`
+	Choices          []ChoiceFixture `json:"choices"`
+}
+
+// AnswerFixture はサンプルの回答履歴（回答者はメールアドレス、問題はタイトル、選択肢は本文で指定する）
+type AnswerFixture struct {
+	User          string   `json:"user"`
+	Question      string   `json:"question"`
+	Choices       []string `json:"choices"`
+	TextAnswer    string   `json:"text_answer"`
+	NumericAnswer *float64 `json:"numeric_answer"`
+}
+
+// Fixtures は投入するサンプルデータ一式
+type Fixtures struct {
+	Users     []UserFixture
+	Genres    []string
+	Questions []QuestionFixture
+	Answers   []AnswerFixture
+}
+
+// LoadFixtures は埋め込んだサンプルデータを読み込む
+func LoadFixtures() (*Fixtures, error) {
+	return loadFixtures(fixtureFiles)
+}
+
+// loadFixtures は fsys の fixtures ディレクトリからサンプルデータを読み込み、参照先がそろっているかを確認する
+func loadFixtures(fsys fs.FS) (*Fixtures, error) {
+	fixtures := &Fixtures{}
+	files := []struct {
+		name string
+		dest interface{}
+	}{
+		{"fixtures/users.json", &fixtures.Users},
+		{"fixtures/genres.json", &fixtures.Genres},
+		{"fixtures/questions.json", &fixtures.Questions},
+		{"fixtures/answers.json", &fixtures.Answers},
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.name, err)
+		}
+		if err := json.Unmarshal(data, file.dest); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file.name, err)
+		}
+	}
+
+	if err := fixtures.validate(); err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+// validate は問題・回答が参照するユーザー・ジャンル・問題・選択肢が定義されているかを確認する
+func (f *Fixtures) validate() error {
+	users := make(map[string]bool, len(f.Users))
+	for _, user := range f.Users {
+		users[user.Email] = true
+	}
+	genres := make(map[string]bool, len(f.Genres))
+	for _, genre := range f.Genres {
+		genres[genre] = true
+	}
+
+	questions := make(map[string]*QuestionFixture, len(f.Questions))
+	for i := range f.Questions {
+		q := &f.Questions[i]
+		if !users[q.Author] {
+			return fmt.Errorf("question %q: unknown author %s", q.Title, q.Author)
+		}
+		if !genres[q.Genre] {
+			return fmt.Errorf("question %q: unknown genre %s", q.Title, q.Genre)
+		}
+		if questions[q.Title] != nil {
+			return fmt.Errorf("question %q is defined twice", q.Title)
+		}
+		questions[q.Title] = q
+	}
+
+	for _, a := range f.Answers {
+		if !users[a.User] {
+			return fmt.Errorf("answer to %q: unknown user %s", a.Question, a.User)
+		}
+		q := questions[a.Question]
+		if q == nil {
+			return fmt.Errorf("answer by %s: unknown question %q", a.User, a.Question)
+		}
+		for _, text := range a.Choices {
+			if q.choiceIndex(text) < 0 {
+				return fmt.Errorf("answer by %s to %q: unknown choice %q", a.User, a.Question, text)
+			}
+		}
+	}
+	return nil
+}
+
+// choiceIndex は本文が一致する選択肢の位置を返す（無ければ -1）
+func (q *QuestionFixture) choiceIndex(text string) int {
+	for i, choice := range q.Choices {
+		if choice.Text == text {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,256 @@
+package seed
+
+// seeder.goはサンプルデータを設定されたリポジトリに投入する処理を定義
+// 既に投入済みのデータは作成しないため、何度実行しても同じ状態になる
+
+import (
+	"context"
+	"fmt"
+
+	answerDto "Shittaka_back/internal/application/answer/dto"
+	answerUsecases "Shittaka_back/internal/application/answer/usecases"
+	genreUsecases "Shittaka_back/internal/application/genre/usecases"
+	questionDto "Shittaka_back/internal/application/question/dto"
+	questionUsecases "Shittaka_back/internal/application/question/usecases"
+	answerRepositories "Shittaka_back/internal/domain/answer/repositories"
+	authRepositories "Shittaka_back/internal/domain/auth/repositories"
+	choiceEntities "Shittaka_back/internal/domain/choices/entities"
+	choiceRepositories "Shittaka_back/internal/domain/choices/repositories"
+	questionEntities "Shittaka_back/internal/domain/question/entities"
+	questionRepositories "Shittaka_back/internal/domain/question/repositories"
+)
+
+// Result は投入で新しく作成した件数
+type Result struct {
+	Users     int
+	Genres    int
+	Questions int
+	Choices   int
+	Answers   int
+}
+
+// session はサンプルのユーザーとしてログインした状態
+type session struct {
+	userID string
+	token  string
+}
+
+// Seeder はサンプルデータを投入する
+// 通常のAPIと同じユースケースを通して作成し、RLS が有効な場合もユーザー本人のトークンで書き込む
+type Seeder struct {
+	users        authRepositories.UserRepository
+	genres       *genreUsecases.GenreUsecase
+	questions    *questionUsecases.QuestionUsecase
+	answers      *answerUsecases.AnswerUsecase
+	questionRepo questionRepositories.QuestionRepository
+	choiceRepo   choiceRepositories.ChoiceRepository
+	answerRepo   answerRepositories.AnswerRepository
+}
+
+// NewSeeder は新しいSeederを作成
+func NewSeeder(users authRepositories.UserRepository, genres *genreUsecases.GenreUsecase, questions *questionUsecases.QuestionUsecase, answers *answerUsecases.AnswerUsecase, questionRepo questionRepositories.QuestionRepository, choiceRepo choiceRepositories.ChoiceRepository, answerRepo answerRepositories.AnswerRepository) *Seeder {
+	return &Seeder{
+		users:        users,
+		genres:       genres,
+		questions:    questions,
+		answers:      answers,
+		questionRepo: questionRepo,
+		choiceRepo:   choiceRepo,
+		answerRepo:   answerRepo,
+	}
+}
+
+// Run はユーザー・ジャンル・問題（選択肢を含む）・回答履歴の順に投入する
+func (s *Seeder) Run(ctx context.Context, fixtures *Fixtures) (*Result, error) {
+	result := &Result{}
+
+	sessions := make(map[string]*session, len(fixtures.Users))
+	for _, user := range fixtures.Users {
+		sess, created, err := s.signIn(ctx, user)
+		if err != nil {
+			return result, err
+		}
+		sessions[user.Email] = sess
+		if created {
+			result.Users++
+		}
+	}
+
+	// ジャンルは最初のユーザーとして作成する
+	genreIDs := make(map[string]int64, len(fixtures.Genres))
+	if len(fixtures.Genres) > 0 {
+		creator := sessions[fixtures.Users[0].Email]
+		for _, name := range fixtures.Genres {
+			genre, created, err := s.genres.EnsureGenre(ctx, name, creator.token)
+			if err != nil {
+				return result, fmt.Errorf("failed to seed genre %s: %w", name, err)
+			}
+			genreIDs[name] = genre.ID
+			if created {
+				result.Genres++
+			}
+		}
+	}
+
+	questions := make(map[string]*questionEntities.Question, len(fixtures.Questions))
+	for _, fixture := range fixtures.Questions {
+		question, err := s.seedQuestion(ctx, fixture, genreIDs[fixture.Genre], sessions[fixture.Author], result)
+		if err != nil {
+			return result, fmt.Errorf("failed to seed question %q: %w", fixture.Title, err)
+		}
+		questions[fixture.Title] = question
+	}
+
+	for _, fixture := range fixtures.Answers {
+		created, err := s.seedAnswer(ctx, fixture, questions[fixture.Question], sessions[fixture.User])
+		if err != nil {
+			return result, fmt.Errorf("failed to seed answer by %s to %q: %w", fixture.User, fixture.Question, err)
+		}
+		if created {
+			result.Answers++
+		}
+	}
+
+	return result, nil
+}
+
+// signIn はサンプルのユーザーとしてログインする（ログインできない場合はユーザーを作成してからログインする）
+func (s *Seeder) signIn(ctx context.Context, user UserFixture) (*session, bool, error) {
+	if auth, err := s.users.Authenticate(ctx, user.Email, user.Password); err == nil {
+		return &session{userID: auth.User.ID, token: auth.AccessToken}, false, nil
+	}
+
+	if _, err := s.users.Create(ctx, user.Email, user.Password, map[string]interface{}{"username": user.Username}); err != nil {
+		return nil, false, fmt.Errorf("failed to create user %s: %w", user.Email, err)
+	}
+	auth, err := s.users.Authenticate(ctx, user.Email, user.Password)
+	if err != nil {
+		// Supabase Auth でメールアドレスの確認が必要な設定の場合はログインできない
+		return nil, false, fmt.Errorf("failed to sign in as %s (email confirmation may be required): %w", user.Email, err)
+	}
+	return &session{userID: auth.User.ID, token: auth.AccessToken}, true, nil
+}
+
+// seedQuestion は作成者の同じタイトルの問題が無ければ作成し、選択肢を付けて公開する
+// 前回の投入が途中で失敗した問題は、足りない選択肢の作成と公開だけを行う
+func (s *Seeder) seedQuestion(ctx context.Context, fixture QuestionFixture, genreID int64, author *session, result *Result) (*questionEntities.Question, error) {
+	question, err := s.findOwnQuestion(ctx, fixture.Title, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if question == nil {
+		_, err := s.questions.CreateQuestion(ctx, questionDto.CreateQuestionRequest{
+			GenreID:          genreID,
+			Title:            fixture.Title,
+			Body:             fixture.Body,
+			Explanation:      fixture.Explanation,
+			BodyFormat:       fixture.BodyFormat,
+			Type:             fixture.Type,
+			PartialCredit:    fixture.PartialCredit,
+			AcceptedAnswers:  fixture.AcceptedAnswers,
+			NumericAnswer:    fixture.NumericAnswer,
+			NumericTolerance: fixture.NumericTolerance,
+			ShuffleChoices:   fixture.ShuffleChoices,
+			Tags:             fixture.Tags,
+		}, author.userID, author.token)
+		if err != nil {
+			return nil, err
+		}
+		result.Questions++
+
+		// 作成後の状態（ID・種類など）をリポジトリから読み直す
+		if question, err = s.findOwnQuestion(ctx, fixture.Title, author); err != nil {
+			return nil, err
+		}
+		if question == nil {
+			return nil, fmt.Errorf("created question was not found")
+		}
+	}
+
+	if question.UsesChoices() && len(fixture.Choices) > 0 {
+		existing, err := s.choiceRepo.GetByQuestionID(ctx, question.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) == 0 {
+			choices := make([]choiceEntities.Choice, len(fixture.Choices))
+			for i, choice := range fixture.Choices {
+				choices[i] = choiceEntities.Choice{
+					QuestionID: question.ID,
+					Text:       choice.Text,
+					IsCorrect:  choice.IsCorrect,
+					Position:   i + 1,
+				}
+			}
+			if _, err := s.choiceRepo.CreateBatch(ctx, choices, author.token); err != nil {
+				return nil, err
+			}
+			result.Choices += len(choices)
+		}
+	}
+
+	if question.Status == questionEntities.StatusDraft {
+		if _, err := s.questions.PublishQuestion(ctx, question.ID, author.userID, author.token); err != nil {
+			return nil, err
+		}
+		question.Status = questionEntities.StatusPublished
+	}
+	return question, nil
+}
+
+// findOwnQuestion はユーザーが作成した問題からタイトルが一致するものを探す（無ければ nil）
+func (s *Seeder) findOwnQuestion(ctx context.Context, title string, author *session) (*questionEntities.Question, error) {
+	questions, err := s.questionRepo.GetByUserID(ctx, author.userID, author.token)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		if q.Title == title {
+			return q, nil
+		}
+	}
+	return nil, nil
+}
+
+// seedAnswer はユーザーがまだ回答していなければ回答する
+func (s *Seeder) seedAnswer(ctx context.Context, fixture AnswerFixture, question *questionEntities.Question, user *session) (bool, error) {
+	answered, err := s.answerRepo.ExistsByUserAndQuestion(ctx, user.userID, question.ID)
+	if err != nil || answered {
+		return false, err
+	}
+
+	req := answerDto.CreateAnswerRequest{
+		QuestionID:    question.ID,
+		TextAnswer:    fixture.TextAnswer,
+		NumericAnswer: fixture.NumericAnswer,
+	}
+	if len(fixture.Choices) > 0 {
+		choices, err := s.choiceRepo.GetByQuestionID(ctx, question.ID)
+		if err != nil {
+			return false, err
+		}
+		choiceIDs := make([]int64, len(fixture.Choices))
+		for i, text := range fixture.Choices {
+			for _, choice := range choices {
+				if choice.Text == text {
+					choiceIDs[i] = choice.ID
+				}
+			}
+			if choiceIDs[i] == 0 {
+				return false, fmt.Errorf("choice %q was not found", text)
+			}
+		}
+
+		if question.Type == questionEntities.TypeMultipleSelect {
+			req.ChoiceIDs = choiceIDs
+		} else {
+			req.ChoiceID = choiceIDs[0]
+		}
+	}
+
+	if _, err := s.answers.CreateAnswer(ctx, req, user.userID, user.token); err != nil {
+		return false, err
+	}
+	return true, nil
+}
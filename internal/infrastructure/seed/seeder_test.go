@@ -0,0 +1,53 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Shittaka_back/internal/infrastructure/config"
+	"Shittaka_back/internal/infrastructure/di"
+	"Shittaka_back/internal/infrastructure/seed"
+)
+
+func TestSeeder_RunIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	fixtures, err := seed.LoadFixtures()
+	require.NoError(t, err)
+
+	repos := di.NewMemoryRepositories()
+	cfg := &config.Config{DuplicateWarnThreshold: 0.6, DuplicateRejectThreshold: 0.9}
+	seeder := di.NewSeeder(cfg, repos)
+
+	first, err := seeder.Run(ctx, fixtures)
+	require.NoError(t, err)
+	assert.Equal(t, len(fixtures.Users), first.Users)
+	assert.Equal(t, len(fixtures.Genres), first.Genres)
+	assert.Equal(t, len(fixtures.Questions), first.Questions)
+	assert.Equal(t, len(fixtures.Answers), first.Answers)
+
+	// 投入した問題は公開され、回答は採点されている
+	published, err := repos.Questions.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, published, len(fixtures.Questions))
+
+	taro, err := repos.Users.FindByEmail(ctx, "taro@example.com")
+	require.NoError(t, err)
+	answers, err := repos.Answers.GetByUserID(ctx, taro.ID)
+	require.NoError(t, err)
+	correct := 0
+	for _, a := range answers {
+		if a.IsCorrect {
+			correct++
+		}
+	}
+	assert.Len(t, answers, 7)
+	assert.Equal(t, 3, correct)
+
+	// 2回目は何も作成しない
+	second, err := seeder.Run(ctx, fixtures)
+	require.NoError(t, err)
+	assert.Equal(t, &seed.Result{}, second)
+}
@@ -0,0 +1,135 @@
+package memory
+
+// tag_repository_impl.goはメモリ上に保持するTagRepositoryの実装
+// 外部サービスに接続せずにサーバーを起動する場合やテストで使う
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"Shittaka_back/internal/domain/tag/entities"
+)
+
+// TagRepositoryImpl はメモリ上に保持するTagRepositoryの実装
+// 問題の絞り込みに使う索引（QuestionIDsBySlug）も提供する
+type TagRepositoryImpl struct {
+	mu           sync.RWMutex
+	tags         []entities.Tag    // 作成順（ID順）
+	questionTags map[int64][]int64 // 問題ID → タグID
+}
+
+// NewTagRepository は新しいTagRepositoryImplを作成
+// 問題リポジトリの索引としても使うため具体的な型を返す
+func NewTagRepository() *TagRepositoryImpl {
+	return &TagRepositoryImpl{questionTags: make(map[int64][]int64)}
+}
+
+// EnsureTags は存在しないタグを作成し、IDを埋めたタグを指定された順序で返す
+func (r *TagRepositoryImpl) EnsureTags(ctx context.Context, tags []*entities.Tag, userToken string) ([]*entities.Tag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ensured := make([]*entities.Tag, len(tags))
+	for i, tag := range tags {
+		// 既に同じキーのタグがある場合は作成せずにそのまま使う
+		existing := r.findBySlug(tag.Slug)
+		if existing == nil {
+			r.tags = append(r.tags, entities.Tag{ID: int64(len(r.tags) + 1), Name: tag.Name, Slug: tag.Slug})
+			existing = &r.tags[len(r.tags)-1]
+		}
+		ensured[i] = &entities.Tag{ID: existing.ID, Name: existing.Name, Slug: existing.Slug}
+	}
+	return ensured, nil
+}
+
+// SetQuestionTags は問題に付いたタグを置き換える
+func (r *TagRepositoryImpl) SetQuestionTags(ctx context.Context, questionID int64, tagIDs []int64, userToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(tagIDs) == 0 {
+		delete(r.questionTags, questionID)
+		return nil
+	}
+	r.questionTags[questionID] = slices.Clone(tagIDs)
+	return nil
+}
+
+// GetByQuestionIDs は問題ごとに付いたタグをタグID順にまとめて取得
+func (r *TagRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) (map[int64][]*entities.Tag, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags := make(map[int64][]*entities.Tag)
+	for _, questionID := range questionIDs {
+		tagIDs := slices.Clone(r.questionTags[questionID])
+		slices.Sort(tagIDs)
+		for _, id := range tagIDs {
+			t := r.tags[id-1]
+			tags[questionID] = append(tags[questionID], &entities.Tag{ID: t.ID, Name: t.Name, Slug: t.Slug})
+		}
+	}
+	return tags, nil
+}
+
+// SearchBySlugPrefix はキーが前方一致するタグを問題数付きでキー順に取得
+func (r *TagRepositoryImpl) SearchBySlugPrefix(ctx context.Context, prefix string) ([]*entities.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[int64]int)
+	for _, tagIDs := range r.questionTags {
+		for _, id := range tagIDs {
+			counts[id]++
+		}
+	}
+
+	var tags []*entities.Tag
+	for _, t := range r.tags {
+		if strings.HasPrefix(t.Slug, prefix) {
+			tags = append(tags, &entities.Tag{ID: t.ID, Name: t.Name, Slug: t.Slug, QuestionCount: counts[t.ID]})
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Slug < tags[j].Slug })
+	return tags, nil
+}
+
+// QuestionIDsBySlug はキーが一致するタグの付いた問題IDを返す
+func (r *TagRepositoryImpl) QuestionIDsBySlug(slug string) []int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tag := r.findBySlug(slug)
+	if tag == nil {
+		return nil
+	}
+
+	var ids []int64
+	for questionID, tagIDs := range r.questionTags {
+		if slices.Contains(tagIDs, tag.ID) {
+			ids = append(ids, questionID)
+		}
+	}
+	return ids
+}
+
+// findBySlug はキーでタグを探す（呼び出し元でロックを取得する）
+func (r *TagRepositoryImpl) findBySlug(slug string) *entities.Tag {
+	for i := range r.tags {
+		if r.tags[i].Slug == slug {
+			return &r.tags[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package postgres
+
+// tag_repository_impl.goはPostgreSQLを直接使用したTagRepositoryの実装
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"Shittaka_back/internal/domain/tag/entities"
+	"Shittaka_back/internal/domain/tag/repositories"
+	"Shittaka_back/internal/infrastructure/database"
+)
+
+// TagRepositoryImpl はPostgreSQLを使用したTagRepositoryの実装
+type TagRepositoryImpl struct {
+	db database.DB
+}
+
+// NewTagRepository は新しいTagRepositoryImplを作成
+// db には接続プールのほか、ユニットオブワークのトランザクションも渡せる
+func NewTagRepository(db database.DB) repositories.TagRepository {
+	return &TagRepositoryImpl{db: db}
+}
+
+// EnsureTags は存在しないタグを作成し、IDを埋めたタグを返す
+// 作成と読み込みを1つのトランザクションで行う
+func (r *TagRepositoryImpl) EnsureTags(ctx context.Context, tags []*entities.Tag, userToken string) ([]*entities.Tag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(tags))
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+		slugs[i] = tag.Slug
+	}
+
+	bySlug := make(map[string]*entities.Tag, len(tags))
+	err := database.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		// 既に同じキーのタグがある場合は作成せずにそのまま使う
+		_, err := tx.Exec(ctx, `
+			INSERT INTO tags (name, slug)
+			SELECT * FROM unnest($1::text[], $2::text[])
+			ON CONFLICT (slug) DO NOTHING`,
+			names, slugs)
+		if err != nil {
+			return database.MapError(err)
+		}
+
+		rows, err := tx.Query(ctx, `SELECT id, name, slug FROM tags WHERE slug = ANY($1)`, slugs)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			tag := &entities.Tag{}
+			if err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug); err != nil {
+				return err
+			}
+			bySlug[tag.Slug] = tag
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 指定された順序で返す
+	ensured := make([]*entities.Tag, 0, len(tags))
+	for _, tag := range tags {
+		existing, ok := bySlug[tag.Slug]
+		if !ok {
+			return nil, fmt.Errorf("tag %q was not created", tag.Slug)
+		}
+		ensured = append(ensured, existing)
+	}
+
+	return ensured, nil
+}
+
+// SetQuestionTags は問題に付いたタグを1つのトランザクションで置き換える
+func (r *TagRepositoryImpl) SetQuestionTags(ctx context.Context, questionID int64, tagIDs []int64, userToken string) error {
+	return database.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM question_tags WHERE question_id = $1`, questionID); err != nil {
+			return database.MapError(err)
+		}
+
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		_, err := tx.Exec(ctx, `
+			INSERT INTO question_tags (question_id, tag_id)
+			SELECT $1, unnest($2::bigint[])`,
+			questionID, tagIDs)
+		return database.MapError(err)
+	})
+}
+
+// GetByQuestionIDs は問題ごとに付いたタグをまとめて取得
+func (r *TagRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) (map[int64][]*entities.Tag, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT qt.question_id, t.id, t.name, t.slug
+		FROM question_tags qt JOIN tags t ON t.id = qt.tag_id
+		WHERE qt.question_id = ANY($1)
+		ORDER BY qt.tag_id ASC`,
+		questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[int64][]*entities.Tag)
+	for rows.Next() {
+		var questionID int64
+		tag := &entities.Tag{}
+		if err := rows.Scan(&questionID, &tag.ID, &tag.Name, &tag.Slug); err != nil {
+			return nil, err
+		}
+		tags[questionID] = append(tags[questionID], tag)
+	}
+	return tags, rows.Err()
+}
+
+// SearchBySlugPrefix はキーが前方一致するタグを問題数付きで取得
+func (r *TagRepositoryImpl) SearchBySlugPrefix(ctx context.Context, prefix string) ([]*entities.Tag, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT t.id, t.name, t.slug, COUNT(qt.question_id)
+		FROM tags t LEFT JOIN question_tags qt ON qt.tag_id = t.id
+		WHERE t.slug LIKE $1
+		GROUP BY t.id
+		ORDER BY t.slug ASC`,
+		slugPrefixPattern(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*entities.Tag
+	for rows.Next() {
+		tag := &entities.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.QuestionCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// slugPrefixPattern はキーが prefix で始まるタグに一致する LIKE のパターンを返す
+// LIKE の特殊文字はそのまま一致させる
+func slugPrefixPattern(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix) + "%"
+}
@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugPrefixPattern(t *testing.T) {
+	assert.Equal(t, "history%", slugPrefixPattern("history"))
+	assert.Equal(t, "%", slugPrefixPattern(""))
+
+	// LIKE の特殊文字はエスケープしてそのまま一致させる
+	assert.Equal(t, `100\%\_off\\%`, slugPrefixPattern(`100%_off\`))
+}
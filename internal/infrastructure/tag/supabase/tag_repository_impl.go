@@ -0,0 +1,191 @@
+package supabase
+
+// tag_repository_impl.goはSupabaseを使用したTagRepositoryの実装
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Shittaka_back/internal/domain/tag/entities"
+	"Shittaka_back/internal/domain/tag/repositories"
+	"Shittaka_back/internal/infrastructure/postgrest"
+)
+
+// TagRepositoryImpl はSupabaseを使用したTagRepositoryの実装
+type TagRepositoryImpl struct {
+	client *postgrest.Client
+}
+
+// NewTagRepository は新しいTagRepositoryImplを作成
+func NewTagRepository(client *postgrest.Client) repositories.TagRepository {
+	return &TagRepositoryImpl{client: client}
+}
+
+// EnsureTags は存在しないタグを作成し、IDを埋めたタグを返す（RLS適用のためユーザートークンを使用）
+func (r *TagRepositoryImpl) EnsureTags(ctx context.Context, tags []*entities.Tag, userToken string) ([]*entities.Tag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	tagDataList := make([]map[string]interface{}, len(tags))
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		tagDataList[i] = map[string]interface{}{
+			"name": tag.Name,
+			"slug": tag.Slug,
+		}
+		slugs[i] = tag.Slug
+	}
+
+	// 既に同じキーのタグがある場合は作成せずにそのまま使う
+	if err := r.client.From("tags").WithToken(userToken).IgnoreDuplicates("slug").Insert(ctx, tagDataList, nil); err != nil {
+		return nil, err
+	}
+
+	found, err := r.findTags(ctx, r.client.From("tags").In("slug", postgrest.Strings(slugs)...))
+	if err != nil {
+		return nil, err
+	}
+
+	// 指定された順序で返す
+	bySlug := make(map[string]*entities.Tag, len(found))
+	for _, tag := range found {
+		bySlug[tag.Slug] = tag
+	}
+	ensured := make([]*entities.Tag, 0, len(tags))
+	for _, tag := range tags {
+		existing, ok := bySlug[tag.Slug]
+		if !ok {
+			return nil, fmt.Errorf("tag %q was not created", tag.Slug)
+		}
+		ensured = append(ensured, existing)
+	}
+
+	return ensured, nil
+}
+
+// SetQuestionTags は問題に付いたタグを置き換える（RLS適用のためユーザートークンを使用）
+func (r *TagRepositoryImpl) SetQuestionTags(ctx context.Context, questionID int64, tagIDs []int64, userToken string) error {
+	if err := r.client.From("question_tags").WithToken(userToken).Eq("question_id", questionID).Delete(ctx); err != nil {
+		return err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, len(tagIDs))
+	for i, tagID := range tagIDs {
+		rows[i] = map[string]interface{}{
+			"question_id": questionID,
+			"tag_id":      tagID,
+		}
+	}
+
+	return r.client.From("question_tags").WithToken(userToken).Insert(ctx, rows, nil)
+}
+
+// GetByQuestionIDs は問題ごとに付いたタグをまとめて取得
+func (r *TagRepositoryImpl) GetByQuestionIDs(ctx context.Context, questionIDs []int64) (map[int64][]*entities.Tag, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []map[string]interface{}
+	err := r.client.From("question_tags").
+		Select("question_id,tags(id,name,slug)").
+		In("question_id", postgrest.Int64s(questionIDs)...).
+		Order("tag_id", true).
+		Find(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[int64][]*entities.Tag)
+	for _, row := range rows {
+		tagData, ok := row["tags"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		questionID := getInt64(row, "question_id")
+		tags[questionID] = append(tags[questionID], mapToTag(tagData))
+	}
+
+	return tags, nil
+}
+
+// SearchBySlugPrefix はキーが前方一致するタグを問題数付きで取得
+func (r *TagRepositoryImpl) SearchBySlugPrefix(ctx context.Context, prefix string) ([]*entities.Tag, error) {
+	query := r.client.From("tags").
+		Select("id,name,slug,question_tags(count)").
+		Order("slug", true)
+	if prefix != "" {
+		// LIKE の特殊文字はそのまま一致させる
+		escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "*", `\*`).Replace(prefix)
+		query = query.Like("slug", escaped+"*")
+	}
+
+	return r.findTags(ctx, query)
+}
+
+// findTags はクエリに合うタグを取得してTagエンティティのスライスに変換
+func (r *TagRepositoryImpl) findTags(ctx context.Context, query *postgrest.Query) ([]*entities.Tag, error) {
+	var tagList []map[string]interface{}
+	if err := query.Find(ctx, &tagList); err != nil {
+		return nil, err
+	}
+
+	tags := make([]*entities.Tag, len(tagList))
+	for i, tagData := range tagList {
+		tags[i] = mapToTag(tagData)
+	}
+
+	return tags, nil
+}
+
+// mapToTag は map[string]interface{} を Tag エンティティに変換
+// question_tags(count) を埋め込んだ場合は問題数も読み取る
+func mapToTag(m map[string]interface{}) *entities.Tag {
+	tag := &entities.Tag{
+		ID:   getInt64(m, "id"),
+		Name: getString(m, "name"),
+		Slug: getString(m, "slug"),
+	}
+	if counts, ok := m["question_tags"].([]interface{}); ok && len(counts) > 0 {
+		if count, ok := counts[0].(map[string]interface{}); ok {
+			tag.QuestionCount = int(getInt64(count, "count"))
+		}
+	}
+	return tag
+}
+
+// getString は map から文字列を安全に取得
+func getString(m map[string]interface{}, key string) string {
+	if val, ok := m[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// getInt64 は map から int64 を安全に取得
+func getInt64(m map[string]interface{}, key string) int64 {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,18 @@
+package dto
+
+// attachment_dto.goは添付画像関連のHTTP DTOを定義
+
+import "time"
+
+// AttachmentResponse は添付画像レスポンスのHTTP DTO
+type AttachmentResponse struct {
+	ID          int64     `json:"id"`
+	QuestionID  int64     `json:"question_id"`
+	ChoiceID    *int64    `json:"choice_id"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// bookmark_dto.goはブックマーク関連のHTTP DTOを定義
+
+import "time"
+
+// BookmarkResponse はブックマーク一覧の1件のHTTP DTO
+type BookmarkResponse struct {
+	QuestionID   int64     `json:"question_id"`
+	Title        string    `json:"title"`
+	BookmarkedAt time.Time `json:"bookmarked_at"`
+}
+
+// BookmarkListResponse はブックマーク一覧レスポンスのHTTP DTO
+type BookmarkListResponse struct {
+	Bookmarks []BookmarkResponse `json:"bookmarks"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+	HasMore   bool               `json:"has_more"`
+}
@@ -7,6 +7,7 @@ type CreateChoiceRequest struct {
 	QuestionID int64  `json:"question_id"`
 	Text       string `json:"text"`
 	IsCorrect  bool   `json:"is_correct"`
+	Position   int    `json:"position"`
 }
 
 // UpdateChoiceRequest は選択肢更新リクエストのHTTP DTO
@@ -15,14 +16,23 @@ type UpdateChoiceRequest struct {
 	QuestionID int64  `json:"question_id"`
 	Text       string `json:"text"`
 	IsCorrect  bool   `json:"is_correct"`
+	Position   int    `json:"position"`
 }
 
 // ChoiceResponse は選択肢レスポンスのHTTP DTO
 type ChoiceResponse struct {
-	ID         int64  `json:"id"`
-	QuestionID int64  `json:"question_id"`
-	Text       string `json:"text"`
-	IsCorrect  bool   `json:"is_correct"`
+	ID          int64                `json:"id"`
+	QuestionID  int64                `json:"question_id"`
+	Text        string               `json:"text"`
+	IsCorrect   bool                 `json:"is_correct"`
+	Position    int                  `json:"position"`
+	Attachments []AttachmentResponse `json:"attachments,omitempty"` // 選択肢に添付された画像（一覧取得時のみ）
+}
+
+// ReorderChoicesRequest は選択肢並べ替えリクエストのHTTP DTO
+type ReorderChoicesRequest struct {
+	QuestionID int64   `json:"question_id"`
+	ChoiceIDs  []int64 `json:"choice_ids"` // 並べたい順の選択肢ID（問題の全選択肢）
 }
 
 // ChoicesResponse は複数選択肢のレスポンスのHTTP DTO
@@ -0,0 +1,16 @@
+package dto
+
+// daily_dto.goは「今日の一問」関連のHTTP DTOを定義
+
+// SetDailyQuestionRequest は「今日の一問」指定リクエストのHTTP DTO
+type SetDailyQuestionRequest struct {
+	Date       string `json:"date"`
+	QuestionID int64  `json:"question_id"`
+}
+
+// DailyQuestionResponse は「今日の一問」レスポンスのHTTP DTO
+type DailyQuestionResponse struct {
+	Date       string           `json:"date"`
+	IsOverride bool             `json:"is_override"`
+	Question   QuestionResponse `json:"question"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// follow_dto.goはフォロー関連のHTTP DTOを定義
+
+import "time"
+
+// FollowUserResponse はフォロワー・フォロー中の一覧の1ユーザーのHTTP DTO
+type FollowUserResponse struct {
+	UserID     string    `json:"user_id"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// FollowListResponse はフォロワー・フォロー中の一覧レスポンスのHTTP DTO
+type FollowListResponse struct {
+	Users   []FollowUserResponse `json:"users"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+	HasMore bool                 `json:"has_more"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// report_dto.goは通報関連のHTTP DTOを定義
+
+import "time"
+
+// CreateReportRequest は通報作成リクエストのHTTP DTO
+type CreateReportRequest struct {
+	Reason  string `json:"reason"`
+	Comment string `json:"comment"`
+}
+
+// ResolveReportRequest は通報対応リクエストのHTTP DTO
+type ResolveReportRequest struct {
+	Status string `json:"status"`
+}
+
+// ReportResponse は通報レスポンスのHTTP DTO
+type ReportResponse struct {
+	ID         int64      `json:"id"`
+	QuestionID int64      `json:"question_id"`
+	UserID     string     `json:"user_id"`
+	Reason     string     `json:"reason"`
+	Comment    string     `json:"comment"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	ResolvedBy string     `json:"resolved_by"`
+}
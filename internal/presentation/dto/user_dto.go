@@ -0,0 +1,23 @@
+package dto
+
+// user_dto.goはユーザーの公開プロフィールのHTTP DTOを定義
+
+import "time"
+
+// UserProfileResponse はユーザーの公開プロフィールのHTTP DTO
+type UserProfileResponse struct {
+	ID        string            `json:"id"`
+	Username  string            `json:"username"`
+	AvatarURL string            `json:"avatar_url"`
+	JoinedAt  time.Time         `json:"joined_at"`
+	Stats     UserStatsResponse `json:"stats"`
+}
+
+// UserStatsResponse はユーザーの問題と回答の集計のHTTP DTO
+type UserStatsResponse struct {
+	QuestionCount       int      `json:"question_count"`
+	ReceivedAnswerCount int      `json:"received_answer_count"`
+	ReceivedCorrectRate *float64 `json:"received_correct_rate"`
+	AnswerCount         int      `json:"answer_count"`
+	Accuracy            *float64 `json:"accuracy"`
+}
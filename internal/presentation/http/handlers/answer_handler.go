@@ -1,12 +1,9 @@
 package handlers
 
 import (
-	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
-	"strings"
 
 	answerDto "Shittaka_back/internal/application/answer/dto"
 	"Shittaka_back/internal/application/answer/usecases"
@@ -17,12 +14,14 @@ import (
 // AnswerHandler は回答関連のHTTPハンドラー
 type AnswerHandler struct {
 	answerUsecase *usecases.AnswerUsecase
+	auth          *Authenticator
 }
 
 // NewAnswerHandler は新しいAnswerHandlerを作成
-func NewAnswerHandler(answerUsecase *usecases.AnswerUsecase) *AnswerHandler {
+func NewAnswerHandler(answerUsecase *usecases.AnswerUsecase, auth *Authenticator) *AnswerHandler {
 	return &AnswerHandler{
 		answerUsecase: answerUsecase,
+		auth:          auth,
 	}
 }
 
@@ -41,7 +40,7 @@ func (h *AnswerHandler) CreateAnswerHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	// ユーザーIDを取得
-	userID, err := h.getUserIDFromToken(userToken)
+	userID, err := h.auth.UserID(userToken)
 	if err != nil {
 		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
 		return
@@ -55,8 +54,11 @@ func (h *AnswerHandler) CreateAnswerHandler(w http.ResponseWriter, r *http.Reque
 
 	// DTOの変換
 	usecaseReq := answerDto.CreateAnswerRequest{
-		QuestionID: req.QuestionID,
-		ChoiceID:   req.ChoiceID,
+		QuestionID:    req.QuestionID,
+		ChoiceID:      req.ChoiceID,
+		ChoiceIDs:     req.ChoiceIDs,
+		TextAnswer:    req.TextAnswer,
+		NumericAnswer: req.NumericAnswer,
 	}
 
 	answerResp, err := h.answerUsecase.CreateAnswer(r.Context(), usecaseReq, userID, userToken)
@@ -67,11 +69,17 @@ func (h *AnswerHandler) CreateAnswerHandler(w http.ResponseWriter, r *http.Reque
 
 	// レスポンスDTOに変換
 	response := presentationDTO.AnswerResponse{
-		ID:         answerResp.ID,
-		UserID:     answerResp.UserID,
-		QuestionID: answerResp.QuestionID,
-		ChoiceID:   answerResp.ChoiceID,
-		AnsweredAt: answerResp.AnsweredAt,
+		ID:               answerResp.ID,
+		UserID:           answerResp.UserID,
+		QuestionID:       answerResp.QuestionID,
+		ChoiceID:         answerResp.ChoiceID,
+		ChoiceIDs:        answerResp.ChoiceIDs,
+		TextAnswer:       answerResp.TextAnswer,
+		NumericAnswer:    answerResp.NumericAnswer,
+		IsCorrect:        answerResp.IsCorrect,
+		Score:            answerResp.Score,
+		AnsweredAt:       answerResp.AnsweredAt,
+		QuestionRevision: answerResp.QuestionRevision,
 	}
 
 	h.sendJSON(w, response, http.StatusCreated)
@@ -95,34 +103,6 @@ func (h *AnswerHandler) extractToken(r *http.Request) (string, error) {
 	return userToken, nil
 }
 
-// getUserIDFromToken はJWTトークンからユーザーIDを取得
-func (h *AnswerHandler) getUserIDFromToken(token string) (string, error) {
-	// JWTトークンを分割 (header.payload.signature)
-	parts := strings.Split(token, ".")
-	if len(parts) != 3 {
-		return "", shared.NewDomainError("INVALID_TOKEN", "Invalid JWT format")
-	}
-
-	// payloadをデコード
-	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
-	if err != nil {
-		return "", fmt.Errorf("failed to decode JWT payload: %w", err)
-	}
-
-	// JSONとしてパース
-	var claims map[string]interface{}
-	if err := json.Unmarshal(payload, &claims); err != nil {
-		return "", fmt.Errorf("failed to parse JWT claims: %w", err)
-	}
-
-	// subクレームからユーザーIDを取得
-	if sub, ok := claims["sub"].(string); ok && sub != "" {
-		return sub, nil
-	}
-
-	return "", shared.NewDomainError("INVALID_TOKEN", "User ID not found in token")
-}
-
 // handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
 func (h *AnswerHandler) handleUsecaseError(w http.ResponseWriter, err error) {
 	switch e := err.(type) {
@@ -136,6 +116,8 @@ func (h *AnswerHandler) handleUsecaseError(w http.ResponseWriter, err error) {
 			h.sendError(w, e.Message, http.StatusForbidden)
 		case "UNAUTHORIZED":
 			h.sendError(w, e.Message, http.StatusUnauthorized)
+		case "CONFLICT":
+			h.sendError(w, e.Message, http.StatusConflict)
 		default:
 			h.sendError(w, e.Message, http.StatusInternalServerError)
 		}
@@ -161,4 +143,4 @@ func (h *AnswerHandler) sendError(w http.ResponseWriter, message string, statusC
 		Message: message,
 	}
 	h.sendJSON(w, response, statusCode)
-}
\ No newline at end of file
+}
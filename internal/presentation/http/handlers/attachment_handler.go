@@ -0,0 +1,253 @@
+package handlers
+
+// attachment_handler.goは問題・選択肢への画像添付に関するHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	attachmentDto "Shittaka_back/internal/application/attachment/dto"
+	"Shittaka_back/internal/application/attachment/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// multipartOverhead はmultipartの境界やフォーム項目のためにファイルサイズの上限へ上乗せするバイト数
+const multipartOverhead = 1 << 20
+
+// AttachmentHandler は添付画像関連のHTTPハンドラー
+type AttachmentHandler struct {
+	attachmentUsecase *usecases.AttachmentUsecase
+	auth              *Authenticator
+}
+
+// NewAttachmentHandler は新しいAttachmentHandlerを作成
+func NewAttachmentHandler(attachmentUsecase *usecases.AttachmentUsecase, auth *Authenticator) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUsecase: attachmentUsecase,
+		auth:              auth,
+	}
+}
+
+// UploadAttachmentHandler は画像のアップロードを処理 (POST /api/questions/{id}/attachments)
+// multipart/form-data の file に画像、choice_id（任意）に添付先の選択肢IDを指定する
+func (h *AttachmentHandler) UploadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから問題IDを取得
+	questionID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	// 上限を超えるリクエストボディは読み込む前に打ち切る
+	maxBytes := h.attachmentUsecase.MaxUploadBytes()
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+multipartOverhead)
+	if err := r.ParseMultipartForm(maxBytes + multipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.sendError(w, fmt.Sprintf("ファイルサイズは%dバイト以下にしてください", maxBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.sendError(w, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		h.sendError(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		h.sendError(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+
+	// DTOの変換
+	usecaseReq := attachmentDto.UploadAttachmentRequest{
+		QuestionID: questionID,
+		Data:       data,
+	}
+	if v := r.FormValue("choice_id"); v != "" {
+		choiceID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			h.sendError(w, "Invalid choice ID", http.StatusBadRequest)
+			return
+		}
+		usecaseReq.ChoiceID = &choiceID
+	}
+
+	attachmentResp, err := h.attachmentUsecase.UploadAttachment(r.Context(), usecaseReq, userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, newAttachmentResponse(attachmentResp), http.StatusCreated)
+}
+
+// DeleteAttachmentHandler は添付画像の削除を処理 (DELETE /api/attachments/{id})
+func (h *AttachmentHandler) DeleteAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから添付画像IDを取得
+	attachmentID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.attachmentUsecase.DeleteAttachment(r.Context(), attachmentID, userID, userToken); err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ヘルパー関数
+
+// newAttachmentResponse はユースケースのDTOを添付画像レスポンスのHTTP DTOに変換
+func newAttachmentResponse(attachment *attachmentDto.AttachmentResponse) presentationDTO.AttachmentResponse {
+	return presentationDTO.AttachmentResponse{
+		ID:          attachment.ID,
+		QuestionID:  attachment.QuestionID,
+		ChoiceID:    attachment.ChoiceID,
+		URL:         attachment.URL,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Width:       attachment.Width,
+		Height:      attachment.Height,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// newAttachmentResponses は添付画像の一覧をHTTP DTOに変換（添付がない場合は空配列）
+// 問題・選択肢のレスポンスを返すハンドラーから利用する
+func newAttachmentResponses(attachments []*attachmentDto.AttachmentResponse) []presentationDTO.AttachmentResponse {
+	responses := make([]presentationDTO.AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = newAttachmentResponse(attachment)
+	}
+	return responses
+}
+
+// extractToken はリクエストからトークンを抽出
+func (h *AttachmentHandler) extractToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+
+	// "Bearer " プレフィックスを除去
+	userToken := authHeader
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		userToken = authHeader[7:]
+	}
+
+	return userToken, nil
+}
+
+// getIDFromPath はURLパスの指定位置からIDを取得
+func (h *AttachmentHandler) getIDFromPath(path string, index int) (int64, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) <= index {
+		return 0, shared.NewDomainError("INVALID_PATH", "Invalid ID path")
+	}
+
+	id, err := strconv.ParseInt(parts[index], 10, 64)
+	if err != nil {
+		return 0, shared.NewDomainError("INVALID_ID", "Invalid ID format")
+	}
+
+	return id, nil
+}
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *AttachmentHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		switch e.Code {
+		case "NOT_FOUND":
+			h.sendError(w, e.Message, http.StatusNotFound)
+		case "FORBIDDEN":
+			h.sendError(w, e.Message, http.StatusForbidden)
+		case "UNAUTHORIZED":
+			h.sendError(w, e.Message, http.StatusUnauthorized)
+		case "FILE_TOO_LARGE":
+			h.sendError(w, e.Message, http.StatusRequestEntityTooLarge)
+		case "UNSUPPORTED_MEDIA_TYPE":
+			h.sendError(w, e.Message, http.StatusUnsupportedMediaType)
+		default:
+			h.sendError(w, e.Message, http.StatusInternalServerError)
+		}
+	default:
+		log.Printf("Attachment usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *AttachmentHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *AttachmentHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
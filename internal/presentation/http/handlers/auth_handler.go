@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
-	"time"
 
 	"Shittaka_back/internal/application/auth/dto"
 	"Shittaka_back/internal/application/auth/usecases"
@@ -131,22 +130,6 @@ func (h *AuthHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"message": "Logged out successfully"})
 }
 
-// TestConnectionHandler はSupabaseとの接続テストを行う
-func (h *AuthHandler) TestConnectionHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	response := map[string]interface{}{
-		"status":    "connected",
-		"message":   "Supabase connection is configured",
-		"timestamp": time.Now().Unix(),
-	}
-
-	h.sendJSON(w, response, http.StatusOK)
-}
-
 // ヘルパー関数
 
 // handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
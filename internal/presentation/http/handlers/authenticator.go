@@ -0,0 +1,107 @@
+package handlers
+
+// authenticator.goはアクセストークンの検証と接続元の特定による利用者の識別を定義
+// 全てのハンドラーで同じ Authenticator を共有し、署名を検証していないトークンのユーザーIDは使わない
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	authRepositories "Shittaka_back/internal/domain/auth/repositories"
+)
+
+// Authenticator はアクセストークンを検証し、リクエストの利用者を識別する
+type Authenticator struct {
+	verifier       authRepositories.TokenVerifier
+	trustedProxies []netip.Prefix
+	now            func() time.Time
+}
+
+// NewAuthenticator は新しいAuthenticatorを作成
+// verifier は memory では発行元のTokenIssuer、それ以外ではSupabase AuthのJWTシークレットで検証する
+// trustedProxies からの接続の場合のみ X-Forwarded-For のクライアントIPを使う
+func NewAuthenticator(verifier authRepositories.TokenVerifier, trustedProxies []netip.Prefix) *Authenticator {
+	return &Authenticator{
+		verifier:       verifier,
+		trustedProxies: trustedProxies,
+		now:            time.Now,
+	}
+}
+
+// UserID はトークンの署名と有効期限を検証し、ユーザーIDを返す
+func (a *Authenticator) UserID(token string) (string, error) {
+	return a.verifier.Verify(token, a.now())
+}
+
+// OptionalUserID は有効なトークンが付いていればユーザーIDを返し、未ログインや無効なトークンの場合は空文字を返す
+func (a *Authenticator) OptionalUserID(r *http.Request) string {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return ""
+	}
+	userID, err := a.UserID(strings.TrimPrefix(authHeader, "Bearer "))
+	if err != nil {
+		return ""
+	}
+	return userID
+}
+
+// ViewerKey は閲覧数の重複判定や選択肢のシャッフルに使う閲覧者のキーを返す
+// ログイン中はユーザーID、未ログインの場合はクライアントIPを使う
+func (a *Authenticator) ViewerKey(r *http.Request) string {
+	if userID := a.OptionalUserID(r); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + a.clientIP(r)
+}
+
+// clientIP はリクエストの送信元のIPアドレスを返す
+// 信頼するプロキシからの接続の場合は X-Forwarded-For を右から辿り、信頼するプロキシ以外の最初のアドレスを使う
+// それ以外の接続の X-Forwarded-For は送信元が自由に書き換えられるため使わない
+func (a *Authenticator) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !a.isTrustedProxy(host) {
+		return host
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if _, err := netip.ParseAddr(hop); err != nil {
+			// 不正な値より左は信頼できないため、ここまでで確認できた最後のアドレスを使う
+			break
+		}
+		host = hop
+		if !a.isTrustedProxy(hop) {
+			break
+		}
+	}
+	return host
+}
+
+// isTrustedProxy はアドレスが信頼するプロキシの範囲に含まれるかどうかを返す
+func (a *Authenticator) isTrustedProxy(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range a.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Shittaka_back/internal/domain/shared"
+)
+
+// fakeTokenVerifier は "valid" のトークンだけを user-1 のものとして受け付けるテスト用の検証
+type fakeTokenVerifier struct{}
+
+func (fakeTokenVerifier) Verify(token string, now time.Time) (string, error) {
+	if token != "valid" {
+		return "", shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+	return "user-1", nil
+}
+
+func TestAuthenticator_ViewerKey(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		authorization string
+		forwardedFor  string
+		want          string
+	}{
+		{name: "verified user", remoteAddr: "203.0.113.7:4000", authorization: "Bearer valid", want: "user:user-1"},
+		{name: "unverified token falls back to ip", remoteAddr: "203.0.113.7:4000", authorization: "Bearer forged", want: "ip:203.0.113.7"},
+		{name: "untrusted client cannot spoof forwarded for", remoteAddr: "203.0.113.7:4000", forwardedFor: "198.51.100.1", want: "ip:203.0.113.7"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.2:4000", forwardedFor: "198.51.100.1", want: "ip:198.51.100.1"},
+		{name: "spoofed hop before trusted proxy", remoteAddr: "10.0.0.2:4000", forwardedFor: "192.0.2.9, 198.51.100.1, 10.0.0.3", want: "ip:198.51.100.1"},
+		{name: "invalid hop", remoteAddr: "10.0.0.2:4000", forwardedFor: "unknown, 10.0.0.3", want: "ip:10.0.0.3"},
+	}
+
+	auth := NewAuthenticator(fakeTokenVerifier{}, trusted)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/questions/1", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			assert.Equal(t, tt.want, auth.ViewerKey(r))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package handlers
+
+// bookmark_handler.goは問題のブックマークに関するHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	bookmarkDto "Shittaka_back/internal/application/bookmark/dto"
+	"Shittaka_back/internal/application/bookmark/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// BookmarkHandler はブックマーク関連のHTTPハンドラー
+type BookmarkHandler struct {
+	bookmarkUsecase *usecases.BookmarkUsecase
+	auth            *Authenticator
+}
+
+// NewBookmarkHandler は新しいBookmarkHandlerを作成
+func NewBookmarkHandler(bookmarkUsecase *usecases.BookmarkUsecase, auth *Authenticator) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkUsecase: bookmarkUsecase,
+		auth:            auth,
+	}
+}
+
+// BookmarkQuestionHandler は問題のブックマーク・ブックマーク解除を処理 (POST/DELETE /api/questions/{id}/bookmark)
+func (h *BookmarkHandler) BookmarkQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userToken, userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	// "/api/questions/{id}/bookmark" の形式から問題IDを取得
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 5 || parts[4] != "bookmark" {
+		h.sendError(w, "Not found", http.StatusNotFound)
+		return
+	}
+	questionID, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	message := "ブックマークしました"
+	if r.Method == http.MethodPost {
+		err = h.bookmarkUsecase.AddBookmark(r.Context(), userID, questionID, userToken)
+	} else {
+		err = h.bookmarkUsecase.RemoveBookmark(r.Context(), userID, questionID, userToken)
+		message = "ブックマークを解除しました"
+	}
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, map[string]string{"message": message}, http.StatusOK)
+}
+
+// ListBookmarksHandler は自分のブックマーク一覧取得を処理 (GET /api/bookmarks)
+func (h *BookmarkHandler) ListBookmarksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userToken, userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	// ページング
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	listResp, err := h.bookmarkUsecase.ListBookmarks(r.Context(), userID, bookmarkDto.ListBookmarksRequest{
+		Limit:  limit,
+		Offset: offset,
+	}, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	// レスポンスDTOに変換
+	response := presentationDTO.BookmarkListResponse{
+		Bookmarks: make([]presentationDTO.BookmarkResponse, len(listResp.Bookmarks)),
+		Limit:     listResp.Limit,
+		Offset:    listResp.Offset,
+		HasMore:   listResp.HasMore,
+	}
+	for i, bookmark := range listResp.Bookmarks {
+		response.Bookmarks[i] = presentationDTO.BookmarkResponse{
+			QuestionID:   bookmark.QuestionID,
+			Title:        bookmark.Title,
+			BookmarkedAt: bookmark.BookmarkedAt,
+		}
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
+
+// ヘルパー関数
+
+// authenticate はリクエストのトークンとユーザーIDを取得し、認証できなければエラーレスポンスを返す
+func (h *BookmarkHandler) authenticate(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return "", "", false
+	}
+
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return "", "", false
+	}
+
+	return userToken, userID, true
+}
+
+// extractToken はリクエストからトークンを抽出
+func (h *BookmarkHandler) extractToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+
+	// "Bearer " プレフィックスを除去
+	userToken := authHeader
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		userToken = authHeader[7:]
+	}
+
+	return userToken, nil
+}
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *BookmarkHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		switch e.Code {
+		case "NOT_FOUND":
+			h.sendError(w, e.Message, http.StatusNotFound)
+		case "UNAUTHORIZED":
+			h.sendError(w, e.Message, http.StatusUnauthorized)
+		default:
+			h.sendError(w, e.Message, http.StatusInternalServerError)
+		}
+	default:
+		log.Printf("Bookmark usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *BookmarkHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *BookmarkHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
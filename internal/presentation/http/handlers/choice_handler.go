@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	attachmentUsecases "Shittaka_back/internal/application/attachment/usecases"
 	"Shittaka_back/internal/domain/choices/entities"
 	"Shittaka_back/internal/domain/choices/services"
 	"Shittaka_back/internal/domain/shared"
@@ -17,13 +18,17 @@ import (
 
 // ChoiceHandler は選択肢関連のHTTPハンドラー
 type ChoiceHandler struct {
-	choiceService *services.ChoiceService
+	choiceService     *services.ChoiceService
+	attachmentUsecase *attachmentUsecases.AttachmentUsecase
+	auth              *Authenticator
 }
 
 // NewChoiceHandler は新しいChoiceHandlerを作成
-func NewChoiceHandler(choiceService *services.ChoiceService) *ChoiceHandler {
+func NewChoiceHandler(choiceService *services.ChoiceService, attachmentUsecase *attachmentUsecases.AttachmentUsecase, auth *Authenticator) *ChoiceHandler {
 	return &ChoiceHandler{
-		choiceService: choiceService,
+		choiceService:     choiceService,
+		attachmentUsecase: attachmentUsecase,
+		auth:              auth,
 	}
 }
 
@@ -48,7 +53,14 @@ func (h *ChoiceHandler) GetChoicesHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	choices, err := h.choiceService.GetChoices(r.Context(), questionID)
+	choices, err := h.choiceService.GetChoicesForViewer(r.Context(), questionID, h.auth.OptionalUserID(r), h.auth.ViewerKey(r))
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	// 選択肢に添付された画像
+	attachments, err := h.attachmentUsecase.GetChoiceAttachments(r.Context(), questionID)
 	if err != nil {
 		h.handleServiceError(w, err)
 		return
@@ -58,10 +70,12 @@ func (h *ChoiceHandler) GetChoicesHandler(w http.ResponseWriter, r *http.Request
 	var choiceResponses []presentationDTO.ChoiceResponse
 	for _, choice := range choices {
 		choiceResponses = append(choiceResponses, presentationDTO.ChoiceResponse{
-			ID:         choice.ID,
-			QuestionID: choice.QuestionID,
-			Text:       choice.Text,
-			IsCorrect:  choice.IsCorrect,
+			ID:          choice.ID,
+			QuestionID:  choice.QuestionID,
+			Text:        choice.Text,
+			IsCorrect:   choice.IsCorrect,
+			Position:    choice.Position,
+			Attachments: newAttachmentResponses(attachments[choice.ID]),
 		})
 	}
 
@@ -72,7 +86,7 @@ func (h *ChoiceHandler) GetChoicesHandler(w http.ResponseWriter, r *http.Request
 	h.sendJSON(w, response, http.StatusOK)
 }
 
-// CreateChoiceHandler は新しい選択肢を作成
+// CreateChoiceHandler は新しい選択肢を作成（問題の作成者のみ）
 func (h *ChoiceHandler) CreateChoiceHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -85,12 +99,11 @@ func (h *ChoiceHandler) CreateChoiceHandler(w http.ResponseWriter, r *http.Reque
 		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
 		return
 	}
-	
-	// デバッグ用ログ
-	if len(userToken) > 20 {
-		log.Printf("Choice Handler - UserToken: %s...", userToken[:20])
-	} else {
-		log.Printf("Choice Handler - UserToken: %s", userToken)
+
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
 	}
 
 	var req presentationDTO.CreateChoiceRequest
@@ -104,9 +117,10 @@ func (h *ChoiceHandler) CreateChoiceHandler(w http.ResponseWriter, r *http.Reque
 		QuestionID: req.QuestionID,
 		Text:       req.Text,
 		IsCorrect:  req.IsCorrect,
+		Position:   req.Position,
 	}
 
-	createdChoice, err := h.choiceService.CreateChoiceWithAuth(r.Context(), choice, userToken)
+	createdChoice, err := h.choiceService.CreateChoiceWithAuth(r.Context(), choice, userID, userToken)
 	if err != nil {
 		h.handleServiceError(w, err)
 		return
@@ -118,18 +132,32 @@ func (h *ChoiceHandler) CreateChoiceHandler(w http.ResponseWriter, r *http.Reque
 		QuestionID: createdChoice.QuestionID,
 		Text:       createdChoice.Text,
 		IsCorrect:  createdChoice.IsCorrect,
+		Position:   createdChoice.Position,
 	}
 
 	h.sendJSON(w, response, http.StatusCreated)
 }
 
-// UpdateChoiceHandler は既存の選択肢を更新
+// UpdateChoiceHandler は既存の選択肢を更新（問題の作成者のみ）
 func (h *ChoiceHandler) UpdateChoiceHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
 		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
 	var req presentationDTO.UpdateChoiceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
@@ -142,9 +170,10 @@ func (h *ChoiceHandler) UpdateChoiceHandler(w http.ResponseWriter, r *http.Reque
 		QuestionID: req.QuestionID,
 		Text:       req.Text,
 		IsCorrect:  req.IsCorrect,
+		Position:   req.Position,
 	}
 
-	updatedChoice, err := h.choiceService.UpdateChoice(r.Context(), choice)
+	updatedChoice, err := h.choiceService.UpdateChoice(r.Context(), choice, userID)
 	if err != nil {
 		h.handleServiceError(w, err)
 		return
@@ -156,18 +185,32 @@ func (h *ChoiceHandler) UpdateChoiceHandler(w http.ResponseWriter, r *http.Reque
 		QuestionID: updatedChoice.QuestionID,
 		Text:       updatedChoice.Text,
 		IsCorrect:  updatedChoice.IsCorrect,
+		Position:   updatedChoice.Position,
 	}
 
 	h.sendJSON(w, response, http.StatusOK)
 }
 
-// DeleteChoiceHandler は選択肢を削除
+// DeleteChoiceHandler は選択肢を削除（問題の作成者のみ）
 func (h *ChoiceHandler) DeleteChoiceHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
 	// URLから選択肢IDを取得 (/api/choices/{id}/delete)
 	path := r.URL.Path
 	parts := strings.Split(path, "/")
@@ -182,7 +225,41 @@ func (h *ChoiceHandler) DeleteChoiceHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if err := h.choiceService.DeleteChoice(r.Context(), choiceID); err != nil {
+	if err := h.choiceService.DeleteChoice(r.Context(), choiceID, userID); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReorderChoicesHandler は問題の選択肢の表示順をまとめて変更（問題の作成者のみ）
+func (h *ChoiceHandler) ReorderChoicesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	var req presentationDTO.ReorderChoicesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.choiceService.ReorderChoices(r.Context(), req.QuestionID, req.ChoiceIDs, userID, userToken); err != nil {
 		h.handleServiceError(w, err)
 		return
 	}
@@ -217,7 +294,11 @@ func (h *ChoiceHandler) handleServiceError(w http.ResponseWriter, err error) {
 		switch e.Code {
 		case "NOT_FOUND":
 			h.sendError(w, e.Message, http.StatusNotFound)
-		case "CHOICE_EXISTS":
+		case "FORBIDDEN":
+			h.sendError(w, e.Message, http.StatusForbidden)
+		case "UNAUTHORIZED":
+			h.sendError(w, e.Message, http.StatusUnauthorized)
+		case "CHOICE_EXISTS", "CONFLICT":
 			h.sendError(w, e.Message, http.StatusConflict)
 		default:
 			h.sendError(w, e.Message, http.StatusInternalServerError)
@@ -244,4 +325,4 @@ func (h *ChoiceHandler) sendError(w http.ResponseWriter, message string, statusC
 		Message: message,
 	}
 	h.sendJSON(w, response, statusCode)
-}
\ No newline at end of file
+}
@@ -0,0 +1,305 @@
+package handlers
+
+// comment_handler.goはコメントに関するHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	commentDto "Shittaka_back/internal/application/comment/dto"
+	"Shittaka_back/internal/application/comment/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// CommentHandler はコメント関連のHTTPハンドラー
+type CommentHandler struct {
+	commentUsecase *usecases.CommentUsecase
+	auth           *Authenticator
+}
+
+// NewCommentHandler は新しいCommentHandlerを作成
+func NewCommentHandler(commentUsecase *usecases.CommentUsecase, auth *Authenticator) *CommentHandler {
+	return &CommentHandler{
+		commentUsecase: commentUsecase,
+		auth:           auth,
+	}
+}
+
+// GetCommentsHandler は問題のコメント一覧取得を処理 (GET /api/questions/{id}/comments)
+func (h *CommentHandler) GetCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// URLから問題IDを取得
+	questionID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	// 認証は任意（未ログイン・未回答の場合は本文が伏せられる）
+	viewerID := h.auth.OptionalUserID(r)
+
+	// ページング
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	usecaseReq := commentDto.ListCommentsRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	listResp, err := h.commentUsecase.GetComments(r.Context(), questionID, usecaseReq, viewerID)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	// レスポンスDTOに変換
+	response := presentationDTO.CommentListResponse{
+		Comments: make([]presentationDTO.CommentResponse, len(listResp.Comments)),
+		Limit:    listResp.Limit,
+		Offset:   listResp.Offset,
+		HasMore:  listResp.HasMore,
+	}
+	for i, c := range listResp.Comments {
+		response.Comments[i] = h.toCommentResponse(c)
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
+
+// CreateCommentHandler はコメント投稿を処理 (POST /api/questions/{id}/comments)
+func (h *CommentHandler) CreateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから問題IDを取得
+	questionID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	var req presentationDTO.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// DTOの変換
+	usecaseReq := commentDto.CreateCommentRequest{
+		ParentID: req.ParentID,
+		Body:     req.Body,
+	}
+
+	commentResp, err := h.commentUsecase.CreateComment(r.Context(), questionID, usecaseReq, userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toCommentResponse(commentResp), http.StatusCreated)
+}
+
+// UpdateCommentHandler はコメント編集を処理 (PUT /api/comments/{id})
+func (h *CommentHandler) UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLからコメントIDを取得
+	commentID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	var req presentationDTO.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// DTOの変換
+	usecaseReq := commentDto.UpdateCommentRequest{
+		Body: req.Body,
+	}
+
+	if err := h.commentUsecase.UpdateComment(r.Context(), commentID, usecaseReq, userID, userToken); err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, map[string]string{"message": "コメントが正常に更新されました"}, http.StatusOK)
+}
+
+// DeleteCommentHandler はコメント削除を処理 (DELETE /api/comments/{id})
+func (h *CommentHandler) DeleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLからコメントIDを取得
+	commentID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.commentUsecase.DeleteComment(r.Context(), commentID, userID, userToken); err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, map[string]string{"message": "コメントが正常に削除されました"}, http.StatusOK)
+}
+
+// ヘルパー関数
+
+// toCommentResponse はユースケースのレスポンスをHTTP DTOに変換
+func (h *CommentHandler) toCommentResponse(c *commentDto.CommentResponse) presentationDTO.CommentResponse {
+	replies := make([]presentationDTO.CommentResponse, len(c.Replies))
+	for i, reply := range c.Replies {
+		replies[i] = h.toCommentResponse(reply)
+	}
+
+	return presentationDTO.CommentResponse{
+		ID:         c.ID,
+		QuestionID: c.QuestionID,
+		UserID:     c.UserID,
+		ParentID:   c.ParentID,
+		Body:       c.Body,
+		IsHidden:   c.IsHidden,
+		IsDeleted:  c.IsDeleted,
+		IsEdited:   c.IsEdited,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+		Replies:    replies,
+	}
+}
+
+// extractToken はリクエストからトークンを抽出
+func (h *CommentHandler) extractToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+
+	// "Bearer " プレフィックスを除去
+	userToken := authHeader
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		userToken = authHeader[7:]
+	}
+
+	return userToken, nil
+}
+
+// getIDFromPath はURLパスの指定位置からIDを取得
+// 例: "/api/questions/{id}/comments" の場合は index=3
+func (h *CommentHandler) getIDFromPath(path string, index int) (int64, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) <= index {
+		return 0, shared.NewDomainError("INVALID_PATH", "Invalid ID path")
+	}
+
+	id, err := strconv.ParseInt(parts[index], 10, 64)
+	if err != nil {
+		return 0, shared.NewDomainError("INVALID_ID", "Invalid ID format")
+	}
+
+	return id, nil
+}
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *CommentHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		switch e.Code {
+		case "NOT_FOUND":
+			h.sendError(w, e.Message, http.StatusNotFound)
+		case "FORBIDDEN", "NOT_ANSWERED", "EDIT_WINDOW_EXPIRED":
+			h.sendError(w, e.Message, http.StatusForbidden)
+		case "UNAUTHORIZED":
+			h.sendError(w, e.Message, http.StatusUnauthorized)
+		default:
+			h.sendError(w, e.Message, http.StatusInternalServerError)
+		}
+	default:
+		log.Printf("Comment usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *CommentHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *CommentHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
@@ -0,0 +1,153 @@
+package handlers
+
+// daily_handler.goは「今日の一問」に関するHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	dailyDto "Shittaka_back/internal/application/daily/dto"
+	"Shittaka_back/internal/application/daily/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// DailyHandler は「今日の一問」関連のHTTPハンドラー
+type DailyHandler struct {
+	dailyUsecase *usecases.DailyQuestionUsecase
+	auth         *Authenticator
+}
+
+// NewDailyHandler は新しいDailyHandlerを作成
+func NewDailyHandler(dailyUsecase *usecases.DailyQuestionUsecase, auth *Authenticator) *DailyHandler {
+	return &DailyHandler{
+		dailyUsecase: dailyUsecase,
+		auth:         auth,
+	}
+}
+
+// GetDailyQuestionHandler は「今日の一問」の取得を処理 (GET /api/daily)
+func (h *DailyHandler) GetDailyQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	dailyResp, err := h.dailyUsecase.GetDailyQuestion(r.Context())
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toDailyQuestionResponse(dailyResp), http.StatusOK)
+}
+
+// SetDailyQuestionHandler はキュレーターによる「今日の一問」の指定を処理 (PUT /api/daily)
+func (h *DailyHandler) SetDailyQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	var req presentationDTO.SetDailyQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// DTOの変換
+	usecaseReq := dailyDto.SetDailyQuestionRequest{
+		Date:       req.Date,
+		QuestionID: req.QuestionID,
+	}
+
+	dailyResp, err := h.dailyUsecase.SetDailyQuestion(r.Context(), usecaseReq, userID)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toDailyQuestionResponse(dailyResp), http.StatusOK)
+}
+
+// ヘルパー関数
+
+// toDailyQuestionResponse はユースケースのレスポンスをHTTP DTOに変換
+func (h *DailyHandler) toDailyQuestionResponse(daily *dailyDto.DailyQuestionResponse) presentationDTO.DailyQuestionResponse {
+	return presentationDTO.DailyQuestionResponse{
+		Date:       daily.Date,
+		IsOverride: daily.IsOverride,
+		Question:   newQuestionResponse(daily.Question),
+	}
+}
+
+// extractToken はリクエストからトークンを抽出
+func (h *DailyHandler) extractToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+
+	// "Bearer " プレフィックスを除去
+	userToken := authHeader
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		userToken = authHeader[7:]
+	}
+
+	return userToken, nil
+}
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *DailyHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		switch e.Code {
+		case "NOT_FOUND":
+			h.sendError(w, e.Message, http.StatusNotFound)
+		case "FORBIDDEN":
+			h.sendError(w, e.Message, http.StatusForbidden)
+		case "UNAUTHORIZED":
+			h.sendError(w, e.Message, http.StatusUnauthorized)
+		default:
+			h.sendError(w, e.Message, http.StatusInternalServerError)
+		}
+	default:
+		log.Printf("Daily question usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *DailyHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *DailyHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
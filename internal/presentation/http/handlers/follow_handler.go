@@ -0,0 +1,231 @@
+package handlers
+
+// follow_handler.goはユーザー・ジャンルのフォローに関するHTTPハンドラーを定義
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	followDto "Shittaka_back/internal/application/follow/dto"
+	"Shittaka_back/internal/application/follow/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// FollowHandler はフォロー関連のHTTPハンドラー
+type FollowHandler struct {
+	followUsecase *usecases.FollowUsecase
+	auth          *Authenticator
+}
+
+// NewFollowHandler は新しいFollowHandlerを作成
+func NewFollowHandler(followUsecase *usecases.FollowUsecase, auth *Authenticator) *FollowHandler {
+	return &FollowHandler{
+		followUsecase: followUsecase,
+		auth:          auth,
+	}
+}
+
+// FollowUserHandler はユーザーのフォロー・フォロー解除を処理 (POST/DELETE /api/users/{id}/follow)
+func (h *FollowHandler) FollowUserHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userToken, userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	// "/api/users/{id}/follow" の形式から相手のユーザーIDを取得
+	followeeID := h.getUserIDFromPath(r.URL.Path)
+
+	var err error
+	message := "フォローしました"
+	if r.Method == http.MethodPost {
+		err = h.followUsecase.FollowUser(r.Context(), userID, followeeID, userToken)
+	} else {
+		err = h.followUsecase.UnfollowUser(r.Context(), userID, followeeID, userToken)
+		message = "フォローを解除しました"
+	}
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, map[string]string{"message": message}, http.StatusOK)
+}
+
+// GetFollowersHandler はフォロワー一覧取得を処理 (GET /api/users/{id}/followers)
+func (h *FollowHandler) GetFollowersHandler(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.followUsecase.GetFollowers)
+}
+
+// GetFollowingHandler はフォロー中のユーザー一覧取得を処理 (GET /api/users/{id}/following)
+func (h *FollowHandler) GetFollowingHandler(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.followUsecase.GetFollowing)
+}
+
+// FollowGenreHandler はジャンルのフォロー・フォロー解除を処理 (POST/DELETE /api/genres/{id}/follow)
+func (h *FollowHandler) FollowGenreHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userToken, userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	// "/api/genres/{id}/follow" の形式からジャンルIDを取得
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 5 || parts[4] != "follow" {
+		h.sendError(w, "Not found", http.StatusNotFound)
+		return
+	}
+	genreID, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		h.sendError(w, "Invalid genre ID", http.StatusBadRequest)
+		return
+	}
+
+	message := "ジャンルをフォローしました"
+	if r.Method == http.MethodPost {
+		err = h.followUsecase.FollowGenre(r.Context(), userID, genreID, userToken)
+	} else {
+		err = h.followUsecase.UnfollowGenre(r.Context(), userID, genreID, userToken)
+		message = "ジャンルのフォローを解除しました"
+	}
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, map[string]string{"message": message}, http.StatusOK)
+}
+
+// listFollows はフォロワー・フォロー中の一覧取得の共通処理
+func (h *FollowHandler) listFollows(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userID string, req followDto.ListFollowsRequest) (*followDto.FollowListResponse, error)) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// ページング
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	listResp, err := list(r.Context(), h.getUserIDFromPath(r.URL.Path), followDto.ListFollowsRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	// レスポンスDTOに変換
+	response := presentationDTO.FollowListResponse{
+		Users:   make([]presentationDTO.FollowUserResponse, len(listResp.Users)),
+		Limit:   listResp.Limit,
+		Offset:  listResp.Offset,
+		HasMore: listResp.HasMore,
+	}
+	for i, user := range listResp.Users {
+		response.Users[i] = presentationDTO.FollowUserResponse{
+			UserID:     user.UserID,
+			FollowedAt: user.FollowedAt,
+		}
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
+
+// ヘルパー関数
+
+// authenticate はリクエストのトークンとユーザーIDを取得し、認証できなければエラーレスポンスを返す
+func (h *FollowHandler) authenticate(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return "", "", false
+	}
+
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return "", "", false
+	}
+
+	return userToken, userID, true
+}
+
+// getUserIDFromPath は "/api/users/{id}/..." の形式からユーザーIDを取得
+func (h *FollowHandler) getUserIDFromPath(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
+
+// extractToken はリクエストからトークンを抽出
+func (h *FollowHandler) extractToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+
+	// "Bearer " プレフィックスを除去
+	userToken := authHeader
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		userToken = authHeader[7:]
+	}
+
+	return userToken, nil
+}
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *FollowHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		switch e.Code {
+		case "NOT_FOUND":
+			h.sendError(w, e.Message, http.StatusNotFound)
+		case "UNAUTHORIZED":
+			h.sendError(w, e.Message, http.StatusUnauthorized)
+		default:
+			h.sendError(w, e.Message, http.StatusInternalServerError)
+		}
+	default:
+		log.Printf("Follow usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *FollowHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *FollowHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
@@ -16,12 +16,14 @@ import (
 // GenreHandler はジャンル関連のHTTPハンドラー
 type GenreHandler struct {
 	genreUsecase *usecases.GenreUsecase
+	auth         *Authenticator
 }
 
 // NewGenreHandler は新しいGenreHandlerを作成
-func NewGenreHandler(genreUsecase *usecases.GenreUsecase) *GenreHandler {
+func NewGenreHandler(genreUsecase *usecases.GenreUsecase, auth *Authenticator) *GenreHandler {
 	return &GenreHandler{
 		genreUsecase: genreUsecase,
+		auth:         auth,
 	}
 }
 
@@ -45,6 +47,12 @@ func (h *GenreHandler) CreateGenreHandler(w http.ResponseWriter, r *http.Request
 		userToken = authHeader[7:]
 	}
 
+	// トークンを検証（行レベルセキュリティを通らない保存先でも未認証の作成を防ぐ）
+	if _, err := h.auth.UserID(userToken); err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
 	var req presentationDTO.CreateGenreRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
@@ -96,7 +104,7 @@ func (h *GenreHandler) handleUsecaseError(w http.ResponseWriter, err error) {
 		h.sendError(w, e.Message, http.StatusBadRequest)
 	case shared.DomainError:
 		switch e.Code {
-		case "GENRE_EXISTS":
+		case "GENRE_EXISTS", "CONFLICT":
 			h.sendError(w, e.Message, http.StatusConflict)
 		case "NOT_FOUND":
 			h.sendError(w, e.Message, http.StatusNotFound)
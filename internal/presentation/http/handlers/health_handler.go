@@ -0,0 +1,61 @@
+package handlers
+
+// health_handler.goは死活監視・準備状態の確認のHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"Shittaka_back/internal/application/health/usecases"
+)
+
+// HealthHandler は死活監視・準備状態の確認のHTTPハンドラー
+type HealthHandler struct {
+	readinessUsecase *usecases.ReadinessUsecase
+}
+
+// NewHealthHandler は新しいHealthHandlerを作成
+func NewHealthHandler(readinessUsecase *usecases.ReadinessUsecase) *HealthHandler {
+	return &HealthHandler{
+		readinessUsecase: readinessUsecase,
+	}
+}
+
+// LivezHandler はプロセスが応答できることを返す (GET /livez)
+// 依存先には接続しないため、依存先の障害でプロセスが再起動されることはない
+func (h *HealthHandler) LivezHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.sendJSON(w, map[string]string{"status": "ok"}, http.StatusOK)
+}
+
+// ReadyzHandler は依存先ごとの状態と応答時間を返す (GET /readyz)
+// 接続できない依存先がある場合は 503 を返し、ロードバランサーの振り分け先から外す
+func (h *HealthHandler) ReadyzHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result := h.readinessUsecase.Check(r.Context())
+	statusCode := http.StatusOK
+	if !result.Ready() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	h.sendJSON(w, result, statusCode)
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *HealthHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
-	"encoding/base64"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	questionDto "Shittaka_back/internal/application/question/dto"
 	"Shittaka_back/internal/application/question/usecases"
@@ -15,15 +19,26 @@ import (
 	presentationDTO "Shittaka_back/internal/presentation/dto"
 )
 
+// maxImportBytes は一括インポートで受け付けるファイルの最大サイズ
+const maxImportBytes = 5 << 20
+
 // QuestionHandler は問題関連のHTTPハンドラー
 type QuestionHandler struct {
 	questionUsecase *usecases.QuestionUsecase
+	importUsecase   *usecases.QuestionImportUsecase
+	exportUsecase   *usecases.QuestionExportUsecase
+	feedUsecase     *usecases.QuestionFeedUsecase
+	auth            *Authenticator
 }
 
 // NewQuestionHandler は新しいQuestionHandlerを作成
-func NewQuestionHandler(questionUsecase *usecases.QuestionUsecase) *QuestionHandler {
+func NewQuestionHandler(questionUsecase *usecases.QuestionUsecase, importUsecase *usecases.QuestionImportUsecase, exportUsecase *usecases.QuestionExportUsecase, feedUsecase *usecases.QuestionFeedUsecase, auth *Authenticator) *QuestionHandler {
 	return &QuestionHandler{
 		questionUsecase: questionUsecase,
+		importUsecase:   importUsecase,
+		exportUsecase:   exportUsecase,
+		feedUsecase:     feedUsecase,
+		auth:            auth,
 	}
 }
 
@@ -42,7 +57,7 @@ func (h *QuestionHandler) CreateQuestionHandler(w http.ResponseWriter, r *http.R
 	}
 
 	// ユーザーIDを取得
-	userID, err := h.getUserIDFromToken(userToken)
+	userID, err := h.auth.UserID(userToken)
 	if err != nil {
 		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
 		return
@@ -60,6 +75,15 @@ func (h *QuestionHandler) CreateQuestionHandler(w http.ResponseWriter, r *http.R
 		Title:       req.Title,
 		Body:        req.Body,
 		Explanation: req.Explanation,
+		BodyFormat:  req.BodyFormat,
+
+		Type:             req.Type,
+		PartialCredit:    req.PartialCredit,
+		AcceptedAnswers:  req.AcceptedAnswers,
+		NumericAnswer:    req.NumericAnswer,
+		NumericTolerance: req.NumericTolerance,
+		ShuffleChoices:   req.ShuffleChoices,
+		Tags:             req.Tags,
 	}
 
 	questionResp, err := h.questionUsecase.CreateQuestion(r.Context(), usecaseReq, userID, userToken)
@@ -69,18 +93,7 @@ func (h *QuestionHandler) CreateQuestionHandler(w http.ResponseWriter, r *http.R
 	}
 
 	// レスポンスDTOに変換
-	response := presentationDTO.QuestionResponse{
-		ID:             questionResp.ID,
-		GenreID:        questionResp.GenreID,
-		UserID:         questionResp.UserID,
-		Title:          questionResp.Title,
-		Body:           questionResp.Body,
-		Explanation:    questionResp.Explanation,
-		CreatedAt:      questionResp.CreatedAt,
-		Views:          questionResp.Views,
-		CorrectCount:   questionResp.CorrectCount,
-		IncorrectCount: questionResp.IncorrectCount,
-	}
+	response := h.toQuestionResponse(questionResp)
 
 	h.sendJSON(w, response, http.StatusCreated)
 }
@@ -100,7 +113,7 @@ func (h *QuestionHandler) UpdateQuestionHandler(w http.ResponseWriter, r *http.R
 	}
 
 	// ユーザーIDを取得
-	userID, err := h.getUserIDFromToken(userToken)
+	userID, err := h.auth.UserID(userToken)
 	if err != nil {
 		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
 		return
@@ -124,6 +137,15 @@ func (h *QuestionHandler) UpdateQuestionHandler(w http.ResponseWriter, r *http.R
 		Title:       req.Title,
 		Body:        req.Body,
 		Explanation: req.Explanation,
+		BodyFormat:  req.BodyFormat,
+
+		Type:             req.Type,
+		PartialCredit:    req.PartialCredit,
+		AcceptedAnswers:  req.AcceptedAnswers,
+		NumericAnswer:    req.NumericAnswer,
+		NumericTolerance: req.NumericTolerance,
+		ShuffleChoices:   req.ShuffleChoices,
+		Tags:             req.Tags,
 	}
 
 	err = h.questionUsecase.UpdateQuestion(r.Context(), questionID, usecaseReq, userID, userToken)
@@ -150,7 +172,7 @@ func (h *QuestionHandler) DeleteQuestionHandler(w http.ResponseWriter, r *http.R
 	}
 
 	// ユーザーIDを取得
-	userID, err := h.getUserIDFromToken(userToken)
+	userID, err := h.auth.UserID(userToken)
 	if err != nil {
 		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
 		return
@@ -186,29 +208,388 @@ func (h *QuestionHandler) GetQuestionHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	questionResp, err := h.questionUsecase.GetQuestion(r.Context(), questionID)
+	// 下書きは作成者のみ閲覧できるため、ログイン中であればユーザーIDを渡す
+	viewerID := h.auth.OptionalUserID(r)
+	questionResp, err := h.questionUsecase.GetQuestion(r.Context(), questionID, viewerID)
 	if err != nil {
 		h.handleUsecaseError(w, err)
 		return
 	}
 
-	// レスポンスDTOに変換
-	response := presentationDTO.QuestionResponse{
-		ID:             questionResp.ID,
-		GenreID:        questionResp.GenreID,
-		UserID:         questionResp.UserID,
-		Title:          questionResp.Title,
-		Body:           questionResp.Body,
-		Explanation:    questionResp.Explanation,
-		CreatedAt:      questionResp.CreatedAt,
-		Views:          questionResp.Views,
-		CorrectCount:   questionResp.CorrectCount,
-		IncorrectCount: questionResp.IncorrectCount,
+	// 閲覧数を記録（公開中の問題のみ、作成者を除く。ユーザー単位、未ログインならIP単位で重複を除外）
+	h.questionUsecase.RecordView(questionResp, viewerID, h.auth.ViewerKey(r))
+
+	if h.wantsRenderedHTML(r) {
+		h.questionUsecase.RenderHTML(questionResp)
 	}
 
+	// レスポンスDTOに変換
+	response := h.toQuestionResponse(questionResp)
+
 	h.sendJSON(w, response, http.StatusOK)
 }
 
+// GetRevisionsHandler は問題の編集履歴取得を処理 (GET /api/questions/{id}/revisions)
+func (h *QuestionHandler) GetRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// URLから問題IDを取得
+	questionID, err := h.getIntFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	revisionResp, err := h.questionUsecase.GetRevisions(r.Context(), questionID, h.auth.OptionalUserID(r))
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	// レスポンスDTOに変換
+	responses := make([]presentationDTO.QuestionRevisionResponse, len(revisionResp))
+	for i, rev := range revisionResp {
+		responses[i] = presentationDTO.QuestionRevisionResponse{
+			ID:          rev.ID,
+			QuestionID:  rev.QuestionID,
+			Revision:    rev.Revision,
+			UserID:      rev.UserID,
+			Title:       rev.Title,
+			Body:        rev.Body,
+			Explanation: rev.Explanation,
+			Diff:        rev.Diff,
+			CreatedAt:   rev.CreatedAt,
+		}
+	}
+
+	h.sendJSON(w, responses, http.StatusOK)
+}
+
+// RevertQuestionHandler は問題を過去のリビジョンに戻す処理 (POST /api/questions/{id}/revisions/{revision}/revert)
+func (h *QuestionHandler) RevertQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから問題IDとリビジョン番号を取得
+	questionID, err := h.getIntFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+	revision, err := h.getIntFromPath(r.URL.Path, 5)
+	if err != nil {
+		h.sendError(w, "Invalid revision", http.StatusBadRequest)
+		return
+	}
+
+	questionResp, err := h.questionUsecase.RevertQuestion(r.Context(), questionID, int(revision), userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toQuestionResponse(questionResp), http.StatusOK)
+}
+
+// PublishQuestionHandler は問題の公開を処理 (POST /api/questions/{id}/publish)
+func (h *QuestionHandler) PublishQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.questionUsecase.PublishQuestion)
+}
+
+// ScheduleQuestionHandler は問題の予約公開を処理 (PUT /api/questions/{id}/schedule)
+func (h *QuestionHandler) ScheduleQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから問題IDを取得
+	questionID, err := h.getIntFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	var req presentationDTO.ScheduleQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	usecaseReq := questionDto.ScheduleQuestionRequest{
+		PublishAt: req.PublishAt,
+	}
+
+	questionResp, err := h.questionUsecase.ScheduleQuestion(r.Context(), questionID, usecaseReq, userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toQuestionResponse(questionResp), http.StatusOK)
+}
+
+// UnpublishQuestionHandler は問題の下書きへの差し戻しを処理 (POST /api/questions/{id}/unpublish)
+func (h *QuestionHandler) UnpublishQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.questionUsecase.UnpublishQuestion)
+}
+
+// ArchiveQuestionHandler は問題のアーカイブを処理 (POST /api/questions/{id}/archive)
+func (h *QuestionHandler) ArchiveQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.questionUsecase.ArchiveQuestion)
+}
+
+// changeStatus は公開状態を変更するエンドポイントの共通処理
+func (h *QuestionHandler) changeStatus(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, id int64, userID string, userToken string) (*questionDto.QuestionResponse, error)) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから問題IDを取得
+	questionID, err := h.getIntFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	questionResp, err := change(r.Context(), questionID, userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toQuestionResponse(questionResp), http.StatusOK)
+}
+
+// ImportQuestionsHandler は問題の一括インポートを処理 (POST /api/questions/import)
+// CSV・JSONをリクエストボディ、または multipart/form-data の file で受け取る
+// 形式は ?format=csv|json、ファイルの拡張子、Content-Type の順に判定し、?dry_run=true で検証のみ行う
+func (h *QuestionHandler) ImportQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// 上限を超えるリクエストボディは読み込む前に打ち切る
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+	data, filename, err := h.readImportFile(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.sendError(w, fmt.Sprintf("ファイルサイズは%dバイト以下にしてください", maxImportBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.sendError(w, "Failed to read import file", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	usecaseReq := questionDto.ImportQuestionsRequest{
+		Format:  h.detectImportFormat(r, filename),
+		Data:    data,
+		DryRun:  query.Get("dry_run") == "true",
+		Publish: query.Get("publish") == "true",
+	}
+
+	importResp, err := h.importUsecase.ImportQuestions(r.Context(), usecaseReq, userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, importResp, http.StatusOK)
+}
+
+// readImportFile はインポートするファイルの中身とファイル名を読み取る
+func (h *QuestionHandler) readImportFile(r *http.Request) ([]byte, string, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		data, err := io.ReadAll(r.Body)
+		return data, "", err
+	}
+
+	if err := r.ParseMultipartForm(maxImportBytes); err != nil {
+		return nil, "", err
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	return data, header.Filename, err
+}
+
+// detectImportFormat はインポートするファイルの形式を判定する
+func (h *QuestionHandler) detectImportFormat(r *http.Request, filename string) string {
+	if format := strings.ToLower(r.URL.Query().Get("format")); format != "" {
+		return format
+	}
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		return questionDto.ImportFormatCSV
+	case ".json":
+		return questionDto.ImportFormatJSON
+	}
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "csv"):
+		return questionDto.ImportFormatCSV
+	case strings.Contains(contentType, "json"):
+		return questionDto.ImportFormatJSON
+	}
+	return ""
+}
+
+// ExportQuestionsHandler は問題のエクスポートを処理 (GET /api/questions/export)
+// ?format=csv|json|anki と、?scope=mine（自分の問題、認証が必要）または ?genre_id={id}（ジャンル内の公開中の問題）を指定する
+func (h *QuestionHandler) ExportQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	usecaseReq := questionDto.ExportQuestionsRequest{
+		Format: strings.ToLower(query.Get("format")),
+		Scope:  query.Get("scope"),
+	}
+	if usecaseReq.Format == "" {
+		usecaseReq.Format = questionDto.ExportFormatCSV
+	}
+	if v := query.Get("genre_id"); v != "" {
+		genreID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			h.sendError(w, "Invalid genre ID", http.StatusBadRequest)
+			return
+		}
+		usecaseReq.GenreID = genreID
+		if usecaseReq.Scope == "" {
+			usecaseReq.Scope = questionDto.ExportScopeGenre
+		}
+	}
+
+	// 認証は自分の問題をエクスポートする場合のみ必須
+	var userID, userToken string
+	if token, err := h.extractToken(r); err == nil {
+		if id, err := h.auth.UserID(token); err == nil {
+			userID, userToken = id, token
+		}
+	}
+
+	export, err := h.exportUsecase.PrepareExport(r.Context(), usecaseReq, userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename))
+	w.WriteHeader(http.StatusOK)
+
+	// SERVER_WRITE_TIMEOUT はレスポンス全体にかかり、大きなジャンルでは書き出しの途中で切れてしまうため
+	// エクスポートでは書き込みの期限をページごとに延ばす
+	stream := newDeadlineExtendingWriter(w, exportPageWriteTimeout)
+
+	// 書き出しを始めた後はステータスを変更できないため、エラーはログに残して打ち切る
+	if err := export.Stream(r.Context(), stream); err != nil {
+		log.Printf("Question export error: %v", err)
+	}
+}
+
+// exportPageWriteTimeout はエクスポートで1ページ分（200件）を書き込むまでの上限時間
+const exportPageWriteTimeout = 30 * time.Second
+
+// deadlineExtendingWriter は Flush のたびにレスポンスの書き込み期限を延ばす
+// 書き出しが進んでいる間は続け、クライアントが受け取らなくなった場合は期限で打ち切る
+type deadlineExtendingWriter struct {
+	http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+// newDeadlineExtendingWriter は最初の期限を設定した deadlineExtendingWriter を作成
+func newDeadlineExtendingWriter(w http.ResponseWriter, timeout time.Duration) *deadlineExtendingWriter {
+	dw := &deadlineExtendingWriter{ResponseWriter: w, rc: http.NewResponseController(w), timeout: timeout}
+	dw.extend()
+	return dw
+}
+
+// Flush は書き込んだ内容をクライアントへ送り、次のページの期限を設定する
+func (w *deadlineExtendingWriter) Flush() {
+	w.rc.Flush()
+	w.extend()
+}
+
+// extend は書き込み期限を timeout 後に設定する（期限を設定できない ResponseWriter では何もしない）
+func (w *deadlineExtendingWriter) extend() {
+	w.rc.SetWriteDeadline(time.Now().Add(w.timeout))
+}
+
 // GetQuestionsHandler は問題一覧取得を処理
 func (h *QuestionHandler) GetQuestionsHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -216,32 +597,65 @@ func (h *QuestionHandler) GetQuestionsHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// ?tag= が指定された場合はタグで絞り込み、ページングして返す
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		h.getQuestionsByTag(w, r, tag)
+		return
+	}
+
 	questionResp, err := h.questionUsecase.GetAllQuestions(r.Context())
 	if err != nil {
 		h.handleUsecaseError(w, err)
 		return
 	}
 
+	if h.wantsRenderedHTML(r) {
+		h.questionUsecase.RenderHTML(questionResp...)
+	}
+
 	// レスポンスDTOに変換
 	responses := make([]presentationDTO.QuestionResponse, len(questionResp))
 	for i, q := range questionResp {
-		responses[i] = presentationDTO.QuestionResponse{
-			ID:             q.ID,
-			GenreID:        q.GenreID,
-			UserID:         q.UserID,
-			Title:          q.Title,
-			Body:           q.Body,
-			Explanation:    q.Explanation,
-			CreatedAt:      q.CreatedAt,
-			Views:          q.Views,
-			CorrectCount:   q.CorrectCount,
-			IncorrectCount: q.IncorrectCount,
-		}
+		responses[i] = h.toQuestionResponse(q)
 	}
 
 	h.sendJSON(w, responses, http.StatusOK)
 }
 
+// getQuestionsByTag はタグが付いた公開中の問題一覧取得を処理 (GET /api/questions?tag=&limit=&offset=)
+func (h *QuestionHandler) getQuestionsByTag(w http.ResponseWriter, r *http.Request, tag string) {
+	// ページング
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	listResp, err := h.questionUsecase.GetQuestionsByTag(r.Context(), tag, questionDto.ListQuestionsRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	if h.wantsRenderedHTML(r) {
+		h.questionUsecase.RenderHTML(listResp.Questions...)
+	}
+
+	// レスポンスDTOに変換
+	response := presentationDTO.QuestionListResponse{
+		Questions: make([]presentationDTO.QuestionResponse, len(listResp.Questions)),
+		Limit:     listResp.Limit,
+		Offset:    listResp.Offset,
+		HasMore:   listResp.HasMore,
+	}
+	for i, q := range listResp.Questions {
+		response.Questions[i] = h.toQuestionResponse(q)
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
+
 // GetMyQuestionsHandler はユーザーの問題一覧取得を処理
 func (h *QuestionHandler) GetMyQuestionsHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -257,7 +671,7 @@ func (h *QuestionHandler) GetMyQuestionsHandler(w http.ResponseWriter, r *http.R
 	}
 
 	// ユーザーIDを取得
-	userID, err := h.getUserIDFromToken(userToken)
+	userID, err := h.auth.UserID(userToken)
 	if err != nil {
 		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
 		return
@@ -269,28 +683,174 @@ func (h *QuestionHandler) GetMyQuestionsHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if h.wantsRenderedHTML(r) {
+		h.questionUsecase.RenderHTML(questionResp...)
+	}
+
 	// レスポンスDTOに変換
 	responses := make([]presentationDTO.QuestionResponse, len(questionResp))
 	for i, q := range questionResp {
-		responses[i] = presentationDTO.QuestionResponse{
-			ID:             q.ID,
-			GenreID:        q.GenreID,
-			UserID:         q.UserID,
-			Title:          q.Title,
-			Body:           q.Body,
-			Explanation:    q.Explanation,
-			CreatedAt:      q.CreatedAt,
-			Views:          q.Views,
-			CorrectCount:   q.CorrectCount,
-			IncorrectCount: q.IncorrectCount,
-		}
+		responses[i] = h.toQuestionResponse(q)
 	}
 
 	h.sendJSON(w, responses, http.StatusOK)
 }
 
+// GetUserQuestionsHandler は他のユーザーが作成した公開中の問題一覧取得を処理 (GET /api/users/{id}/questions)
+func (h *QuestionHandler) GetUserQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// "/api/users/{id}/questions" の形式からユーザーIDを取得
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 5 {
+		h.sendError(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+	userID := parts[3]
+
+	// ページング
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	listResp, err := h.questionUsecase.GetPublishedQuestionsByUser(r.Context(), userID, questionDto.ListQuestionsRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	if h.wantsRenderedHTML(r) {
+		h.questionUsecase.RenderHTML(listResp.Questions...)
+	}
+
+	// レスポンスDTOに変換
+	response := presentationDTO.QuestionListResponse{
+		Questions: make([]presentationDTO.QuestionResponse, len(listResp.Questions)),
+		Limit:     listResp.Limit,
+		Offset:    listResp.Offset,
+		HasMore:   listResp.HasMore,
+	}
+	for i, q := range listResp.Questions {
+		response.Questions[i] = h.toQuestionResponse(q)
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
+
+// GetFeedHandler はフォローしている作成者・ジャンルの新しい問題のフィード取得を処理 (GET /api/feed?cursor=&limit=)
+func (h *QuestionHandler) GetFeedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	feedResp, err := h.feedUsecase.GetFeed(r.Context(), userID, questionDto.FeedRequest{
+		Cursor: query.Get("cursor"),
+		Limit:  limit,
+	})
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	if h.wantsRenderedHTML(r) {
+		h.questionUsecase.RenderHTML(feedResp.Questions...)
+	}
+
+	// レスポンスDTOに変換
+	response := presentationDTO.FeedResponse{
+		Questions:  make([]presentationDTO.QuestionResponse, len(feedResp.Questions)),
+		NextCursor: feedResp.NextCursor,
+	}
+	for i, q := range feedResp.Questions {
+		response.Questions[i] = h.toQuestionResponse(q)
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
+
 // ヘルパー関数
 
+// toQuestionResponse はユースケースのレスポンスをHTTP DTOに変換
+func (h *QuestionHandler) toQuestionResponse(q *questionDto.QuestionResponse) presentationDTO.QuestionResponse {
+	return newQuestionResponse(q)
+}
+
+// newQuestionResponse はユースケースのDTOを問題レスポンスのHTTP DTOに変換
+// 問題を含むレスポンスを返す他のハンドラーからも利用する
+func newQuestionResponse(q *questionDto.QuestionResponse) presentationDTO.QuestionResponse {
+	return presentationDTO.QuestionResponse{
+		ID:             q.ID,
+		GenreID:        q.GenreID,
+		UserID:         q.UserID,
+		Title:          q.Title,
+		Body:           q.Body,
+		Explanation:    q.Explanation,
+		BodyFormat:     q.BodyFormat,
+		CreatedAt:      q.CreatedAt,
+		Views:          q.Views,
+		CorrectCount:   q.CorrectCount,
+		IncorrectCount: q.IncorrectCount,
+		IsHidden:       q.IsHidden,
+		Revision:       q.Revision,
+		Status:         q.Status,
+		PublishedAt:    q.PublishedAt,
+		PublishAt:      q.PublishAt,
+
+		BodyHTML:        q.BodyHTML,
+		ExplanationHTML: q.ExplanationHTML,
+
+		Type:             q.Type,
+		PartialCredit:    q.PartialCredit,
+		AcceptedAnswers:  q.AcceptedAnswers,
+		NumericAnswer:    q.NumericAnswer,
+		NumericTolerance: q.NumericTolerance,
+		ShuffleChoices:   q.ShuffleChoices,
+		Tags:             q.Tags,
+		Attachments:      newAttachmentResponses(q.Attachments),
+		SimilarQuestions: newSimilarQuestionResponses(q.SimilarQuestions),
+	}
+}
+
+// newSimilarQuestionResponses は似た問題の警告をプレゼンテーション層のDTOに変換
+func newSimilarQuestionResponses(similar []*questionDto.SimilarQuestionResponse) []presentationDTO.SimilarQuestionResponse {
+	if len(similar) == 0 {
+		return nil
+	}
+	responses := make([]presentationDTO.SimilarQuestionResponse, len(similar))
+	for i, s := range similar {
+		responses[i] = presentationDTO.SimilarQuestionResponse{
+			ID:         s.ID,
+			Title:      s.Title,
+			Similarity: s.Similarity,
+		}
+	}
+	return responses
+}
+
 // extractToken はリクエストからトークンを抽出
 func (h *QuestionHandler) extractToken(r *http.Request) (string, error) {
 	authHeader := r.Header.Get("Authorization")
@@ -307,32 +867,9 @@ func (h *QuestionHandler) extractToken(r *http.Request) (string, error) {
 	return userToken, nil
 }
 
-// getUserIDFromToken はJWTトークンからユーザーIDを取得
-func (h *QuestionHandler) getUserIDFromToken(token string) (string, error) {
-	// JWTトークンを分割 (header.payload.signature)
-	parts := strings.Split(token, ".")
-	if len(parts) != 3 {
-		return "", shared.NewDomainError("INVALID_TOKEN", "Invalid JWT format")
-	}
-
-	// payloadをデコード
-	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
-	if err != nil {
-		return "", fmt.Errorf("failed to decode JWT payload: %w", err)
-	}
-
-	// JSONとしてパース
-	var claims map[string]interface{}
-	if err := json.Unmarshal(payload, &claims); err != nil {
-		return "", fmt.Errorf("failed to parse JWT claims: %w", err)
-	}
-
-	// subクレームからユーザーIDを取得
-	if sub, ok := claims["sub"].(string); ok && sub != "" {
-		return sub, nil
-	}
-
-	return "", shared.NewDomainError("INVALID_TOKEN", "User ID not found in token")
+// wantsRenderedHTML はレスポンスに表示用のHTMLを含めるか（?render=html）を返す
+func (h *QuestionHandler) wantsRenderedHTML(r *http.Request) bool {
+	return r.URL.Query().Get("render") == "html"
 }
 
 // getQuestionIDFromPath はURLパスから問題IDを取得
@@ -352,6 +889,22 @@ func (h *QuestionHandler) getQuestionIDFromPath(path string) (int64, error) {
 	return questionID, nil
 }
 
+// getIntFromPath はURLパスの指定位置から数値を取得
+// 例: "/api/questions/{id}/revisions/{revision}/revert" の場合、IDは index=3、リビジョンは index=5
+func (h *QuestionHandler) getIntFromPath(path string, index int) (int64, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) <= index {
+		return 0, shared.NewDomainError("INVALID_PATH", "Invalid path")
+	}
+
+	value, err := strconv.ParseInt(parts[index], 10, 64)
+	if err != nil {
+		return 0, shared.NewDomainError("INVALID_ID", "Invalid number format")
+	}
+
+	return value, nil
+}
+
 // handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
 func (h *QuestionHandler) handleUsecaseError(w http.ResponseWriter, err error) {
 	switch e := err.(type) {
@@ -365,6 +918,8 @@ func (h *QuestionHandler) handleUsecaseError(w http.ResponseWriter, err error) {
 			h.sendError(w, e.Message, http.StatusForbidden)
 		case "UNAUTHORIZED":
 			h.sendError(w, e.Message, http.StatusUnauthorized)
+		case "INVALID_TRANSITION", "DUPLICATE_QUESTION", "CONFLICT":
+			h.sendError(w, e.Message, http.StatusConflict)
 		default:
 			h.sendError(w, e.Message, http.StatusInternalServerError)
 		}
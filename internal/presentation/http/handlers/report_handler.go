@@ -0,0 +1,263 @@
+package handlers
+
+// report_handler.goは通報とモデレーションに関するHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	reportDto "Shittaka_back/internal/application/report/dto"
+	"Shittaka_back/internal/application/report/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// ReportHandler は通報関連のHTTPハンドラー
+type ReportHandler struct {
+	reportUsecase *usecases.ReportUsecase
+	auth          *Authenticator
+}
+
+// NewReportHandler は新しいReportHandlerを作成
+func NewReportHandler(reportUsecase *usecases.ReportUsecase, auth *Authenticator) *ReportHandler {
+	return &ReportHandler{
+		reportUsecase: reportUsecase,
+		auth:          auth,
+	}
+}
+
+// CreateReportHandler は問題の通報を処理 (POST /api/questions/{id}/reports)
+func (h *ReportHandler) CreateReportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから問題IDを取得
+	questionID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	var req presentationDTO.CreateReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// DTOの変換
+	usecaseReq := reportDto.CreateReportRequest{
+		Reason:  req.Reason,
+		Comment: req.Comment,
+	}
+
+	reportResp, err := h.reportUsecase.CreateReport(r.Context(), questionID, usecaseReq, userID, userToken)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toReportResponse(reportResp), http.StatusCreated)
+}
+
+// ListReportsHandler はモデレーター向けの通報キュー取得を処理 (GET /api/reports?status=open)
+func (h *ReportHandler) ListReportsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	usecaseReq := reportDto.ListReportsRequest{
+		Status: query.Get("status"),
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	reportResp, err := h.reportUsecase.ListReports(r.Context(), usecaseReq, userID)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	// レスポンスDTOに変換
+	responses := make([]presentationDTO.ReportResponse, len(reportResp))
+	for i, report := range reportResp {
+		responses[i] = h.toReportResponse(report)
+	}
+
+	h.sendJSON(w, responses, http.StatusOK)
+}
+
+// ResolveReportHandler は通報の受理・却下を処理 (PUT /api/reports/{id})
+func (h *ReportHandler) ResolveReportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証トークンの取得
+	userToken, err := h.extractToken(r)
+	if err != nil {
+		h.sendError(w, "認証が必要です", http.StatusUnauthorized)
+		return
+	}
+
+	// ユーザーIDを取得
+	userID, err := h.auth.UserID(userToken)
+	if err != nil {
+		h.sendError(w, "無効なトークンです", http.StatusUnauthorized)
+		return
+	}
+
+	// URLから通報IDを取得
+	reportID, err := h.getIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		h.sendError(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	var req presentationDTO.ResolveReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// DTOの変換
+	usecaseReq := reportDto.ResolveReportRequest{
+		Status: req.Status,
+	}
+
+	reportResp, err := h.reportUsecase.ResolveReport(r.Context(), reportID, usecaseReq, userID)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.toReportResponse(reportResp), http.StatusOK)
+}
+
+// ヘルパー関数
+
+// toReportResponse はユースケースのレスポンスをHTTP DTOに変換
+func (h *ReportHandler) toReportResponse(report *reportDto.ReportResponse) presentationDTO.ReportResponse {
+	return presentationDTO.ReportResponse{
+		ID:         report.ID,
+		QuestionID: report.QuestionID,
+		UserID:     report.UserID,
+		Reason:     report.Reason,
+		Comment:    report.Comment,
+		Status:     report.Status,
+		CreatedAt:  report.CreatedAt,
+		ResolvedAt: report.ResolvedAt,
+		ResolvedBy: report.ResolvedBy,
+	}
+}
+
+// extractToken はリクエストからトークンを抽出
+func (h *ReportHandler) extractToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", shared.NewDomainError("UNAUTHORIZED", "認証が必要です")
+	}
+
+	// "Bearer " プレフィックスを除去
+	userToken := authHeader
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		userToken = authHeader[7:]
+	}
+
+	return userToken, nil
+}
+
+// getIDFromPath はURLパスの指定位置からIDを取得
+func (h *ReportHandler) getIDFromPath(path string, index int) (int64, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) <= index {
+		return 0, shared.NewDomainError("INVALID_PATH", "Invalid ID path")
+	}
+
+	id, err := strconv.ParseInt(parts[index], 10, 64)
+	if err != nil {
+		return 0, shared.NewDomainError("INVALID_ID", "Invalid ID format")
+	}
+
+	return id, nil
+}
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *ReportHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		switch e.Code {
+		case "NOT_FOUND":
+			h.sendError(w, e.Message, http.StatusNotFound)
+		case "FORBIDDEN":
+			h.sendError(w, e.Message, http.StatusForbidden)
+		case "UNAUTHORIZED":
+			h.sendError(w, e.Message, http.StatusUnauthorized)
+		case "REPORT_EXISTS", "INVALID_TRANSITION":
+			h.sendError(w, e.Message, http.StatusConflict)
+		default:
+			h.sendError(w, e.Message, http.StatusInternalServerError)
+		}
+	default:
+		log.Printf("Report usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *ReportHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *ReportHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
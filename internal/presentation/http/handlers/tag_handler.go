@@ -0,0 +1,85 @@
+package handlers
+
+// tag_handler.goはタグに関するHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"Shittaka_back/internal/application/tag/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// TagHandler はタグ関連のHTTPハンドラー
+type TagHandler struct {
+	tagUsecase *usecases.TagUsecase
+}
+
+// NewTagHandler は新しいTagHandlerを作成
+func NewTagHandler(tagUsecase *usecases.TagUsecase) *TagHandler {
+	return &TagHandler{
+		tagUsecase: tagUsecase,
+	}
+}
+
+// GetTagsHandler はタグの候補・問題数の取得を処理 (GET /api/tags?q=&limit=)
+func (h *TagHandler) GetTagsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			h.sendError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	tags, err := h.tagUsecase.SearchTags(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, tags, http.StatusOK)
+}
+
+// ヘルパー関数
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *TagHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		h.sendError(w, e.Message, http.StatusInternalServerError)
+	default:
+		log.Printf("Tag usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *TagHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *TagHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
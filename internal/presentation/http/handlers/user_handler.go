@@ -0,0 +1,102 @@
+package handlers
+
+// user_handler.goはユーザーの公開プロフィールに関するHTTPハンドラーを定義
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"Shittaka_back/internal/application/profile/usecases"
+	"Shittaka_back/internal/domain/shared"
+	presentationDTO "Shittaka_back/internal/presentation/dto"
+)
+
+// UserHandler はユーザー関連のHTTPハンドラー
+type UserHandler struct {
+	profileUsecase *usecases.ProfileUsecase
+}
+
+// NewUserHandler は新しいUserHandlerを作成
+func NewUserHandler(profileUsecase *usecases.ProfileUsecase) *UserHandler {
+	return &UserHandler{
+		profileUsecase: profileUsecase,
+	}
+}
+
+// GetUserProfileHandler はユーザーの公開プロフィール取得を処理 (GET /api/users/{id})
+func (h *UserHandler) GetUserProfileHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// "/api/users/{id}" の形式からユーザーIDを取得
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 {
+		h.sendError(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.profileUsecase.GetProfile(r.Context(), parts[3])
+	if err != nil {
+		h.handleUsecaseError(w, err)
+		return
+	}
+
+	// レスポンスDTOに変換
+	response := presentationDTO.UserProfileResponse{
+		ID:        profile.ID,
+		Username:  profile.Username,
+		AvatarURL: profile.AvatarURL,
+		JoinedAt:  profile.JoinedAt,
+		Stats: presentationDTO.UserStatsResponse{
+			QuestionCount:       profile.Stats.QuestionCount,
+			ReceivedAnswerCount: profile.Stats.ReceivedAnswerCount,
+			ReceivedCorrectRate: profile.Stats.ReceivedCorrectRate,
+			AnswerCount:         profile.Stats.AnswerCount,
+			Accuracy:            profile.Stats.Accuracy,
+		},
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
+
+// ヘルパー関数
+
+// handleUsecaseError はユースケースエラーを適切なHTTPエラーに変換
+func (h *UserHandler) handleUsecaseError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case shared.ValidationError:
+		h.sendError(w, e.Message, http.StatusBadRequest)
+	case shared.DomainError:
+		switch e.Code {
+		case "NOT_FOUND":
+			h.sendError(w, e.Message, http.StatusNotFound)
+		default:
+			h.sendError(w, e.Message, http.StatusInternalServerError)
+		}
+	default:
+		log.Printf("Profile usecase error: %v", err)
+		h.sendError(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// sendJSON はJSONレスポンスを送信
+func (h *UserHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON encode error: %v", err)
+	}
+}
+
+// sendError はエラーレスポンスを送信
+func (h *UserHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := presentationDTO.ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+	}
+	h.sendJSON(w, response, statusCode)
+}
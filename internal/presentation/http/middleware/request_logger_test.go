@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger_PropagatesRequestIDAndLogsRequest(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	var seen string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/questions/", func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("ok"))
+	})
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1"}`))
+	req := httptest.NewRequest(http.MethodPost, "/api/questions/42", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	req.Header.Set("Authorization", "Bearer header."+payload+".signature")
+	rec := httptest.NewRecorder()
+	RequestLogger(logger)(mux).ServeHTTP(rec, req)
+
+	assert.Equal(t, "req-42", seen)
+	assert.Equal(t, "req-42", rec.Header().Get(RequestIDHeader))
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "req-42", line["request_id"])
+	assert.Equal(t, "POST", line["method"])
+	assert.Equal(t, "/api/questions/", line["route"])
+	assert.Equal(t, "/api/questions/42", line["path"])
+	assert.Equal(t, float64(http.StatusCreated), line["status"])
+	assert.Equal(t, "user-1", line["user_id"])
+	assert.Contains(t, line, "latency_ms")
+	assert.NotContains(t, buf.String(), "signature")
+}
+
+func TestRequestLogger_ReplacesInvalidRequestID(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	handler := RequestLogger(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+	req.Header.Set(RequestIDHeader, "bad id\nwith newline")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	id := rec.Header().Get(RequestIDHeader)
+	assert.NotEmpty(t, id)
+	assert.NotEqual(t, "bad id\nwith newline", id)
+}
+
+func TestRequestLogger_KeepsResponseFlushable(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	var flushable bool
+	handler := RequestLogger(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flushable = w.(interface{ Flush() })
+		w.Write([]byte("page"))
+		w.(http.Flusher).Flush()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/questions/export", nil))
+
+	assert.True(t, flushable)
+	assert.True(t, rec.Flushed)
+}
@@ -4,20 +4,21 @@ package router
 
 import (
 	"net/http"
+	"strings"
 
 	"Shittaka_back/internal/presentation/http/handlers"
 	"Shittaka_back/internal/presentation/http/middleware"
 )
 
 // SetupRoutes はルーティングを設定
-func SetupRoutes(authHandler *handlers.AuthHandler, genreHandler *handlers.GenreHandler, questionHandler *handlers.QuestionHandler, answerHandler *handlers.AnswerHandler, choiceHandler *handlers.ChoiceHandler) *http.ServeMux {
+func SetupRoutes(authHandler *handlers.AuthHandler, genreHandler *handlers.GenreHandler, questionHandler *handlers.QuestionHandler, answerHandler *handlers.AnswerHandler, choiceHandler *handlers.ChoiceHandler, commentHandler *handlers.CommentHandler, reportHandler *handlers.ReportHandler, dailyHandler *handlers.DailyHandler, attachmentHandler *handlers.AttachmentHandler, tagHandler *handlers.TagHandler, userHandler *handlers.UserHandler, followHandler *handlers.FollowHandler, bookmarkHandler *handlers.BookmarkHandler, healthHandler *handlers.HealthHandler) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	// 認証関連のエンドポイント
 	mux.HandleFunc("/api/auth/signup", middleware.CORS(authHandler.SignupHandler))
 	mux.HandleFunc("/api/auth/login", middleware.CORS(authHandler.LoginHandler))
 	mux.HandleFunc("/api/auth/logout", middleware.CORS(authHandler.LogoutHandler))
-	mux.HandleFunc("/api/auth/test", middleware.CORS(authHandler.TestConnectionHandler))
+	mux.HandleFunc("/api/auth/test", middleware.CORS(healthHandler.ReadyzHandler)) // 互換のため /readyz と同じ結果を返す
 
 	// ジャンル関連のエンドポイント
 	mux.HandleFunc("/api/genres", middleware.CORS(func(w http.ResponseWriter, r *http.Request) {
@@ -30,6 +31,7 @@ func SetupRoutes(authHandler *handlers.AuthHandler, genreHandler *handlers.Genre
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	}))
+	mux.HandleFunc("/api/genres/", middleware.CORS(followHandler.FollowGenreHandler)) // POST/DELETE /api/genres/{id}/follow
 
 	// 問題関連のエンドポイント
 	mux.HandleFunc("/api/questions", middleware.CORS(func(w http.ResponseWriter, r *http.Request) {
@@ -42,7 +44,70 @@ func SetupRoutes(authHandler *handlers.AuthHandler, genreHandler *handlers.Genre
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	}))
+	mux.HandleFunc("/api/questions/import", middleware.CORS(questionHandler.ImportQuestionsHandler))
+	mux.HandleFunc("/api/questions/export", middleware.CORS(questionHandler.ExportQuestionsHandler))
 	mux.HandleFunc("/api/questions/", middleware.CORS(func(w http.ResponseWriter, r *http.Request) {
+		// GET/POST /api/questions/{id}/comments
+		if strings.HasSuffix(r.URL.Path, "/comments") {
+			switch r.Method {
+			case http.MethodGet:
+				commentHandler.GetCommentsHandler(w, r)
+			case http.MethodPost:
+				commentHandler.CreateCommentHandler(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// GET /api/questions/{id}/revisions
+		// POST /api/questions/{id}/revisions/{revision}/revert
+		if strings.Contains(r.URL.Path, "/revisions") {
+			if strings.HasSuffix(r.URL.Path, "/revert") {
+				questionHandler.RevertQuestionHandler(w, r)
+			} else {
+				questionHandler.GetRevisionsHandler(w, r)
+			}
+			return
+		}
+
+		// POST /api/questions/{id}/publish | unpublish | archive
+		if strings.HasSuffix(r.URL.Path, "/publish") {
+			questionHandler.PublishQuestionHandler(w, r)
+			return
+		}
+		// PUT /api/questions/{id}/schedule
+		if strings.HasSuffix(r.URL.Path, "/schedule") {
+			questionHandler.ScheduleQuestionHandler(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/unpublish") {
+			questionHandler.UnpublishQuestionHandler(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/archive") {
+			questionHandler.ArchiveQuestionHandler(w, r)
+			return
+		}
+
+		// POST/DELETE /api/questions/{id}/bookmark
+		if strings.HasSuffix(r.URL.Path, "/bookmark") {
+			bookmarkHandler.BookmarkQuestionHandler(w, r)
+			return
+		}
+
+		// POST /api/questions/{id}/reports
+		if strings.HasSuffix(r.URL.Path, "/reports") {
+			reportHandler.CreateReportHandler(w, r)
+			return
+		}
+
+		// POST /api/questions/{id}/attachments
+		if strings.HasSuffix(r.URL.Path, "/attachments") {
+			attachmentHandler.UploadAttachmentHandler(w, r)
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			questionHandler.GetQuestionHandler(w, r)
@@ -55,22 +120,85 @@ func SetupRoutes(authHandler *handlers.AuthHandler, genreHandler *handlers.Genre
 		}
 	}))
 	mux.HandleFunc("/api/my-questions", middleware.CORS(questionHandler.GetMyQuestionsHandler))
+	mux.HandleFunc("/api/bookmarks", middleware.CORS(bookmarkHandler.ListBookmarksHandler)) // GET /api/bookmarks?limit=&offset=
 
 	// 回答関連のエンドポイント
 	mux.HandleFunc("/api/answers", middleware.CORS(answerHandler.CreateAnswerHandler))
 
+	// コメント関連のエンドポイント
+	mux.HandleFunc("/api/comments/", middleware.CORS(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			commentHandler.UpdateCommentHandler(w, r)
+		case http.MethodDelete:
+			commentHandler.DeleteCommentHandler(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	// 通報・モデレーション関連のエンドポイント
+	mux.HandleFunc("/api/reports", middleware.CORS(reportHandler.ListReportsHandler))    // GET /api/reports?status=open
+	mux.HandleFunc("/api/reports/", middleware.CORS(reportHandler.ResolveReportHandler)) // PUT /api/reports/{id}
+
+	// 添付画像関連のエンドポイント
+	mux.HandleFunc("/api/attachments/", middleware.CORS(attachmentHandler.DeleteAttachmentHandler)) // DELETE /api/attachments/{id}
+
+	// タグ関連のエンドポイント
+	mux.HandleFunc("/api/tags", middleware.CORS(tagHandler.GetTagsHandler)) // GET /api/tags?q=&limit=
+
+	// フィード（フォローしている作成者・ジャンルの新しい問題）
+	mux.HandleFunc("/api/feed", middleware.CORS(questionHandler.GetFeedHandler)) // GET /api/feed?cursor=&limit=
+
+	// ユーザーの公開プロフィール・フォロー関連のエンドポイント
+	mux.HandleFunc("/api/users/", middleware.CORS(func(w http.ResponseWriter, r *http.Request) {
+		// GET /api/users/{id}/questions
+		if strings.HasSuffix(r.URL.Path, "/questions") {
+			questionHandler.GetUserQuestionsHandler(w, r)
+			return
+		}
+		// POST/DELETE /api/users/{id}/follow
+		if strings.HasSuffix(r.URL.Path, "/follow") {
+			followHandler.FollowUserHandler(w, r)
+			return
+		}
+		// GET /api/users/{id}/followers
+		if strings.HasSuffix(r.URL.Path, "/followers") {
+			followHandler.GetFollowersHandler(w, r)
+			return
+		}
+		// GET /api/users/{id}/following
+		if strings.HasSuffix(r.URL.Path, "/following") {
+			followHandler.GetFollowingHandler(w, r)
+			return
+		}
+		// GET /api/users/{id}
+		userHandler.GetUserProfileHandler(w, r)
+	}))
+
+	// 今日の一問のエンドポイント
+	mux.HandleFunc("/api/daily", middleware.CORS(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			dailyHandler.GetDailyQuestionHandler(w, r)
+		case http.MethodPut:
+			dailyHandler.SetDailyQuestionHandler(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
 	// 選択肢関連のエンドポイント
-	mux.HandleFunc("/api/choices/", middleware.CORS(choiceHandler.GetChoicesHandler))          // GET /api/choices/{questionID}
-	mux.HandleFunc("/api/choices/create", middleware.CORS(choiceHandler.CreateChoiceHandler))  // POST /api/choices/create
-	mux.HandleFunc("/api/choices/update", middleware.CORS(choiceHandler.UpdateChoiceHandler))  // PUT /api/choices/update
-	mux.HandleFunc("/api/choices/delete/", middleware.CORS(choiceHandler.DeleteChoiceHandler)) // DELETE /api/choices/delete/{id}
+	mux.HandleFunc("/api/choices/", middleware.CORS(choiceHandler.GetChoicesHandler))            // GET /api/choices/{questionID}
+	mux.HandleFunc("/api/choices/create", middleware.CORS(choiceHandler.CreateChoiceHandler))    // POST /api/choices/create
+	mux.HandleFunc("/api/choices/update", middleware.CORS(choiceHandler.UpdateChoiceHandler))    // PUT /api/choices/update
+	mux.HandleFunc("/api/choices/delete/", middleware.CORS(choiceHandler.DeleteChoiceHandler))   // DELETE /api/choices/delete/{id}
+	mux.HandleFunc("/api/choices/reorder", middleware.CORS(choiceHandler.ReorderChoicesHandler)) // PUT /api/choices/reorder
 
 	// ヘルスチェック用エンドポイント
-	mux.HandleFunc("/health", middleware.CORS(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status": "ok"}`))
-	}))
+	mux.HandleFunc("/livez", middleware.CORS(healthHandler.LivezHandler))   // プロセスの死活監視
+	mux.HandleFunc("/readyz", middleware.CORS(healthHandler.ReadyzHandler)) // 依存先への接続を含む準備状態
+	mux.HandleFunc("/health", middleware.CORS(healthHandler.LivezHandler))  // 互換のため /livez と同じ結果を返す
 
 	// 静的ファイル配信
 	fs := http.FileServer(http.Dir("./static/"))